@@ -2,9 +2,14 @@
 package all
 
 import (
-	_ "github.com/seastar-consulting/checkers/checks/cloud" // Register cloud checks
-	_ "github.com/seastar-consulting/checkers/checks/git"   // Register git checks
-	_ "github.com/seastar-consulting/checkers/checks/k8s"   // Register k8s checks
-	_ "github.com/seastar-consulting/checkers/checks/os"    // Register os checks
+	_ "github.com/seastar-consulting/checkers/checks/cloud"  // Register cloud checks
+	_ "github.com/seastar-consulting/checkers/checks/db"     // Register db checks
+	_ "github.com/seastar-consulting/checkers/checks/docker" // Register docker checks
+	_ "github.com/seastar-consulting/checkers/checks/git"    // Register git checks
+	_ "github.com/seastar-consulting/checkers/checks/k8s"    // Register k8s checks
+	_ "github.com/seastar-consulting/checkers/checks/logic"  // Register logic checks
+	_ "github.com/seastar-consulting/checkers/checks/net"    // Register net checks
+	_ "github.com/seastar-consulting/checkers/checks/os"     // Register os checks
+	_ "github.com/seastar-consulting/checkers/checks/plugin" // Register the exec check running plugins
 	// Add new check packages here
 )
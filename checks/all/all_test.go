@@ -0,0 +1,41 @@
+package all
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+func TestDocURLs(t *testing.T) {
+	docs, err := os.ReadFile(filepath.Join("..", "..", "docs", "built-in-checks.md"))
+	if err != nil {
+		t.Fatalf("failed to read the documentation of the built-in checks: %v", err)
+	}
+
+	// Every built-in check links to a section of the documentation
+	for _, check := range checks.List() {
+		if check.DocURL == "" {
+			t.Errorf("check %q has no doc URL", check.Name)
+			continue
+		}
+		if check.DocURL != checks.BuiltInDocURL(check.Name) && strings.HasPrefix(check.DocURL, checks.BuiltInDocURL("")) {
+			t.Errorf("check %q links to %s, not to its own section", check.Name, check.DocURL)
+		}
+		if check.DocURL == checks.BuiltInDocURL(check.Name) && !strings.Contains(string(docs), "\n### "+check.Name+"\n") {
+			t.Errorf("check %q links to %s, but the documentation has no section for it", check.Name, check.DocURL)
+		}
+	}
+
+	// Checks of other families are not documented there
+	checks.Register("custom.check", "Check that is not built in", func(item types.CheckItem) (types.CheckResult, error) {
+		return types.CheckResult{}, nil
+	})
+	defer delete(checks.Registry, "custom.check")
+	if check, _ := checks.Get("custom.check"); check.DocURL != "" {
+		t.Errorf("check %q links to %s, want no doc URL", check.Name, check.DocURL)
+	}
+}
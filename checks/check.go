@@ -1,13 +1,64 @@
 package checks
 
-import "github.com/seastar-consulting/checkers/types"
+import (
+	"context"
+	"time"
+
+	"github.com/seastar-consulting/checkers/types"
+)
 
 // CheckFunc is a function that implements a check
 type CheckFunc func(item types.CheckItem) (types.CheckResult, error)
 
+// MultiCheckFunc is a function that implements a check producing a result per object it
+// inspects, e.g. every deployment of a namespace. The name of a result identifies the
+// object and is prefixed with the name of the check by the executor.
+type MultiCheckFunc func(item types.CheckItem) ([]types.CheckResult, error)
+
+// ContextCheckFunc is a function that implements a check which stops when the context is
+// done, e.g. because the check timed out or the run was cancelled. Checks that start
+// processes or other checks use it so these do not outlive the check.
+type ContextCheckFunc func(ctx context.Context, item types.CheckItem) (types.CheckResult, error)
+
+// Parameter types of the check catalog
+const (
+	ParamString   = "string"
+	ParamInt      = "integer"
+	ParamBool     = "boolean"
+	ParamDuration = "duration"
+)
+
+// Parameter describes a parameter accepted by a check. The JSON field names are part
+// of the exported check catalog and must remain stable.
+type Parameter struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Description string   `json:"description"`
+	Required    bool     `json:"required"`
+	Default     string   `json:"default,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+	// Pattern is a regular expression the whole value has to match, e.g. [a-z0-9-]+
+	Pattern string `json:"pattern,omitempty"`
+	// Secret parameters, e.g. passwords, are masked in the dumped configuration and
+	// in the results
+	Secret bool `json:"secret,omitempty"`
+}
+
 // Check represents a registered check
 type Check struct {
 	Name        string
 	Description string
-	Func        CheckFunc
+	Parameters  []Parameter
+	// DefaultTimeout replaces the built-in default timeout for checks of this type, zero if not set
+	DefaultTimeout time.Duration
+	Func           CheckFunc
+	// AnyParameters is set for checks accepting parameters they do not declare, e.g. to
+	// pass them on to a plugin
+	AnyParameters bool
+	// MultiFunc is set instead of Func for checks producing multiple results
+	MultiFunc MultiCheckFunc
+	// ContextFunc is set instead of Func for checks that need the context of the check
+	ContextFunc ContextCheckFunc
+	// DocURL links to the documentation on how to fix failures of this type, if set
+	DocURL string
 }
@@ -1,14 +1,31 @@
 package cloud
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 
@@ -18,29 +35,200 @@ import (
 
 // for testing
 var (
-	newSession = defaultNewSession
-	newSTS     = defaultNewSTS
-	newS3      = defaultNewS3
-	timeNow    = time.Now
+	newSession    = defaultNewSession
+	newSTS        = defaultNewSTS
+	newS3         = defaultNewS3
+	newDynamoDB   = defaultNewDynamoDB
+	newSSM        = defaultNewSSM
+	newCloudWatch = defaultNewCloudWatch
+	newRetryer    = defaultNewRetryer
+	timeNow       = time.Now
+	randomHex     = defaultRandomHex
 )
 
+const (
+	defaultRegion = "us-east-1"
+	// defaultS3KeyPrefix is the prefix of the test objects written by the S3 access check
+	defaultS3KeyPrefix = "access-check/"
+	// defaultS3TestContent is the content of the test objects written by the S3 access check
+	defaultS3TestContent = "test content"
+	// defaultMaxRetries is the number of times a throttled AWS request is retried
+	defaultMaxRetries = 3
+	// minThrottleDelay and maxThrottleDelay bound the exponential backoff of throttled requests
+	minThrottleDelay = 500 * time.Millisecond
+	maxThrottleDelay = 10 * time.Second
+)
+
+// accountIDPattern matches AWS account IDs
+var accountIDPattern = regexp.MustCompile(`^[0-9]{12}$`)
+
+// accessDeniedCodes are the error codes of permission failures, which are never retried
+var accessDeniedCodes = []string{"AccessDenied", "AccessDeniedException", "UnauthorizedOperation"}
+
+// awsParameters are the session parameters accepted by all AWS checks
+var awsParameters = []checks.Parameter{
+	{Name: "aws_profile", Type: checks.ParamString, Description: "AWS profile to use"},
+	{Name: "region", Type: checks.ParamString, Description: "AWS region to use", Default: defaultRegion},
+	{Name: "endpoint", Type: checks.ParamString, Description: "Custom AWS endpoint URL, defaults to the AWS_ENDPOINT_URL environment variable"},
+	{Name: "max_retries", Type: checks.ParamInt, Description: "Number of times a throttled or failed request is retried with exponential backoff", Default: strconv.Itoa(defaultMaxRetries)},
+}
+
 func init() {
-	checks.Register("cloud.aws_authentication", "Verifies AWS authentication and identity", CheckAwsAuthentication)
-	checks.Register("cloud.aws_s3_access", "Verifies read/write access to an S3 bucket", CheckAwsS3Access)
+	checks.Register("cloud.aws_authentication", "Verifies AWS authentication and identity", CheckAwsAuthentication,
+		append([]checks.Parameter{
+			{Name: "identity", Type: checks.ParamString, Description: "Expected ARN of the caller identity, at least one of identity and expected_account is required"},
+			{Name: "expected_account", Type: checks.ParamString, Description: "Expected ID of the account of the caller identity, at least one of identity and expected_account is required", Pattern: `[0-9]{12}`},
+		}, awsParameters...)...)
+	checks.Register("cloud.aws_s3_access", "Verifies read/write access to an S3 bucket", CheckAwsS3Access,
+		append([]checks.Parameter{
+			{Name: "bucket", Type: checks.ParamString, Description: "Name of the S3 bucket", Required: true},
+			{Name: "key", Type: checks.ParamString, Description: "Object to check read access to instead of writing a test object"},
+			{Name: "key_prefix", Type: checks.ParamString, Description: "Prefix of the test objects written to check write access", Default: defaultS3KeyPrefix},
+			{Name: "test_content", Type: checks.ParamString, Description: "Content of the test objects, e.g. to satisfy size limits of the bucket policy", Default: defaultS3TestContent},
+			{Name: "tags", Type: checks.ParamString, Description: "Comma-separated key=value tags of the test objects, e.g. tags required by the bucket policy"},
+			{Name: "storage_class", Type: checks.ParamString, Description: "Storage class of the test objects, e.g. STANDARD_IA, defaults to the storage class of the bucket"},
+		}, awsParameters...)...)
+	checks.Register("cloud.aws_dynamodb_table", "Verifies a DynamoDB table exists and is active", CheckAwsDynamoDBTable,
+		append([]checks.Parameter{
+			{Name: "table_name", Type: checks.ParamString, Description: "Name of the DynamoDB table", Required: true},
+		}, awsParameters...)...)
+	checks.Register("cloud.aws_ssm_parameter", "Verifies an SSM Parameter Store parameter exists and is readable", CheckAwsSSMParameter,
+		append([]checks.Parameter{
+			{Name: "name", Type: checks.ParamString, Description: "Name of the parameter", Required: true},
+			{Name: "with_decryption", Type: checks.ParamBool, Description: "Decrypt SecureString parameters, which requires access to their KMS key", Default: "false"},
+		}, awsParameters...)...)
+	checks.Register("cloud.aws_cloudwatch_alarm", "Verifies a CloudWatch alarm is in the OK state", CheckAwsCloudWatchAlarm,
+		append([]checks.Parameter{
+			{Name: "alarm_name", Type: checks.ParamString, Description: "Name of the metric or composite alarm", Required: true},
+		}, awsParameters...)...)
+}
+
+// sessionConfig holds the options used to create an AWS session
+type sessionConfig struct {
+	profile    string
+	region     string
+	endpoint   string
+	maxRetries int
+}
+
+// throttleRetryer retries throttled requests with exponential backoff, but never retries
+// permission failures, so checks don't keep calling AWS when access is denied
+type throttleRetryer struct {
+	client.DefaultRetryer
+}
+
+// ShouldRetry implements request.Retryer
+func (r throttleRetryer) ShouldRetry(req *request.Request) bool {
+	if r.NumMaxRetries == 0 || isAccessDenied(req.Error) {
+		return false
+	}
+	return req.IsErrorThrottle() || r.DefaultRetryer.ShouldRetry(req)
+}
+
+// isAccessDenied reports whether err is an AWS permission failure
+func isAccessDenied(err error) bool {
+	if reqErr, ok := err.(awserr.RequestFailure); ok && reqErr.StatusCode() == 403 {
+		return true
+	}
+	if aerr, ok := err.(awserr.Error); ok {
+		for _, code := range accessDeniedCodes {
+			if aerr.Code() == code {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func defaultNewRetryer(maxRetries int) request.Retryer {
+	return throttleRetryer{client.DefaultRetryer{
+		NumMaxRetries:    maxRetries,
+		MinThrottleDelay: minThrottleDelay,
+		MaxThrottleDelay: maxThrottleDelay,
+	}}
+}
+
+// defaultRandomHex returns n random bytes encoded as hex
+func defaultRandomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// parseS3Tags converts comma-separated key=value pairs to the URL-encoded tag set of an
+// S3 object
+func parseS3Tags(value string) (string, error) {
+	tags := url.Values{}
+	for _, pair := range strings.Split(value, ",") {
+		key, tagValue, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return "", fmt.Errorf("Invalid value for 'tags' parameter: expected key=value pairs, got '%s'", pair)
+		}
+		tags.Add(strings.TrimSpace(key), strings.TrimSpace(tagValue))
+	}
+	return tags.Encode(), nil
+}
+
+// newSessionConfig builds the session options from the check parameters. The endpoint
+// falls back to the AWS_ENDPOINT_URL environment variable when not set explicitly.
+func newSessionConfig(params map[string]string) (sessionConfig, error) {
+	endpoint := params["endpoint"]
+	if endpoint == "" {
+		endpoint = os.Getenv("AWS_ENDPOINT_URL")
+	}
+	maxRetries := defaultMaxRetries
+	if value, ok := params["max_retries"]; ok {
+		var err error
+		maxRetries, err = strconv.Atoi(value)
+		if err != nil || maxRetries < 0 {
+			return sessionConfig{}, fmt.Errorf("Invalid value for 'max_retries' parameter: %s", value)
+		}
+	}
+	return sessionConfig{
+		profile:    params["aws_profile"],
+		region:     params["region"],
+		endpoint:   endpoint,
+		maxRetries: maxRetries,
+	}, nil
+}
+
+// regionOrDefault returns the region of the session, or the default region if none is set
+func (c sessionConfig) regionOrDefault() string {
+	if c.region == "" {
+		return defaultRegion
+	}
+	return c.region
 }
 
-func defaultNewSession(profile string) (*session.Session, error) {
-	if profile != "" {
-		return session.NewSessionWithOptions(session.Options{
-			Config: aws.Config{
-				Region: aws.String("us-east-1"),
-			},
-			Profile: profile,
+func defaultNewSession(cfg sessionConfig) (*session.Session, error) {
+	awsConfig := aws.Config{
+		Region: aws.String(cfg.regionOrDefault()),
+	}
+	if cfg.endpoint != "" {
+		// Emulators such as LocalStack don't support virtual-hosted bucket addressing
+		awsConfig.Endpoint = aws.String(cfg.endpoint)
+		awsConfig.S3ForcePathStyle = aws.Bool(true)
+	}
+	request.WithRetryer(&awsConfig, newRetryer(cfg.maxRetries))
+
+	var sess *session.Session
+	var err error
+	if cfg.profile != "" {
+		sess, err = session.NewSessionWithOptions(session.Options{
+			Config:  awsConfig,
+			Profile: cfg.profile,
 		})
+	} else {
+		sess, err = session.NewSession(&awsConfig)
 	}
-	return session.NewSession(&aws.Config{
-		Region: aws.String("us-east-1"),
-	})
+	if err != nil {
+		return nil, err
+	}
+	// Every attempt of every request counts against the rate limit shared by all checks
+	sess.Handlers.Send.PushFrontNamed(request.NamedHandler{Name: "checkers.RateLimit", Fn: waitForRateLimit})
+	return sess, nil
 }
 
 func defaultNewSTS(sess *session.Session) stsiface.STSAPI {
@@ -51,23 +239,74 @@ func defaultNewS3(sess *session.Session) s3iface.S3API {
 	return s3.New(sess)
 }
 
+func defaultNewDynamoDB(sess *session.Session) dynamodbiface.DynamoDBAPI {
+	return dynamodb.New(sess)
+}
+
+func defaultNewSSM(sess *session.Session) ssmiface.SSMAPI {
+	return ssm.New(sess)
+}
+
+func defaultNewCloudWatch(sess *session.Session) cloudwatchiface.CloudWatchAPI {
+	return cloudwatch.New(sess)
+}
+
+// identityDetails returns the facts of a caller identity: its ARN, account and the type
+// and name of the principal parsed from the ARN, e.g. "assumed-role" and "deploy"
+func identityDetails(identity *sts.GetCallerIdentityOutput) map[string]interface{} {
+	details := map[string]interface{}{}
+	if identity.Arn == nil {
+		return details
+	}
+	details["arn"] = *identity.Arn
+	if parsed, err := arn.Parse(*identity.Arn); err == nil {
+		details["account"] = parsed.AccountID
+		principalType, rest, _ := strings.Cut(parsed.Resource, "/")
+		details["principal_type"] = principalType
+		if principalName, _, _ := strings.Cut(rest, "/"); principalName != "" {
+			details["principal_name"] = principalName
+		}
+	}
+	if identity.Account != nil && *identity.Account != "" {
+		details["account"] = *identity.Account
+	}
+	return details
+}
+
 // CheckAwsAuthentication verifies the user can authenticate successfully with AWS and has the correct identity as returned by STS.
+// The identity can be checked by its ARN, by its account, e.g. to make sure credentials do not point to
+// production, or both.
 func CheckAwsAuthentication(item types.CheckItem) (types.CheckResult, error) {
-	// Get optional AWS profile
-	awsProfile := item.Parameters["aws_profile"]
-
-	// Get required identity
 	identity := item.Parameters["identity"]
-	if identity == "" {
+	expectedAccount := item.Parameters["expected_account"]
+	if identity == "" && expectedAccount == "" {
 		return types.CheckResult{
 			Name:   item.Name,
 			Type:   item.Type,
 			Status: types.Error,
-			Error:  "identity parameter is required",
+			Error:  "identity or expected_account parameter is required",
+		}, nil
+	}
+	if expectedAccount != "" && !accountIDPattern.MatchString(expectedAccount) {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Invalid value for 'expected_account' parameter: %s (must be a 12-digit account ID)", expectedAccount),
 		}, nil
 	}
 
-	sess, err := newSession(awsProfile)
+	sessCfg, err := newSessionConfig(item.Parameters)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  err.Error(),
+		}, nil
+	}
+
+	sess, err := newSession(sessCfg)
 	if err != nil {
 		return types.CheckResult{
 			Name:   item.Name,
@@ -89,27 +328,46 @@ func CheckAwsAuthentication(item types.CheckItem) (types.CheckResult, error) {
 			Error:  fmt.Sprintf("error calling GetCallerIdentity: %v", err),
 		}, nil
 	}
+	details := identityDetails(stsResult)
 
-	if stsResult.Arn == nil || *stsResult.Arn != identity {
+	// Being authenticated into the wrong account is the more dangerous mistake, so it is reported first
+	if account, _ := details["account"].(string); expectedAccount != "" && account != expectedAccount {
 		return types.CheckResult{
-			Name:   item.Name,
-			Type:   item.Type,
-			Status: types.Failure,
-			Output: fmt.Sprintf("Expected identity '%s', but got '%s'", identity, *stsResult.Arn),
+			Name:     item.Name,
+			Type:     item.Type,
+			Status:   types.Failure,
+			Output:   fmt.Sprintf("Authenticated into AWS account '%s', expected account '%s'", account, expectedAccount),
+			Expected: expectedAccount,
+			Actual:   account,
+			Details:  details,
+		}, nil
+	}
+
+	if identity != "" && (stsResult.Arn == nil || *stsResult.Arn != identity) {
+		return types.CheckResult{
+			Name:     item.Name,
+			Type:     item.Type,
+			Status:   types.Failure,
+			Output:   fmt.Sprintf("Expected identity '%s', but got '%s'", identity, aws.StringValue(stsResult.Arn)),
+			Expected: identity,
+			Actual:   aws.StringValue(stsResult.Arn),
+			Details:  details,
 		}, nil
 	}
 
 	return types.CheckResult{
-		Name:   item.Name,
-		Type:   item.Type,
-		Status: types.Success,
-		Output: fmt.Sprintf("Successfully authenticated with AWS as '%s'", *stsResult.Arn),
+		Name:    item.Name,
+		Type:    item.Type,
+		Status:  types.Success,
+		Output:  fmt.Sprintf("Successfully authenticated with AWS as '%s'", aws.StringValue(stsResult.Arn)),
+		Details: details,
 	}, nil
 }
 
 // CheckAwsS3Access verifies read/write access to an S3 bucket by attempting to put and get an object.
 // If a key is provided, it verifies read access to that key. If not, it creates a new object with
-// a random name, writes to it, and then deletes it.
+// a random name below key_prefix, writes to it, and then deletes it. The content, tags and
+// storage class of the test object can be set for buckets whose policy restricts them.
 func CheckAwsS3Access(item types.CheckItem) (types.CheckResult, error) {
 	// Get required parameters
 	bucket := item.Parameters["bucket"]
@@ -122,11 +380,42 @@ func CheckAwsS3Access(item types.CheckItem) (types.CheckResult, error) {
 		}, nil
 	}
 
-	// Get optional parameters
-	awsProfile := item.Parameters["aws_profile"]
+	// The test objects may have to conform to the bucket policy, e.g. required tags
+	var tagging string
+	if value := item.Parameters["tags"]; value != "" {
+		var err error
+		if tagging, err = parseS3Tags(value); err != nil {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  err.Error(),
+			}, nil
+		}
+	}
+	storageClass := item.Parameters["storage_class"]
+	if storageClass != "" && !slices.Contains(s3.StorageClass_Values(), storageClass) {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error: fmt.Sprintf("Invalid value for 'storage_class' parameter: %s (supported values: %s)",
+				storageClass, strings.Join(s3.StorageClass_Values(), ", ")),
+		}, nil
+	}
 
 	// Create AWS session
-	sess, err := newSession(awsProfile)
+	sessCfg, err := newSessionConfig(item.Parameters)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  err.Error(),
+		}, nil
+	}
+
+	sess, err := newSession(sessCfg)
 	if err != nil {
 		return types.CheckResult{
 			Name:   item.Name,
@@ -138,6 +427,10 @@ func CheckAwsS3Access(item types.CheckItem) (types.CheckResult, error) {
 
 	// Create S3 client
 	svc := newS3(sess)
+	details := map[string]interface{}{
+		"bucket": bucket,
+		"region": sessCfg.regionOrDefault(),
+	}
 
 	// Check if key is provided
 	key := item.Parameters["key"]
@@ -156,31 +449,59 @@ func CheckAwsS3Access(item types.CheckItem) (types.CheckResult, error) {
 			}, nil
 		}
 
+		return types.CheckResult{
+			Name:    item.Name,
+			Type:    item.Type,
+			Status:  types.Success,
+			Output:  fmt.Sprintf("Successfully verified read access to object '%s' in bucket '%s'", key, bucket),
+			Details: details,
+		}, nil
+	}
+
+	// Generate a random key for testing write access. The random suffix keeps concurrent
+	// runs from racing on the same key.
+	prefix := item.Parameters["key_prefix"]
+	if prefix == "" {
+		prefix = defaultS3KeyPrefix
+	} else if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	suffix, err := randomHex(4)
+	if err != nil {
 		return types.CheckResult{
 			Name:   item.Name,
 			Type:   item.Type,
-			Status: types.Success,
-			Output: fmt.Sprintf("Successfully verified read access to object '%s' in bucket '%s'", key, bucket),
+			Status: types.Error,
+			Error:  fmt.Sprintf("error generating test object key: %v", err),
 		}, nil
 	}
-
-	// Generate a random key for testing write access
 	timestamp := timeNow().UTC().Format("20060102-150405.000")
-	testKey := fmt.Sprintf("access-check/%s.txt", timestamp)
+	testKey := fmt.Sprintf("%s%s-%s.txt", prefix, timestamp, suffix)
 
 	// Test write access by putting a small object
-	content := "test content"
-	_, err = svc.PutObject(&s3.PutObjectInput{
+	content, ok := item.Parameters["test_content"]
+	if !ok {
+		content = defaultS3TestContent
+	}
+	input := &s3.PutObjectInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(testKey),
 		Body:   strings.NewReader(content),
-	})
+	}
+	if tagging != "" {
+		input.Tagging = aws.String(tagging)
+	}
+	if storageClass != "" {
+		input.StorageClass = aws.String(storageClass)
+		details["storage_class"] = storageClass
+	}
+	_, err = svc.PutObject(input)
 	if err != nil {
 		return types.CheckResult{
 			Name:   item.Name,
 			Type:   item.Type,
 			Status: types.Failure,
-			Output: fmt.Sprintf("Failed to write to bucket '%s': %v", bucket, err),
+			Output: fmt.Sprintf("Failed to write object '%s' to bucket '%s': %v", testKey, bucket, err),
 		}, nil
 	}
 
@@ -194,7 +515,183 @@ func CheckAwsS3Access(item types.CheckItem) (types.CheckResult, error) {
 			Name:   item.Name,
 			Type:   item.Type,
 			Status: types.Failure,
-			Output: fmt.Sprintf("Failed to delete test object from bucket '%s': %v", bucket, err),
+			Output: fmt.Sprintf("Failed to delete test object '%s' from bucket '%s': %v", testKey, bucket, err),
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:    item.Name,
+		Type:    item.Type,
+		Status:  types.Success,
+		Output:  fmt.Sprintf("Successfully verified write access to bucket '%s'", bucket),
+		Details: details,
+	}, nil
+}
+
+// CheckAwsDynamoDBTable verifies that a DynamoDB table exists and is in the ACTIVE state.
+// Tables that are still being created, updated or deleted are reported as a failure.
+func CheckAwsDynamoDBTable(item types.CheckItem) (types.CheckResult, error) {
+	// Get required parameters
+	tableName := item.Parameters["table_name"]
+	if tableName == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "table_name parameter is required",
+		}, nil
+	}
+
+	// Create AWS session
+	sessCfg, err := newSessionConfig(item.Parameters)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  err.Error(),
+		}, nil
+	}
+
+	sess, err := newSession(sessCfg)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("error creating AWS session: %v", err),
+		}, nil
+	}
+
+	// Create DynamoDB client
+	svc := newDynamoDB(sess)
+
+	out, err := svc.DescribeTable(&dynamodb.DescribeTableInput{
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeResourceNotFoundException {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("DynamoDB table '%s' not found", tableName),
+			}, nil
+		}
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("error calling DescribeTable for table '%s': %v", tableName, err),
+		}, nil
+	}
+
+	status := aws.StringValue(out.Table.TableStatus)
+	itemCount := aws.Int64Value(out.Table.ItemCount)
+	details := map[string]interface{}{
+		"table_arn":  aws.StringValue(out.Table.TableArn),
+		"status":     status,
+		"item_count": itemCount,
+	}
+
+	if status != dynamodb.TableStatusActive {
+		return types.CheckResult{
+			Name:    item.Name,
+			Type:    item.Type,
+			Status:  types.Failure,
+			Output:  fmt.Sprintf("DynamoDB table '%s' is %s (expected %s), item count: %d", tableName, status, dynamodb.TableStatusActive, itemCount),
+			Details: details,
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:    item.Name,
+		Type:    item.Type,
+		Status:  types.Success,
+		Output:  fmt.Sprintf("DynamoDB table '%s' is %s, item count: %d", tableName, status, itemCount),
+		Details: details,
+	}, nil
+}
+
+// CheckAwsSSMParameter verifies that a parameter exists in SSM Parameter Store and can be
+// read by calling GetParameter. The value of the parameter is never included in the output.
+// Denied access is reported as a failure, a missing parameter as an error.
+func CheckAwsSSMParameter(item types.CheckItem) (types.CheckResult, error) {
+	// Get required parameters
+	name := item.Parameters["name"]
+	if name == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "name parameter is required",
+		}, nil
+	}
+
+	withDecryption := false
+	if value, ok := item.Parameters["with_decryption"]; ok {
+		var err error
+		withDecryption, err = strconv.ParseBool(value)
+		if err != nil {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid value for 'with_decryption' parameter: %s", value),
+			}, nil
+		}
+	}
+
+	sessCfg, err := newSessionConfig(item.Parameters)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  err.Error(),
+		}, nil
+	}
+
+	// Create AWS session
+	sess, err := newSession(sessCfg)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("error creating AWS session: %v", err),
+		}, nil
+	}
+
+	// Create SSM client
+	svc := newSSM(sess)
+
+	out, err := svc.GetParameter(&ssm.GetParameterInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(withDecryption),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == ssm.ErrCodeParameterNotFound {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("SSM parameter '%s' not found", name),
+			}, nil
+		}
+		if isAccessDenied(err) {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Failure,
+				Output: fmt.Sprintf("Access to SSM parameter '%s' denied: %v", name, err),
+			}, nil
+		}
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("error calling GetParameter for parameter '%s': %v", name, err),
 		}, nil
 	}
 
@@ -202,6 +699,125 @@ func CheckAwsS3Access(item types.CheckItem) (types.CheckResult, error) {
 		Name:   item.Name,
 		Type:   item.Type,
 		Status: types.Success,
-		Output: fmt.Sprintf("Successfully verified write access to bucket '%s'", bucket),
+		Output: fmt.Sprintf("SSM parameter '%s' of type %s is readable, version: %d",
+			name, aws.StringValue(out.Parameter.Type), aws.Int64Value(out.Parameter.Version)),
+		Details: map[string]interface{}{
+			"arn":     aws.StringValue(out.Parameter.ARN),
+			"type":    aws.StringValue(out.Parameter.Type),
+			"version": aws.Int64Value(out.Parameter.Version),
+		},
+	}, nil
+}
+
+// CheckAwsCloudWatchAlarm reports the state of an existing CloudWatch alarm, so alarms
+// show up in the same report as the other checks. An alarm in the OK state is a success,
+// INSUFFICIENT_DATA a warning and ALARM a failure. Both metric and composite alarms are
+// looked up.
+func CheckAwsCloudWatchAlarm(item types.CheckItem) (types.CheckResult, error) {
+	// Get required parameters
+	alarmName := item.Parameters["alarm_name"]
+	if alarmName == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "alarm_name parameter is required",
+		}, nil
+	}
+
+	sessCfg, err := newSessionConfig(item.Parameters)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  err.Error(),
+		}, nil
+	}
+
+	// Create AWS session
+	sess, err := newSession(sessCfg)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("error creating AWS session: %v", err),
+		}, nil
+	}
+
+	// Create CloudWatch client
+	svc := newCloudWatch(sess)
+
+	out, err := svc.DescribeAlarms(&cloudwatch.DescribeAlarmsInput{
+		AlarmNames: []*string{aws.String(alarmName)},
+		AlarmTypes: aws.StringSlice([]string{cloudwatch.AlarmTypeMetricAlarm, cloudwatch.AlarmTypeCompositeAlarm}),
+	})
+	if err != nil {
+		if isAccessDenied(err) {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Failure,
+				Output: fmt.Sprintf("Access to CloudWatch alarm '%s' denied: %v", alarmName, err),
+			}, nil
+		}
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("error calling DescribeAlarms for alarm '%s': %v", alarmName, err),
+		}, nil
+	}
+
+	var arn, state, reason string
+	var updated *time.Time
+	switch {
+	case len(out.MetricAlarms) > 0:
+		alarm := out.MetricAlarms[0]
+		arn, state, reason, updated = aws.StringValue(alarm.AlarmArn), aws.StringValue(alarm.StateValue),
+			aws.StringValue(alarm.StateReason), alarm.StateUpdatedTimestamp
+	case len(out.CompositeAlarms) > 0:
+		alarm := out.CompositeAlarms[0]
+		arn, state, reason, updated = aws.StringValue(alarm.AlarmArn), aws.StringValue(alarm.StateValue),
+			aws.StringValue(alarm.StateReason), alarm.StateUpdatedTimestamp
+	default:
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("CloudWatch alarm '%s' not found", alarmName),
+		}, nil
+	}
+
+	details := map[string]interface{}{
+		"arn":          arn,
+		"state":        state,
+		"state_reason": reason,
+	}
+	if updated != nil {
+		details["state_updated"] = updated.UTC().Format(time.RFC3339)
+	}
+
+	var status types.CheckStatus
+	switch state {
+	case cloudwatch.StateValueOk:
+		status = types.Success
+	case cloudwatch.StateValueInsufficientData:
+		status = types.Warning
+	default:
+		status = types.Failure
+	}
+
+	output := fmt.Sprintf("CloudWatch alarm '%s' is in state %s", alarmName, state)
+	if reason != "" {
+		output += ": " + reason
+	}
+	return types.CheckResult{
+		Name:    item.Name,
+		Type:    item.Type,
+		Status:  status,
+		Output:  output,
+		Details: details,
 	}, nil
 }
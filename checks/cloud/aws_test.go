@@ -8,9 +8,17 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	"github.com/stretchr/testify/assert"
@@ -20,10 +28,15 @@ import (
 
 // Save original functions for testing
 var (
-	originalNewSession = newSession
-	originalNewSTS     = newSTS
-	originalNewS3      = newS3
-	originalTimeNow    = timeNow
+	originalNewSession    = newSession
+	originalNewSTS        = newSTS
+	originalNewS3         = newS3
+	originalNewDynamoDB   = newDynamoDB
+	originalNewSSM        = newSSM
+	originalNewCloudWatch = newCloudWatch
+	originalTimeNow       = timeNow
+	originalRandomHex     = randomHex
+	originalNewRetryer    = newRetryer
 )
 
 func TestCheckAwsAuthentication(t *testing.T) {
@@ -55,6 +68,76 @@ func TestCheckAwsAuthentication(t *testing.T) {
 				Type:   "cloud.aws_authentication",
 				Status: types.Success,
 				Output: "Successfully authenticated with AWS as 'arn:aws:iam::123456789012:user/test'",
+				Details: map[string]interface{}{
+					"arn":            "arn:aws:iam::123456789012:user/test",
+					"account":        "123456789012",
+					"principal_type": "user",
+					"principal_name": "test",
+				},
+			},
+		},
+		{
+			name: "expected account",
+			checkItem: types.CheckItem{
+				Name: "test-check",
+				Type: "cloud.aws_authentication",
+				Parameters: map[string]string{
+					"expected_account": "123456789012",
+				},
+			},
+			identity: "arn:aws:sts::123456789012:assumed-role/deploy/ci-session",
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_authentication",
+				Status: types.Success,
+				Output: "Successfully authenticated with AWS as 'arn:aws:sts::123456789012:assumed-role/deploy/ci-session'",
+				Details: map[string]interface{}{
+					"arn":            "arn:aws:sts::123456789012:assumed-role/deploy/ci-session",
+					"account":        "123456789012",
+					"principal_type": "assumed-role",
+					"principal_name": "deploy",
+				},
+			},
+		},
+		{
+			name: "wrong account",
+			checkItem: types.CheckItem{
+				Name: "test-check",
+				Type: "cloud.aws_authentication",
+				Parameters: map[string]string{
+					"identity":         "arn:aws:iam::123456789012:root",
+					"expected_account": "123456789012",
+				},
+			},
+			identity: "arn:aws:iam::210987654321:root",
+			want: types.CheckResult{
+				Name:     "test-check",
+				Type:     "cloud.aws_authentication",
+				Status:   types.Failure,
+				Output:   "Authenticated into AWS account '210987654321', expected account '123456789012'",
+				Expected: "123456789012",
+				Actual:   "210987654321",
+				Details: map[string]interface{}{
+					"arn":            "arn:aws:iam::210987654321:root",
+					"account":        "210987654321",
+					"principal_type": "root",
+				},
+			},
+		},
+		{
+			name: "invalid expected account",
+			checkItem: types.CheckItem{
+				Name: "test-check",
+				Type: "cloud.aws_authentication",
+				Parameters: map[string]string{
+					"expected_account": "prod",
+				},
+			},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_authentication",
+				Status: types.Error,
+				Error:  "Invalid value for 'expected_account' parameter: prod (must be a 12-digit account ID)",
 			},
 		},
 		{
@@ -67,11 +150,36 @@ func TestCheckAwsAuthentication(t *testing.T) {
 				},
 			},
 			identity: "arn:aws:iam::123456789012:user/wrong",
+			want: types.CheckResult{
+				Name:     "test-check",
+				Type:     "cloud.aws_authentication",
+				Status:   types.Failure,
+				Output:   "Expected identity 'arn:aws:iam::123456789012:user/test', but got 'arn:aws:iam::123456789012:user/wrong'",
+				Expected: "arn:aws:iam::123456789012:user/test",
+				Actual:   "arn:aws:iam::123456789012:user/wrong",
+				Details: map[string]interface{}{
+					"arn":            "arn:aws:iam::123456789012:user/wrong",
+					"account":        "123456789012",
+					"principal_type": "user",
+					"principal_name": "wrong",
+				},
+			},
+		},
+		{
+			name: "invalid max_retries",
+			checkItem: types.CheckItem{
+				Name: "test-check",
+				Type: "cloud.aws_authentication",
+				Parameters: map[string]string{
+					"identity":    "arn:aws:iam::123456789012:user/test",
+					"max_retries": "-1",
+				},
+			},
 			want: types.CheckResult{
 				Name:   "test-check",
 				Type:   "cloud.aws_authentication",
-				Status: types.Failure,
-				Output: "Expected identity 'arn:aws:iam::123456789012:user/test', but got 'arn:aws:iam::123456789012:user/wrong'",
+				Status: types.Error,
+				Error:  "Invalid value for 'max_retries' parameter: -1",
 			},
 		},
 		{
@@ -85,7 +193,7 @@ func TestCheckAwsAuthentication(t *testing.T) {
 				Name:   "test-check",
 				Type:   "cloud.aws_authentication",
 				Status: types.Error,
-				Error:  "identity parameter is required",
+				Error:  "identity or expected_account parameter is required",
 			},
 		},
 	}
@@ -93,7 +201,7 @@ func TestCheckAwsAuthentication(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Mock AWS session
-			newSession = func(profile string) (*session.Session, error) {
+			newSession = func(cfg sessionConfig) (*session.Session, error) {
 				return &session.Session{}, nil
 			}
 
@@ -122,6 +230,7 @@ func TestCheckAwsS3Access(t *testing.T) {
 		newSession = originalNewSession
 		newS3 = originalNewS3
 		timeNow = originalTimeNow
+		randomHex = originalRandomHex
 	}()
 
 	// Mock time.Now
@@ -129,6 +238,9 @@ func TestCheckAwsS3Access(t *testing.T) {
 	timeNow = func() time.Time {
 		return mockTime
 	}
+	randomHex = func(n int) (string, error) {
+		return "1a2b3c4d", nil
+	}
 
 	tests := []struct {
 		name      string
@@ -137,7 +249,12 @@ func TestCheckAwsS3Access(t *testing.T) {
 		getErr    error
 		deleteErr error
 		want      types.CheckResult
-		wantErr   bool
+		wantKey   string
+		// wantContent, wantTagging and wantStorageClass describe the test object written
+		wantContent      string
+		wantTagging      string
+		wantStorageClass string
+		wantErr          bool
 	}{
 		{
 			name: "successful write access (no key provided)",
@@ -153,7 +270,97 @@ func TestCheckAwsS3Access(t *testing.T) {
 				Type:   "cloud.aws_s3_access",
 				Status: types.Success,
 				Output: "Successfully verified write access to bucket 'test-bucket'",
+				Details: map[string]interface{}{
+					"bucket": "test-bucket",
+					"region": "us-east-1",
+				},
 			},
+			wantKey:     "access-check/20250116-171859.000-1a2b3c4d.txt",
+			wantContent: "test content",
+		},
+		{
+			name: "successful write access with content, tags and storage class",
+			checkItem: types.CheckItem{
+				Name: "test-check",
+				Type: "cloud.aws_s3_access",
+				Parameters: map[string]string{
+					"bucket":        "test-bucket",
+					"test_content":  "{\"check\": true}",
+					"tags":          "team=platform, cost-center=ci & tests",
+					"storage_class": "STANDARD_IA",
+				},
+			},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_s3_access",
+				Status: types.Success,
+				Output: "Successfully verified write access to bucket 'test-bucket'",
+				Details: map[string]interface{}{
+					"bucket":        "test-bucket",
+					"region":        "us-east-1",
+					"storage_class": "STANDARD_IA",
+				},
+			},
+			wantKey:          "access-check/20250116-171859.000-1a2b3c4d.txt",
+			wantContent:      "{\"check\": true}",
+			wantTagging:      "cost-center=ci+%26+tests&team=platform",
+			wantStorageClass: "STANDARD_IA",
+		},
+		{
+			name: "invalid tags",
+			checkItem: types.CheckItem{
+				Name: "test-check",
+				Type: "cloud.aws_s3_access",
+				Parameters: map[string]string{
+					"bucket": "test-bucket",
+					"tags":   "team=platform,ci",
+				},
+			},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_s3_access",
+				Status: types.Error,
+				Error:  "Invalid value for 'tags' parameter: expected key=value pairs, got 'ci'",
+			},
+		},
+		{
+			name: "invalid storage class",
+			checkItem: types.CheckItem{
+				Name: "test-check",
+				Type: "cloud.aws_s3_access",
+				Parameters: map[string]string{
+					"bucket":        "test-bucket",
+					"storage_class": "COLD",
+				},
+			},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_s3_access",
+				Status: types.Error,
+				Error:  "Invalid value for 'storage_class' parameter: COLD (supported values: " + strings.Join(s3.StorageClass_Values(), ", ") + ")",
+			},
+		},
+		{
+			name: "successful write access with key prefix",
+			checkItem: types.CheckItem{
+				Name: "test-check",
+				Type: "cloud.aws_s3_access",
+				Parameters: map[string]string{
+					"bucket":     "test-bucket",
+					"key_prefix": "tmp/checkers",
+				},
+			},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_s3_access",
+				Status: types.Success,
+				Output: "Successfully verified write access to bucket 'test-bucket'",
+				Details: map[string]interface{}{
+					"bucket": "test-bucket",
+					"region": "us-east-1",
+				},
+			},
+			wantKey: "tmp/checkers/20250116-171859.000-1a2b3c4d.txt",
 		},
 		{
 			name: "successful read access (key provided)",
@@ -170,6 +377,10 @@ func TestCheckAwsS3Access(t *testing.T) {
 				Type:   "cloud.aws_s3_access",
 				Status: types.Success,
 				Output: "Successfully verified read access to object 'test-key' in bucket 'test-bucket'",
+				Details: map[string]interface{}{
+					"bucket": "test-bucket",
+					"region": "us-east-1",
+				},
 			},
 		},
 		{
@@ -202,7 +413,7 @@ func TestCheckAwsS3Access(t *testing.T) {
 				Name:   "test-check",
 				Type:   "cloud.aws_s3_access",
 				Status: types.Failure,
-				Output: "Failed to write to bucket 'test-bucket': access denied",
+				Output: "Failed to write object 'access-check/20250116-171859.000-1a2b3c4d.txt' to bucket 'test-bucket': access denied",
 			},
 		},
 		{
@@ -237,7 +448,7 @@ func TestCheckAwsS3Access(t *testing.T) {
 				Name:   "test-check",
 				Type:   "cloud.aws_s3_access",
 				Status: types.Failure,
-				Output: "Failed to delete test object from bucket 'test-bucket': access denied",
+				Output: "Failed to delete test object 'access-check/20250116-171859.000-1a2b3c4d.txt' from bucket 'test-bucket': access denied",
 			},
 		},
 	}
@@ -245,17 +456,18 @@ func TestCheckAwsS3Access(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Mock AWS session
-			newSession = func(profile string) (*session.Session, error) {
+			newSession = func(cfg sessionConfig) (*session.Session, error) {
 				return &session.Session{}, nil
 			}
 
 			// Mock S3 client
+			client := &mockS3Client{
+				putErr:    tt.putErr,
+				getErr:    tt.getErr,
+				deleteErr: tt.deleteErr,
+			}
 			newS3 = func(sess *session.Session) s3iface.S3API {
-				return &mockS3Client{
-					putErr:    tt.putErr,
-					getErr:    tt.getErr,
-					deleteErr: tt.deleteErr,
-				}
+				return client
 			}
 
 			got, err := CheckAwsS3Access(tt.checkItem)
@@ -264,6 +476,598 @@ func TestCheckAwsS3Access(t *testing.T) {
 				return
 			}
 			assert.Equal(t, tt.want, got)
+			if tt.wantKey != "" {
+				assert.Equal(t, tt.wantKey, client.putKey)
+			}
+			if tt.wantContent != "" {
+				assert.Equal(t, tt.wantContent, client.putContent)
+				assert.Equal(t, tt.wantTagging, client.putTagging)
+				assert.Equal(t, tt.wantStorageClass, client.putStorageClass)
+			}
+		})
+	}
+}
+
+func TestCheckAwsDynamoDBTable(t *testing.T) {
+	// Save original functions and restore them after test
+	defer func() {
+		newSession = originalNewSession
+		newDynamoDB = originalNewDynamoDB
+	}()
+
+	tests := []struct {
+		name        string
+		checkItem   types.CheckItem
+		tableStatus string
+		itemCount   int64
+		describeErr error
+		want        types.CheckResult
+		wantErr     bool
+	}{
+		{
+			name: "active table",
+			checkItem: types.CheckItem{
+				Name: "test-check",
+				Type: "cloud.aws_dynamodb_table",
+				Parameters: map[string]string{
+					"table_name": "test-table",
+					"region":     "eu-west-1",
+				},
+			},
+			tableStatus: dynamodb.TableStatusActive,
+			itemCount:   42,
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_dynamodb_table",
+				Status: types.Success,
+				Output: "DynamoDB table 'test-table' is ACTIVE, item count: 42",
+				Details: map[string]interface{}{
+					"table_arn":  "arn:aws:dynamodb:eu-west-1:123456789012:table/test-table",
+					"status":     "ACTIVE",
+					"item_count": int64(42),
+				},
+			},
+		},
+		{
+			name: "table being created",
+			checkItem: types.CheckItem{
+				Name: "test-check",
+				Type: "cloud.aws_dynamodb_table",
+				Parameters: map[string]string{
+					"table_name": "test-table",
+				},
+			},
+			tableStatus: dynamodb.TableStatusCreating,
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_dynamodb_table",
+				Status: types.Failure,
+				Output: "DynamoDB table 'test-table' is CREATING (expected ACTIVE), item count: 0",
+				Details: map[string]interface{}{
+					"table_arn":  "arn:aws:dynamodb:eu-west-1:123456789012:table/test-table",
+					"status":     "CREATING",
+					"item_count": int64(0),
+				},
+			},
+		},
+		{
+			name: "table not found",
+			checkItem: types.CheckItem{
+				Name: "test-check",
+				Type: "cloud.aws_dynamodb_table",
+				Parameters: map[string]string{
+					"table_name": "missing-table",
+				},
+			},
+			describeErr: awserr.New(dynamodb.ErrCodeResourceNotFoundException, "table not found", nil),
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_dynamodb_table",
+				Status: types.Error,
+				Error:  "DynamoDB table 'missing-table' not found",
+			},
+		},
+		{
+			name: "access denied",
+			checkItem: types.CheckItem{
+				Name: "test-check",
+				Type: "cloud.aws_dynamodb_table",
+				Parameters: map[string]string{
+					"table_name": "test-table",
+				},
+			},
+			describeErr: fmt.Errorf("access denied"),
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_dynamodb_table",
+				Status: types.Error,
+				Error:  "error calling DescribeTable for table 'test-table': access denied",
+			},
+		},
+		{
+			name: "missing table name",
+			checkItem: types.CheckItem{
+				Name:       "test-check",
+				Type:       "cloud.aws_dynamodb_table",
+				Parameters: map[string]string{},
+			},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_dynamodb_table",
+				Status: types.Error,
+				Error:  "table_name parameter is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Mock AWS session
+			newSession = func(cfg sessionConfig) (*session.Session, error) {
+				return &session.Session{}, nil
+			}
+
+			// Mock DynamoDB client
+			newDynamoDB = func(sess *session.Session) dynamodbiface.DynamoDBAPI {
+				return &mockDynamoDBClient{
+					describeTableOutput: &dynamodb.DescribeTableOutput{
+						Table: &dynamodb.TableDescription{
+							TableName:   aws.String(tt.checkItem.Parameters["table_name"]),
+							TableArn:    aws.String("arn:aws:dynamodb:eu-west-1:123456789012:table/" + tt.checkItem.Parameters["table_name"]),
+							TableStatus: aws.String(tt.tableStatus),
+							ItemCount:   aws.Int64(tt.itemCount),
+						},
+					},
+					err: tt.describeErr,
+				}
+			}
+
+			got, err := CheckAwsDynamoDBTable(tt.checkItem)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckAwsDynamoDBTable() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCheckAwsSSMParameter(t *testing.T) {
+	// Save original functions and restore them after test
+	defer func() {
+		newSession = originalNewSession
+		newSSM = originalNewSSM
+	}()
+
+	tests := []struct {
+		name               string
+		params             map[string]string
+		getErr             error
+		wantWithDecryption bool
+		want               types.CheckResult
+	}{
+		{
+			name:   "readable parameter",
+			params: map[string]string{"name": "/app/db/host"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_ssm_parameter",
+				Status: types.Success,
+				Output: "SSM parameter '/app/db/host' of type SecureString is readable, version: 3",
+				Details: map[string]interface{}{
+					"arn":     "arn:aws:ssm:us-east-1:123456789012:parameter/app/db/host",
+					"type":    "SecureString",
+					"version": int64(3),
+				},
+			},
+		},
+		{
+			name:               "with decryption",
+			params:             map[string]string{"name": "/app/db/password", "with_decryption": "true"},
+			wantWithDecryption: true,
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_ssm_parameter",
+				Status: types.Success,
+				Output: "SSM parameter '/app/db/password' of type SecureString is readable, version: 3",
+				Details: map[string]interface{}{
+					"arn":     "arn:aws:ssm:us-east-1:123456789012:parameter/app/db/password",
+					"type":    "SecureString",
+					"version": int64(3),
+				},
+			},
+		},
+		{
+			name:   "parameter not found",
+			params: map[string]string{"name": "/app/missing"},
+			getErr: awserr.New(ssm.ErrCodeParameterNotFound, "parameter not found", nil),
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_ssm_parameter",
+				Status: types.Error,
+				Error:  "SSM parameter '/app/missing' not found",
+			},
+		},
+		{
+			name:   "access denied",
+			params: map[string]string{"name": "/app/db/host"},
+			getErr: awserr.New("AccessDeniedException", "not authorized to perform ssm:GetParameter", nil),
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_ssm_parameter",
+				Status: types.Failure,
+				Output: "Access to SSM parameter '/app/db/host' denied: AccessDeniedException: not authorized to perform ssm:GetParameter",
+			},
+		},
+		{
+			name:   "other error",
+			params: map[string]string{"name": "/app/db/host"},
+			getErr: fmt.Errorf("connection reset"),
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_ssm_parameter",
+				Status: types.Error,
+				Error:  "error calling GetParameter for parameter '/app/db/host': connection reset",
+			},
+		},
+		{
+			name:   "invalid with_decryption",
+			params: map[string]string{"name": "/app/db/host", "with_decryption": "maybe"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_ssm_parameter",
+				Status: types.Error,
+				Error:  "Invalid value for 'with_decryption' parameter: maybe",
+			},
+		},
+		{
+			name:   "missing name",
+			params: map[string]string{},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_ssm_parameter",
+				Status: types.Error,
+				Error:  "name parameter is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Mock AWS session
+			newSession = func(cfg sessionConfig) (*session.Session, error) {
+				return &session.Session{}, nil
+			}
+
+			// Mock SSM client
+			client := &mockSSMClient{
+				getParameterOutput: &ssm.GetParameterOutput{
+					Parameter: &ssm.Parameter{
+						Name:    aws.String(tt.params["name"]),
+						ARN:     aws.String("arn:aws:ssm:us-east-1:123456789012:parameter" + tt.params["name"]),
+						Type:    aws.String(ssm.ParameterTypeSecureString),
+						Value:   aws.String("secret"),
+						Version: aws.Int64(3),
+					},
+				},
+				err: tt.getErr,
+			}
+			newSSM = func(sess *session.Session) ssmiface.SSMAPI {
+				return client
+			}
+
+			got, err := CheckAwsSSMParameter(types.CheckItem{
+				Name:       "test-check",
+				Type:       "cloud.aws_ssm_parameter",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NotContains(t, got.Output, "secret")
+			if client.input != nil {
+				assert.Equal(t, tt.wantWithDecryption, aws.BoolValue(client.input.WithDecryption))
+			}
+		})
+	}
+}
+
+func TestCheckAwsCloudWatchAlarm(t *testing.T) {
+	// Save original functions and restore them after test
+	defer func() {
+		newSession = originalNewSession
+		newCloudWatch = originalNewCloudWatch
+	}()
+
+	updated := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	metricAlarm := func(state, reason string) *cloudwatch.DescribeAlarmsOutput {
+		return &cloudwatch.DescribeAlarmsOutput{
+			MetricAlarms: []*cloudwatch.MetricAlarm{{
+				AlarmName:             aws.String("api-5xx"),
+				AlarmArn:              aws.String("arn:aws:cloudwatch:us-east-1:123456789012:alarm:api-5xx"),
+				StateValue:            aws.String(state),
+				StateReason:           aws.String(reason),
+				StateUpdatedTimestamp: &updated,
+			}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		output *cloudwatch.DescribeAlarmsOutput
+		err    error
+		want   types.CheckResult
+	}{
+		{
+			name:   "alarm OK",
+			params: map[string]string{"alarm_name": "api-5xx"},
+			output: metricAlarm(cloudwatch.StateValueOk, "Threshold Crossed: no datapoints were greater than the threshold"),
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_cloudwatch_alarm",
+				Status: types.Success,
+				Output: "CloudWatch alarm 'api-5xx' is in state OK: Threshold Crossed: no datapoints were greater than the threshold",
+				Details: map[string]interface{}{
+					"arn":           "arn:aws:cloudwatch:us-east-1:123456789012:alarm:api-5xx",
+					"state":         "OK",
+					"state_reason":  "Threshold Crossed: no datapoints were greater than the threshold",
+					"state_updated": "2024-03-01T12:00:00Z",
+				},
+			},
+		},
+		{
+			name:   "insufficient data",
+			params: map[string]string{"alarm_name": "api-5xx"},
+			output: metricAlarm(cloudwatch.StateValueInsufficientData, "Unchecked: Initial alarm creation"),
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_cloudwatch_alarm",
+				Status: types.Warning,
+				Output: "CloudWatch alarm 'api-5xx' is in state INSUFFICIENT_DATA: Unchecked: Initial alarm creation",
+				Details: map[string]interface{}{
+					"arn":           "arn:aws:cloudwatch:us-east-1:123456789012:alarm:api-5xx",
+					"state":         "INSUFFICIENT_DATA",
+					"state_reason":  "Unchecked: Initial alarm creation",
+					"state_updated": "2024-03-01T12:00:00Z",
+				},
+			},
+		},
+		{
+			name:   "in alarm",
+			params: map[string]string{"alarm_name": "api-5xx"},
+			output: metricAlarm(cloudwatch.StateValueAlarm, "Threshold Crossed: 1 datapoint [12.0] was greater than the threshold (5.0)"),
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_cloudwatch_alarm",
+				Status: types.Failure,
+				Output: "CloudWatch alarm 'api-5xx' is in state ALARM: Threshold Crossed: 1 datapoint [12.0] was greater than the threshold (5.0)",
+				Details: map[string]interface{}{
+					"arn":           "arn:aws:cloudwatch:us-east-1:123456789012:alarm:api-5xx",
+					"state":         "ALARM",
+					"state_reason":  "Threshold Crossed: 1 datapoint [12.0] was greater than the threshold (5.0)",
+					"state_updated": "2024-03-01T12:00:00Z",
+				},
+			},
+		},
+		{
+			name:   "composite alarm",
+			params: map[string]string{"alarm_name": "service-health"},
+			output: &cloudwatch.DescribeAlarmsOutput{
+				CompositeAlarms: []*cloudwatch.CompositeAlarm{{
+					AlarmName:  aws.String("service-health"),
+					AlarmArn:   aws.String("arn:aws:cloudwatch:us-east-1:123456789012:alarm:service-health"),
+					StateValue: aws.String(cloudwatch.StateValueOk),
+				}},
+			},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_cloudwatch_alarm",
+				Status: types.Success,
+				Output: "CloudWatch alarm 'service-health' is in state OK",
+				Details: map[string]interface{}{
+					"arn":          "arn:aws:cloudwatch:us-east-1:123456789012:alarm:service-health",
+					"state":        "OK",
+					"state_reason": "",
+				},
+			},
+		},
+		{
+			name:   "alarm not found",
+			params: map[string]string{"alarm_name": "missing"},
+			output: &cloudwatch.DescribeAlarmsOutput{},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_cloudwatch_alarm",
+				Status: types.Error,
+				Error:  "CloudWatch alarm 'missing' not found",
+			},
+		},
+		{
+			name:   "access denied",
+			params: map[string]string{"alarm_name": "api-5xx"},
+			err:    awserr.New("AccessDenied", "not authorized to perform cloudwatch:DescribeAlarms", nil),
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_cloudwatch_alarm",
+				Status: types.Failure,
+				Output: "Access to CloudWatch alarm 'api-5xx' denied: AccessDenied: not authorized to perform cloudwatch:DescribeAlarms",
+			},
+		},
+		{
+			name:   "other error",
+			params: map[string]string{"alarm_name": "api-5xx"},
+			err:    fmt.Errorf("connection reset"),
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_cloudwatch_alarm",
+				Status: types.Error,
+				Error:  "error calling DescribeAlarms for alarm 'api-5xx': connection reset",
+			},
+		},
+		{
+			name:   "missing alarm name",
+			params: map[string]string{},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "cloud.aws_cloudwatch_alarm",
+				Status: types.Error,
+				Error:  "alarm_name parameter is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Mock AWS session
+			newSession = func(cfg sessionConfig) (*session.Session, error) {
+				return &session.Session{}, nil
+			}
+
+			// Mock CloudWatch client
+			client := &mockCloudWatchClient{describeAlarmsOutput: tt.output, err: tt.err}
+			newCloudWatch = func(sess *session.Session) cloudwatchiface.CloudWatchAPI {
+				return client
+			}
+
+			got, err := CheckAwsCloudWatchAlarm(types.CheckItem{
+				Name:       "test-check",
+				Type:       "cloud.aws_cloudwatch_alarm",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			if client.input != nil {
+				assert.Equal(t, []string{tt.params["alarm_name"]}, aws.StringValueSlice(client.input.AlarmNames))
+			}
+		})
+	}
+}
+
+func TestNewSessionConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  map[string]string
+		envURL  string
+		want    sessionConfig
+		wantErr string
+	}{
+		{
+			name: "all parameters",
+			params: map[string]string{
+				"aws_profile": "prod",
+				"region":      "eu-west-1",
+				"endpoint":    "http://localhost:4566",
+			},
+			want: sessionConfig{profile: "prod", region: "eu-west-1", endpoint: "http://localhost:4566", maxRetries: defaultMaxRetries},
+		},
+		{
+			name:   "endpoint from environment",
+			params: map[string]string{},
+			envURL: "http://localstack:4566",
+			want:   sessionConfig{endpoint: "http://localstack:4566", maxRetries: defaultMaxRetries},
+		},
+		{
+			name: "parameter takes precedence over environment",
+			params: map[string]string{
+				"endpoint": "http://localhost:4566",
+			},
+			envURL: "http://localstack:4566",
+			want:   sessionConfig{endpoint: "http://localhost:4566", maxRetries: defaultMaxRetries},
+		},
+		{
+			name:   "max retries",
+			params: map[string]string{"max_retries": "0"},
+			want:   sessionConfig{maxRetries: 0},
+		},
+		{
+			name:    "invalid max retries",
+			params:  map[string]string{"max_retries": "many"},
+			wantErr: "Invalid value for 'max_retries' parameter: many",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AWS_ENDPOINT_URL", tt.envURL)
+			got, err := newSessionConfig(tt.params)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDefaultNewSessionEndpoint(t *testing.T) {
+	sess, err := defaultNewSession(sessionConfig{endpoint: "http://localhost:4566"})
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:4566", aws.StringValue(sess.Config.Endpoint))
+	assert.True(t, aws.BoolValue(sess.Config.S3ForcePathStyle))
+	assert.Equal(t, defaultRegion, aws.StringValue(sess.Config.Region))
+}
+
+func TestDefaultNewSessionRetryer(t *testing.T) {
+	defer func() { newRetryer = originalNewRetryer }()
+
+	var gotMaxRetries int
+	newRetryer = func(maxRetries int) request.Retryer {
+		gotMaxRetries = maxRetries
+		return originalNewRetryer(maxRetries)
+	}
+
+	sess, err := defaultNewSession(sessionConfig{maxRetries: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, gotMaxRetries)
+	retryer, ok := sess.Config.Retryer.(request.Retryer)
+	assert.True(t, ok)
+	assert.Equal(t, 5, retryer.MaxRetries())
+}
+
+func TestThrottleRetryer(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries int
+		err        error
+		want       bool
+	}{
+		{
+			name:       "throttling is retried",
+			maxRetries: 3,
+			err:        awserr.New("ThrottlingException", "Rate exceeded", nil),
+			want:       true,
+		},
+		{
+			name:       "access denied is not retried",
+			maxRetries: 3,
+			err:        awserr.New("AccessDenied", "Access Denied", nil),
+			want:       false,
+		},
+		{
+			name:       "forbidden status is not retried",
+			maxRetries: 3,
+			err:        awserr.NewRequestFailure(awserr.New("Forbidden", "Forbidden", nil), 403, "request-id"),
+			want:       false,
+		},
+		{
+			name:       "validation errors are not retried",
+			maxRetries: 3,
+			err:        awserr.New("ValidationError", "Invalid input", nil),
+			want:       false,
+		},
+		{
+			name:       "throttling is not retried without retries",
+			maxRetries: 0,
+			err:        awserr.New("ThrottlingException", "Rate exceeded", nil),
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retryer := defaultNewRetryer(tt.maxRetries)
+			assert.Equal(t, tt.want, retryer.ShouldRetry(&request.Request{Error: tt.err}))
 		})
 	}
 }
@@ -286,9 +1090,19 @@ type mockS3Client struct {
 	putErr    error
 	getErr    error
 	deleteErr error
+	putKey    string
+	// putContent, putTagging and putStorageClass describe the last object written
+	putContent      string
+	putTagging      string
+	putStorageClass string
 }
 
-func (m *mockS3Client) PutObject(*s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+func (m *mockS3Client) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	m.putKey = aws.StringValue(input.Key)
+	content, _ := io.ReadAll(input.Body)
+	m.putContent = string(content)
+	m.putTagging = aws.StringValue(input.Tagging)
+	m.putStorageClass = aws.StringValue(input.StorageClass)
 	if m.putErr != nil {
 		return nil, m.putErr
 	}
@@ -310,3 +1124,46 @@ func (m *mockS3Client) DeleteObject(*s3.DeleteObjectInput) (*s3.DeleteObjectOutp
 	}
 	return &s3.DeleteObjectOutput{}, nil
 }
+
+type mockDynamoDBClient struct {
+	dynamodbiface.DynamoDBAPI
+	describeTableOutput *dynamodb.DescribeTableOutput
+	err                 error
+}
+
+func (m *mockDynamoDBClient) DescribeTable(*dynamodb.DescribeTableInput) (*dynamodb.DescribeTableOutput, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.describeTableOutput, nil
+}
+
+type mockSSMClient struct {
+	ssmiface.SSMAPI
+	getParameterOutput *ssm.GetParameterOutput
+	err                error
+	input              *ssm.GetParameterInput
+}
+
+func (m *mockSSMClient) GetParameter(input *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+	m.input = input
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.getParameterOutput, nil
+}
+
+type mockCloudWatchClient struct {
+	cloudwatchiface.CloudWatchAPI
+	describeAlarmsOutput *cloudwatch.DescribeAlarmsOutput
+	err                  error
+	input                *cloudwatch.DescribeAlarmsInput
+}
+
+func (m *mockCloudWatchClient) DescribeAlarms(input *cloudwatch.DescribeAlarmsInput) (*cloudwatch.DescribeAlarmsOutput, error) {
+	m.input = input
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.describeAlarmsOutput, nil
+}
@@ -0,0 +1,40 @@
+package cloud
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"golang.org/x/time/rate"
+)
+
+var (
+	limiterMu sync.RWMutex
+	// limiter limits the rate of the AWS API calls of all checks, nil when unlimited
+	limiter *rate.Limiter
+)
+
+// SetRateLimit limits the AWS API calls of all checks together, including retries, to
+// perSecond requests per second, allowing bursts of up to one second of requests. Zero
+// removes the limit.
+func SetRateLimit(perSecond float64) {
+	limiterMu.Lock()
+	defer limiterMu.Unlock()
+	if perSecond <= 0 {
+		limiter = nil
+		return
+	}
+	limiter = rate.NewLimiter(rate.Limit(perSecond), max(1, int(perSecond)))
+}
+
+// waitForRateLimit is a send handler delaying requests until the rate limit allows them
+func waitForRateLimit(r *request.Request) {
+	limiterMu.RLock()
+	l := limiter
+	limiterMu.RUnlock()
+	if l == nil {
+		return
+	}
+	if err := l.Wait(r.Context()); err != nil {
+		r.Error = err
+	}
+}
@@ -0,0 +1,54 @@
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForRateLimit(t *testing.T) {
+	defer SetRateLimit(0)
+
+	// Without a limit, requests are never delayed
+	SetRateLimit(0)
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		r := &request.Request{}
+		waitForRateLimit(r)
+		assert.NoError(t, r.Error)
+	}
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	// With 20 requests per second and a burst of 20, the 22nd request waits for two tokens
+	SetRateLimit(20)
+	start = time.Now()
+	for i := 0; i < 22; i++ {
+		r := &request.Request{}
+		waitForRateLimit(r)
+		assert.NoError(t, r.Error)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	// Waiting requests fail when their context is cancelled
+	SetRateLimit(0.1)
+	waitForRateLimit(&request.Request{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	httpReq, err := http.NewRequest(http.MethodPost, "https://sts.amazonaws.com", nil)
+	assert.NoError(t, err)
+	r := &request.Request{HTTPRequest: httpReq}
+	r.SetContext(ctx)
+	waitForRateLimit(r)
+	assert.Error(t, r.Error)
+}
+
+func TestDefaultNewSessionRateLimit(t *testing.T) {
+	sess, err := defaultNewSession(sessionConfig{})
+	assert.NoError(t, err)
+	assert.True(t, sess.Handlers.Send.Swap("checkers.RateLimit",
+		request.NamedHandler{Name: "checkers.RateLimit", Fn: waitForRateLimit}))
+}
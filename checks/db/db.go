@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+const (
+	// defaultQuery is run against a database when no query is configured
+	defaultQuery = "SELECT 1"
+	// queryTimeout limits how long connecting to a database and running the query may take
+	queryTimeout = 10 * time.Second
+)
+
+// runQuery connects to a database and runs the check's query, reporting connection
+// and query errors, such as refused connections or rejected credentials, as a failure
+func runQuery(item types.CheckItem, db *sql.DB, target string) types.CheckResult {
+	defer db.Close()
+
+	query := item.Parameters["query"]
+	if query == "" {
+		query = defaultQuery
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx, query)
+	if err == nil {
+		// Drain the rows so errors returned while streaming results are reported
+		for rows.Next() {
+		}
+		err = rows.Err()
+		rows.Close()
+	}
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("Query '%s' failed on %s: %v", query, target, err),
+		}
+	}
+
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Success,
+		Output: fmt.Sprintf("Query '%s' succeeded on %s", query, target),
+	}
+}
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"net"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// for testing
+var openMySQL = defaultOpenMySQL
+
+func init() {
+	checks.Register("db.mysql", "Verifies a query can be run against a MySQL database", CheckMySQLConnect,
+		checks.Parameter{Name: "dsn", Type: checks.ParamString, Description: "Connection string, e.g. \"user:password@tcp(host:3306)/dbname\", instead of the parameters below", Secret: true},
+		checks.Parameter{Name: "host", Type: checks.ParamString, Description: "Host name or IP address of the database server, required without dsn"},
+		checks.Parameter{Name: "port", Type: checks.ParamInt, Description: "Port of the database server", Default: "3306"},
+		checks.Parameter{Name: "user", Type: checks.ParamString, Description: "User to connect as"},
+		checks.Parameter{Name: "password", Type: checks.ParamString, Description: "Password of the user", Secret: true},
+		checks.Parameter{Name: "dbname", Type: checks.ParamString, Description: "Name of the database to connect to"},
+		checks.Parameter{Name: "tls", Type: checks.ParamString, Description: "TLS mode of the connection, defaults to no TLS",
+			Enum: []string{"true", "false", "skip-verify", "preferred"}},
+		checks.Parameter{Name: "query", Type: checks.ParamString, Description: "Query to run", Default: defaultQuery},
+	)
+}
+
+// defaultOpenMySQL returns a database handle that connects lazily
+func defaultOpenMySQL(dsn string) (*sql.DB, error) {
+	return sql.Open("mysql", dsn)
+}
+
+// mysqlDSN builds a connection string from the host, port, user, password, dbname and
+// tls parameters
+func mysqlDSN(params map[string]string) string {
+	port := params["port"]
+	if port == "" {
+		port = "3306"
+	}
+
+	cfg := mysql.NewConfig()
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(params["host"], port)
+	cfg.User = params["user"]
+	cfg.Passwd = params["password"]
+	cfg.DBName = params["dbname"]
+	cfg.TLSConfig = params["tls"]
+	return cfg.FormatDSN()
+}
+
+// CheckMySQLConnect verifies that a query can be run against a MySQL database
+// Parameters:
+//   - dsn: connection string, e.g. "user:password@tcp(host:3306)/dbname", instead of the parameters below
+//   - host: host name or IP address of the database server
+//   - port: port of the database server, defaults to 3306
+//   - user: user to connect as
+//   - password: password of the user
+//   - dbname: name of the database to connect to
+//   - tls: TLS mode of the connection, e.g. "true", "skip-verify" or "preferred"
+//   - query: query to run, defaults to "SELECT 1"
+func CheckMySQLConnect(item types.CheckItem) (types.CheckResult, error) {
+	dsn := item.Parameters["dsn"]
+	host := item.Parameters["host"]
+	if dsn == "" && host == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "either the dsn or the host parameter is required",
+		}, nil
+	}
+	if dsn != "" && host != "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "the dsn and host parameters cannot be used together",
+		}, nil
+	}
+
+	if dsn == "" {
+		dsn = mysqlDSN(item.Parameters)
+	}
+
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Invalid MySQL connection string: %v", err),
+		}, nil
+	}
+
+	db, err := openMySQL(dsn)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Invalid MySQL connection string: %v", err),
+		}, nil
+	}
+
+	return runQuery(item, db, cfg.Addr+"/"+cfg.DBName), nil
+}
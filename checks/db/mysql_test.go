@@ -0,0 +1,126 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckMySQLConnect(t *testing.T) {
+	// Save original values and restore them after test
+	originalOpenMySQL := openMySQL
+	defer func() { openMySQL = originalOpenMySQL }()
+
+	tests := []struct {
+		name      string
+		params    map[string]string
+		setupMock func(mock sqlmock.Sqlmock)
+		wantDSN   string
+		want      types.CheckResult
+	}{
+		{
+			name:   "default query with discrete parameters",
+			params: map[string]string{"host": "db.example.com", "user": "app", "password": "secret", "dbname": "orders", "tls": "true"},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT 1").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+			},
+			wantDSN: "app:secret@tcp(db.example.com:3306)/orders?tls=true",
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "db.mysql",
+				Status: types.Success,
+				Output: "Query 'SELECT 1' succeeded on db.example.com:3306/orders",
+			},
+		},
+		{
+			name:   "custom query with dsn",
+			params: map[string]string{"dsn": "app:secret@tcp(db.example.com:3307)/orders", "query": "SELECT count(*) FROM orders"},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM orders").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+			},
+			wantDSN: "app:secret@tcp(db.example.com:3307)/orders",
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "db.mysql",
+				Status: types.Success,
+				Output: "Query 'SELECT count(*) FROM orders' succeeded on db.example.com:3307/orders",
+			},
+		},
+		{
+			name:   "authentication rejected",
+			params: map[string]string{"host": "db.example.com", "user": "app", "password": "wrong", "dbname": "orders"},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT 1").WillReturnError(errors.New("Error 1045 (28000): Access denied for user 'app'@'10.0.0.1' (using password: YES)"))
+			},
+			wantDSN: "app:wrong@tcp(db.example.com:3306)/orders",
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "db.mysql",
+				Status: types.Failure,
+				Output: "Query 'SELECT 1' failed on db.example.com:3306/orders: Error 1045 (28000): Access denied for user 'app'@'10.0.0.1' (using password: YES)",
+			},
+		},
+		{
+			name:   "malformed dsn",
+			params: map[string]string{"dsn": "app:secret@db.example.com/orders"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "db.mysql",
+				Status: types.Error,
+				Error:  "Invalid MySQL connection string: default addr for network 'db.example.com' unknown",
+			},
+		},
+		{
+			name:   "missing connection parameters",
+			params: map[string]string{},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "db.mysql",
+				Status: types.Error,
+				Error:  "either the dsn or the host parameter is required",
+			},
+		},
+		{
+			name:   "dsn and host together",
+			params: map[string]string{"dsn": "app@tcp(db.example.com)/orders", "host": "db.example.com"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "db.mysql",
+				Status: types.Error,
+				Error:  "the dsn and host parameters cannot be used together",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.setupMock != nil {
+				tt.setupMock(mock)
+			}
+
+			var gotDSN string
+			openMySQL = func(dsn string) (*sql.DB, error) {
+				gotDSN = dsn
+				return db, nil
+			}
+
+			got, err := CheckMySQLConnect(types.CheckItem{
+				Name:       "test-check",
+				Type:       "db.mysql",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantDSN, gotDSN)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
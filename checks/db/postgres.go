@@ -0,0 +1,115 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/lib/pq"
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// for testing
+var openPostgres = defaultOpenPostgres
+
+func init() {
+	checks.Register("db.postgres", "Verifies a query can be run against a PostgreSQL database", CheckPostgresConnect,
+		checks.Parameter{Name: "dsn", Type: checks.ParamString, Description: "Connection string, either a URL or key/value pairs, instead of the parameters below", Secret: true},
+		checks.Parameter{Name: "host", Type: checks.ParamString, Description: "Host name or IP address of the database server, required without dsn"},
+		checks.Parameter{Name: "port", Type: checks.ParamInt, Description: "Port of the database server", Default: "5432"},
+		checks.Parameter{Name: "user", Type: checks.ParamString, Description: "User to connect as"},
+		checks.Parameter{Name: "password", Type: checks.ParamString, Description: "Password of the user", Secret: true},
+		checks.Parameter{Name: "dbname", Type: checks.ParamString, Description: "Name of the database to connect to"},
+		checks.Parameter{Name: "sslmode", Type: checks.ParamString, Description: "SSL mode of the connection", Default: "require",
+			Enum: []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}},
+		checks.Parameter{Name: "query", Type: checks.ParamString, Description: "Query to run", Default: defaultQuery},
+	)
+}
+
+// defaultOpenPostgres parses the DSN and returns a database handle that connects lazily
+func defaultOpenPostgres(dsn string) (*sql.DB, error) {
+	connector, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(connector), nil
+}
+
+// postgresDSN builds a connection URL from the host, port, user, password, dbname and
+// sslmode parameters and returns it with a description of the target without credentials
+func postgresDSN(params map[string]string) (string, string) {
+	port := params["port"]
+	if port == "" {
+		port = "5432"
+	}
+
+	u := url.URL{
+		Scheme: "postgres",
+		Host:   net.JoinHostPort(params["host"], port),
+		Path:   "/" + params["dbname"],
+	}
+	if user := params["user"]; user != "" {
+		if password, ok := params["password"]; ok {
+			u.User = url.UserPassword(user, password)
+		} else {
+			u.User = url.User(user)
+		}
+	}
+	if sslmode := params["sslmode"]; sslmode != "" {
+		u.RawQuery = url.Values{"sslmode": {sslmode}}.Encode()
+	}
+
+	return u.String(), u.Host + u.Path
+}
+
+// CheckPostgresConnect verifies that a query can be run against a PostgreSQL database
+// Parameters:
+//   - dsn: connection string, either a URL or key/value pairs, instead of the parameters below
+//   - host: host name or IP address of the database server
+//   - port: port of the database server, defaults to 5432
+//   - user: user to connect as
+//   - password: password of the user
+//   - dbname: name of the database to connect to
+//   - sslmode: SSL mode of the connection, e.g. "disable" or "verify-full"
+//   - query: query to run, defaults to "SELECT 1"
+func CheckPostgresConnect(item types.CheckItem) (types.CheckResult, error) {
+	dsn := item.Parameters["dsn"]
+	host := item.Parameters["host"]
+	if dsn == "" && host == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "either the dsn or the host parameter is required",
+		}, nil
+	}
+	if dsn != "" && host != "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "the dsn and host parameters cannot be used together",
+		}, nil
+	}
+
+	target := "the configured database"
+	if dsn == "" {
+		dsn, target = postgresDSN(item.Parameters)
+	} else if u, err := url.Parse(dsn); err == nil && u.Host != "" {
+		target = u.Host + u.Path
+	}
+
+	db, err := openPostgres(dsn)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Invalid PostgreSQL connection string: %v", err),
+		}, nil
+	}
+
+	return runQuery(item, db, target), nil
+}
@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// redisClient is the subset of the Redis client used by the check
+type redisClient interface {
+	Ping(ctx context.Context) *redis.StatusCmd
+	Exists(ctx context.Context, keys ...string) *redis.IntCmd
+	Close() error
+}
+
+// for testing
+var newRedisClient = func(opts *redis.Options) redisClient {
+	return redis.NewClient(opts)
+}
+
+func init() {
+	checks.Register("db.redis", "Verifies a Redis server responds to PING", CheckRedisPing,
+		checks.Parameter{Name: "address", Type: checks.ParamString, Description: "Host and port of the server, e.g. \"localhost:6379\"", Required: true},
+		checks.Parameter{Name: "username", Type: checks.ParamString, Description: "ACL user to authenticate as"},
+		checks.Parameter{Name: "password", Type: checks.ParamString, Description: "Password to authenticate with", Secret: true},
+		checks.Parameter{Name: "db", Type: checks.ParamInt, Description: "Index of the database to select", Default: "0"},
+		checks.Parameter{Name: "key", Type: checks.ParamString, Description: "Key that must exist in the database"},
+	)
+}
+
+// redisErrorResult reports errors returned by the server, such as rejected credentials,
+// as a failure and all other errors, such as connection problems, as an error
+func redisErrorResult(item types.CheckItem, address string, err error) types.CheckResult {
+	var serverErr redis.Error
+	if errors.As(err, &serverErr) {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("Redis server %s returned an error: %v", address, err),
+		}
+	}
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Error,
+		Error:  fmt.Sprintf("Failed to connect to Redis server %s: %v", address, err),
+	}
+}
+
+// CheckRedisPing verifies that a Redis server responds to PING
+// Parameters:
+//   - address: host and port of the server, e.g. "localhost:6379"
+//   - username: ACL user to authenticate as
+//   - password: password to authenticate with
+//   - db: index of the database to select, defaults to 0
+//   - key: key that must exist in the database
+func CheckRedisPing(item types.CheckItem) (types.CheckResult, error) {
+	address := item.Parameters["address"]
+	if address == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "address parameter is required",
+		}, nil
+	}
+
+	db := 0
+	if dbStr, ok := item.Parameters["db"]; ok {
+		var err error
+		db, err = strconv.Atoi(dbStr)
+		if err != nil || db < 0 {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid value for 'db' parameter: %s", dbStr),
+			}, nil
+		}
+	}
+
+	client := newRedisClient(&redis.Options{
+		Addr:        address,
+		Username:    item.Parameters["username"],
+		Password:    item.Parameters["password"],
+		DB:          db,
+		DialTimeout: queryTimeout,
+		MaxRetries:  -1,
+	})
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	pong, err := client.Ping(ctx).Result()
+	if err != nil {
+		return redisErrorResult(item, address, err), nil
+	}
+
+	key, ok := item.Parameters["key"]
+	if !ok || key == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Success,
+			Output: fmt.Sprintf("Redis server %s replied %s", address, pong),
+		}, nil
+	}
+
+	count, err := client.Exists(ctx, key).Result()
+	if err != nil {
+		return redisErrorResult(item, address, err), nil
+	}
+	if count == 0 {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("Key '%s' does not exist in database %d of Redis server %s", key, db, address),
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Success,
+		Output: fmt.Sprintf("Redis server %s replied %s and key '%s' exists in database %d", address, pong, key, db),
+	}, nil
+}
@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockRedisClient is a mock implementation of redisClient
+type mockRedisClient struct {
+	pingErr     error
+	existsCount int64
+	existsErr   error
+	existsKeys  []string
+}
+
+func (m *mockRedisClient) Ping(ctx context.Context) *redis.StatusCmd {
+	return redis.NewStatusResult("PONG", m.pingErr)
+}
+
+func (m *mockRedisClient) Exists(ctx context.Context, keys ...string) *redis.IntCmd {
+	m.existsKeys = keys
+	return redis.NewIntResult(m.existsCount, m.existsErr)
+}
+
+func (m *mockRedisClient) Close() error {
+	return nil
+}
+
+// serverError is an error returned by a Redis server
+type serverError string
+
+func (e serverError) Error() string { return string(e) }
+func (e serverError) RedisError()   {}
+
+func TestCheckRedisPing(t *testing.T) {
+	// Save original values and restore them after test
+	originalNewRedisClient := newRedisClient
+	defer func() { newRedisClient = originalNewRedisClient }()
+
+	tests := []struct {
+		name       string
+		params     map[string]string
+		client     *mockRedisClient
+		wantOpts   *redis.Options
+		wantExists []string
+		want       types.CheckResult
+	}{
+		{
+			name:     "ping succeeds",
+			params:   map[string]string{"address": "cache:6379", "password": "secret", "db": "2"},
+			client:   &mockRedisClient{},
+			wantOpts: &redis.Options{Addr: "cache:6379", Password: "secret", DB: 2, DialTimeout: queryTimeout, MaxRetries: -1},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "db.redis",
+				Status: types.Success,
+				Output: "Redis server cache:6379 replied PONG",
+			},
+		},
+		{
+			name:       "key exists",
+			params:     map[string]string{"address": "cache:6379", "key": "jobs:queue"},
+			client:     &mockRedisClient{existsCount: 1},
+			wantExists: []string{"jobs:queue"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "db.redis",
+				Status: types.Success,
+				Output: "Redis server cache:6379 replied PONG and key 'jobs:queue' exists in database 0",
+			},
+		},
+		{
+			name:       "key missing",
+			params:     map[string]string{"address": "cache:6379", "key": "jobs:queue"},
+			client:     &mockRedisClient{existsCount: 0},
+			wantExists: []string{"jobs:queue"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "db.redis",
+				Status: types.Failure,
+				Output: "Key 'jobs:queue' does not exist in database 0 of Redis server cache:6379",
+			},
+		},
+		{
+			name:   "authentication rejected",
+			params: map[string]string{"address": "cache:6379", "password": "wrong"},
+			client: &mockRedisClient{pingErr: serverError("WRONGPASS invalid username-password pair or user is disabled.")},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "db.redis",
+				Status: types.Failure,
+				Output: "Redis server cache:6379 returned an error: WRONGPASS invalid username-password pair or user is disabled.",
+			},
+		},
+		{
+			name:   "connection refused",
+			params: map[string]string{"address": "cache:6379"},
+			client: &mockRedisClient{pingErr: errors.New("dial tcp 10.0.0.1:6379: connect: connection refused")},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "db.redis",
+				Status: types.Error,
+				Error:  "Failed to connect to Redis server cache:6379: dial tcp 10.0.0.1:6379: connect: connection refused",
+			},
+		},
+		{
+			name:   "missing address",
+			params: map[string]string{},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "db.redis",
+				Status: types.Error,
+				Error:  "address parameter is required",
+			},
+		},
+		{
+			name:   "invalid db",
+			params: map[string]string{"address": "cache:6379", "db": "first"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "db.redis",
+				Status: types.Error,
+				Error:  "Invalid value for 'db' parameter: first",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOpts *redis.Options
+			newRedisClient = func(opts *redis.Options) redisClient {
+				gotOpts = opts
+				return tt.client
+			}
+
+			got, err := CheckRedisPing(types.CheckItem{
+				Name:       "test-check",
+				Type:       "db.redis",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			if tt.wantOpts != nil {
+				assert.Equal(t, tt.wantOpts, gotOpts)
+			}
+			if tt.client != nil {
+				assert.Equal(t, tt.wantExists, tt.client.existsKeys)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultHost is the address of the Docker daemon when neither the host parameter
+	// nor DOCKER_HOST is set
+	defaultHost = "unix:///var/run/docker.sock"
+	// apiTimeout limits how long a request to the Docker daemon may take
+	apiTimeout = 10 * time.Second
+)
+
+// errNotFound is returned by the client when the requested object does not exist
+var errNotFound = errors.New("not found")
+
+// containerInfo is the subset of the container details returned by the Docker API
+type containerInfo struct {
+	ID    string `json:"Id"`
+	Name  string `json:"Name"`
+	State struct {
+		Status string `json:"Status"`
+		Health *struct {
+			Status string `json:"Status"`
+		} `json:"Health"`
+	} `json:"State"`
+	Config struct {
+		Image string `json:"Image"`
+	} `json:"Config"`
+}
+
+// imageInfo is the subset of the image details returned by the Docker API
+type imageInfo struct {
+	ID          string   `json:"Id"`
+	RepoTags    []string `json:"RepoTags"`
+	RepoDigests []string `json:"RepoDigests"`
+}
+
+// dockerClient is the subset of the Docker API used by the checks
+type dockerClient interface {
+	InspectContainer(ctx context.Context, id string) (containerInfo, error)
+	InspectImage(ctx context.Context, image string) (imageInfo, error)
+}
+
+// for testing
+var newDockerClient = func(host string) (dockerClient, error) {
+	return newAPIClient(host)
+}
+
+// apiClient talks to the Docker Engine API over a unix socket or TCP
+type apiClient struct {
+	http    *http.Client
+	baseURL string
+}
+
+// resolveHost returns the address of the Docker daemon, falling back to DOCKER_HOST
+// and the default socket
+func resolveHost(host string) string {
+	if host != "" {
+		return host
+	}
+	if env := os.Getenv("DOCKER_HOST"); env != "" {
+		return env
+	}
+	return defaultHost
+}
+
+// newAPIClient creates a client for a daemon address such as unix:///var/run/docker.sock
+// or tcp://localhost:2375
+func newAPIClient(host string) (*apiClient, error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Docker host %q: %v", host, err)
+	}
+
+	transport := &http.Transport{}
+	baseURL := ""
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socket)
+		}
+		// The host name is ignored when dialing the socket
+		baseURL = "http://docker"
+	case "tcp", "http":
+		baseURL = "http://" + u.Host
+	default:
+		return nil, fmt.Errorf("invalid Docker host %q: unsupported scheme %q (supported: unix, tcp)", host, u.Scheme)
+	}
+
+	return &apiClient{
+		http:    &http.Client{Transport: transport, Timeout: apiTimeout},
+		baseURL: baseURL,
+	}, nil
+}
+
+// get requests a path of the API and decodes the JSON response into v. A 404 response
+// is returned as errNotFound.
+func (c *apiClient) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		// The daemon describes errors in a message field
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("daemon returned %s: %s", resp.Status, apiErr.Message)
+		}
+		return fmt.Errorf("daemon returned %s", resp.Status)
+	}
+	return json.Unmarshal(body, v)
+}
+
+// InspectContainer returns the details of a container by name or ID
+func (c *apiClient) InspectContainer(ctx context.Context, id string) (containerInfo, error) {
+	var info containerInfo
+	err := c.get(ctx, "/containers/"+url.PathEscape(id)+"/json", &info)
+	// Names are reported with a leading slash
+	info.Name = strings.TrimPrefix(info.Name, "/")
+	return info, err
+}
+
+// InspectImage returns the details of a local image by reference, e.g. nginx:1.27,
+// or ID
+func (c *apiClient) InspectImage(ctx context.Context, image string) (imageInfo, error) {
+	// References of images in registries contain slashes, which are part of the path
+	segments := strings.Split(image, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	var info imageInfo
+	err := c.get(ctx, "/images/"+strings.Join(segments, "/")+"/json", &info)
+	return info, err
+}
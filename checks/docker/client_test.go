@@ -0,0 +1,112 @@
+package docker
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeDaemon serves the container, image and error responses of the Docker API
+func newFakeDaemon() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/containers/web/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Id": "4f1c2d3e", "Name": "/web", "State": {"Status": "running", "Health": {"Status": "healthy"}}, "Config": {"Image": "nginx:1.27"}}`))
+	})
+	mux.HandleFunc("/images/ghcr.io/acme/api:1.0/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Id": "sha256:0d2c", "RepoTags": ["ghcr.io/acme/api:1.0"], "RepoDigests": ["ghcr.io/acme/api@sha256:9f8e"]}`))
+	})
+	mux.HandleFunc("/containers/broken/json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"message": "storage driver failed"}`))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "No such container"}`))
+	})
+	return mux
+}
+
+func TestAPIClient_InspectContainer(t *testing.T) {
+	server := httptest.NewServer(newFakeDaemon())
+	defer server.Close()
+
+	client, err := newAPIClient("tcp://" + strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := client.InspectContainer(context.Background(), "web")
+	assert.NoError(t, err)
+	assert.Equal(t, "4f1c2d3e", info.ID)
+	assert.Equal(t, "web", info.Name)
+	assert.Equal(t, "running", info.State.Status)
+	assert.Equal(t, "healthy", info.State.Health.Status)
+	assert.Equal(t, "nginx:1.27", info.Config.Image)
+
+	_, err = client.InspectContainer(context.Background(), "missing")
+	assert.ErrorIs(t, err, errNotFound)
+
+	_, err = client.InspectContainer(context.Background(), "broken")
+	assert.EqualError(t, err, "daemon returned 500 Internal Server Error: storage driver failed")
+}
+
+func TestAPIClient_InspectImage(t *testing.T) {
+	server := httptest.NewServer(newFakeDaemon())
+	defer server.Close()
+
+	client, err := newAPIClient("tcp://" + strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := client.InspectImage(context.Background(), "ghcr.io/acme/api:1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, imageInfo{
+		ID:          "sha256:0d2c",
+		RepoTags:    []string{"ghcr.io/acme/api:1.0"},
+		RepoDigests: []string{"ghcr.io/acme/api@sha256:9f8e"},
+	}, info)
+
+	_, err = client.InspectImage(context.Background(), "ghcr.io/acme/api:2.0")
+	assert.ErrorIs(t, err, errNotFound)
+}
+
+func TestAPIClient_UnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "docker.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("unix sockets are not supported: %v", err)
+	}
+	server := httptest.NewUnstartedServer(newFakeDaemon())
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	client, err := newAPIClient("unix://" + socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := client.InspectContainer(context.Background(), "web")
+	assert.NoError(t, err)
+	assert.Equal(t, "running", info.State.Status)
+}
+
+func TestNewAPIClient_InvalidHost(t *testing.T) {
+	_, err := newAPIClient("ssh://user@remote")
+	assert.EqualError(t, err, `invalid Docker host "ssh://user@remote": unsupported scheme "ssh" (supported: unix, tcp)`)
+}
+
+func TestResolveHost(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "")
+	assert.Equal(t, defaultHost, resolveHost(""))
+	assert.Equal(t, "tcp://localhost:2375", resolveHost("tcp://localhost:2375"))
+
+	t.Setenv("DOCKER_HOST", "unix:///run/user/1000/docker.sock")
+	assert.Equal(t, "unix:///run/user/1000/docker.sock", resolveHost(""))
+}
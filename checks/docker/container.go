@@ -0,0 +1,123 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// containerStatuses are the states a container can be in
+var containerStatuses = []string{"created", "running", "paused", "restarting", "removing", "exited", "dead"}
+
+func init() {
+	checks.Register("docker.container_running", "Verifies a Docker container exists and is in the expected state", CheckContainerRunning,
+		checks.Parameter{Name: "name", Type: checks.ParamString, Description: "Name of the container, required unless id is set"},
+		checks.Parameter{Name: "id", Type: checks.ParamString, Description: "ID of the container, required unless name is set"},
+		checks.Parameter{Name: "expected_status", Type: checks.ParamString, Description: "Expected state of the container", Default: "running",
+			Enum: containerStatuses},
+		checks.Parameter{Name: "host", Type: checks.ParamString, Description: "Address of the Docker daemon, defaults to DOCKER_HOST or " + defaultHost},
+	)
+}
+
+// CheckContainerRunning verifies that a Docker container exists and is in the expected
+// state. A missing container or a container in another state is a failure, an
+// unreachable daemon is an error.
+// Parameters:
+//   - name: name of the container
+//   - id: ID of the container, either name or id is required
+//   - expected_status: expected state, defaults to running
+//   - host: address of the Docker daemon, defaults to DOCKER_HOST or the local socket
+func CheckContainerRunning(item types.CheckItem) (types.CheckResult, error) {
+	container := item.Parameters["name"]
+	if container == "" {
+		container = item.Parameters["id"]
+	}
+	if container == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "name or id parameter is required",
+		}, nil
+	}
+
+	expected := strings.ToLower(item.Parameters["expected_status"])
+	if expected == "" {
+		expected = "running"
+	}
+	if !slices.Contains(containerStatuses, expected) {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error: fmt.Sprintf("Invalid value for 'expected_status' parameter: %s (supported: %s)",
+				item.Parameters["expected_status"], strings.Join(containerStatuses, ", ")),
+		}, nil
+	}
+
+	host := resolveHost(item.Parameters["host"])
+	client, err := newDockerClient(host)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  err.Error(),
+		}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	defer cancel()
+	info, err := client.InspectContainer(ctx, container)
+	if errors.Is(err, errNotFound) {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("Container '%s' does not exist", container),
+		}, nil
+	}
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Failed to inspect container '%s' on Docker daemon %s: %v", container, host, err),
+		}, nil
+	}
+
+	details := map[string]interface{}{
+		"id":     info.ID,
+		"name":   info.Name,
+		"image":  info.Config.Image,
+		"status": info.State.Status,
+	}
+	if info.State.Health != nil {
+		details["health"] = info.State.Health.Status
+	}
+
+	if info.State.Status != expected {
+		return types.CheckResult{
+			Name:     item.Name,
+			Type:     item.Type,
+			Status:   types.Failure,
+			Output:   fmt.Sprintf("Container '%s' is %s, expected %s", container, info.State.Status, expected),
+			Expected: expected,
+			Actual:   info.State.Status,
+			Details:  details,
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:    item.Name,
+		Type:    item.Type,
+		Status:  types.Success,
+		Output:  fmt.Sprintf("Container '%s' is %s", container, info.State.Status),
+		Details: details,
+	}, nil
+}
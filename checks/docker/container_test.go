@@ -0,0 +1,189 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClient returns fixed containers and images by name
+type fakeClient struct {
+	containers map[string]containerInfo
+	images     map[string]imageInfo
+	err        error
+}
+
+func (c *fakeClient) InspectContainer(ctx context.Context, id string) (containerInfo, error) {
+	if c.err != nil {
+		return containerInfo{}, c.err
+	}
+	info, ok := c.containers[id]
+	if !ok {
+		return containerInfo{}, errNotFound
+	}
+	return info, nil
+}
+
+func (c *fakeClient) InspectImage(ctx context.Context, image string) (imageInfo, error) {
+	if c.err != nil {
+		return imageInfo{}, c.err
+	}
+	info, ok := c.images[image]
+	if !ok {
+		return imageInfo{}, errNotFound
+	}
+	return info, nil
+}
+
+// newContainer creates the details of a container in the given state
+func newContainer(id, name, image, status string) containerInfo {
+	var info containerInfo
+	info.ID = id
+	info.Name = name
+	info.Config.Image = image
+	info.State.Status = status
+	return info
+}
+
+func TestCheckContainerRunning(t *testing.T) {
+	web := newContainer("4f1c2d3e", "web", "nginx:1.27", "running")
+	web.State.Health = &struct {
+		Status string `json:"Status"`
+	}{Status: "healthy"}
+	client := &fakeClient{containers: map[string]containerInfo{
+		"web":      web,
+		"4f1c2d3e": web,
+		"migrate":  newContainer("9a8b7c6d", "migrate", "app:latest", "exited"),
+	}}
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		client *fakeClient
+		want   types.CheckResult
+	}{
+		{
+			name:   "running by name",
+			params: map[string]string{"name": "web"},
+			client: client,
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "docker.container_running",
+				Status: types.Success,
+				Output: "Container 'web' is running",
+				Details: map[string]interface{}{
+					"id": "4f1c2d3e", "name": "web", "image": "nginx:1.27", "status": "running", "health": "healthy",
+				},
+			},
+		},
+		{
+			name:   "running by id",
+			params: map[string]string{"id": "4f1c2d3e"},
+			client: client,
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "docker.container_running",
+				Status: types.Success,
+				Output: "Container '4f1c2d3e' is running",
+				Details: map[string]interface{}{
+					"id": "4f1c2d3e", "name": "web", "image": "nginx:1.27", "status": "running", "health": "healthy",
+				},
+			},
+		},
+		{
+			name:   "expected status",
+			params: map[string]string{"name": "migrate", "expected_status": "Exited"},
+			client: client,
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "docker.container_running",
+				Status: types.Success,
+				Output: "Container 'migrate' is exited",
+				Details: map[string]interface{}{
+					"id": "9a8b7c6d", "name": "migrate", "image": "app:latest", "status": "exited",
+				},
+			},
+		},
+		{
+			name:   "unexpected status",
+			params: map[string]string{"name": "migrate"},
+			client: client,
+			want: types.CheckResult{
+				Name:     "test-check",
+				Type:     "docker.container_running",
+				Status:   types.Failure,
+				Output:   "Container 'migrate' is exited, expected running",
+				Expected: "running",
+				Actual:   "exited",
+				Details: map[string]interface{}{
+					"id": "9a8b7c6d", "name": "migrate", "image": "app:latest", "status": "exited",
+				},
+			},
+		},
+		{
+			name:   "missing container",
+			params: map[string]string{"name": "db"},
+			client: client,
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "docker.container_running",
+				Status: types.Failure,
+				Output: "Container 'db' does not exist",
+			},
+		},
+		{
+			name:   "daemon unreachable",
+			params: map[string]string{"name": "web", "host": "unix:///tmp/docker.sock"},
+			client: &fakeClient{err: errors.New("connection refused")},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "docker.container_running",
+				Status: types.Error,
+				Error:  "Failed to inspect container 'web' on Docker daemon unix:///tmp/docker.sock: connection refused",
+			},
+		},
+		{
+			name:   "invalid expected status",
+			params: map[string]string{"name": "web", "expected_status": "up"},
+			client: client,
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "docker.container_running",
+				Status: types.Error,
+				Error:  "Invalid value for 'expected_status' parameter: up (supported: created, running, paused, restarting, removing, exited, dead)",
+			},
+		},
+		{
+			name:   "missing name and id",
+			params: map[string]string{},
+			client: client,
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "docker.container_running",
+				Status: types.Error,
+				Error:  "name or id parameter is required",
+			},
+		},
+	}
+
+	originalNewDockerClient := newDockerClient
+	defer func() { newDockerClient = originalNewDockerClient }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newDockerClient = func(host string) (dockerClient, error) {
+				return tt.client, nil
+			}
+			got, err := CheckContainerRunning(types.CheckItem{
+				Name:       "test-check",
+				Type:       "docker.container_running",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+func init() {
+	checks.Register("docker.image_exists", "Verifies a Docker image is present locally", CheckImageExists,
+		checks.Parameter{Name: "image", Type: checks.ParamString, Description: "Reference of the image, e.g. nginx:1.27", Required: true},
+		checks.Parameter{Name: "expected_digest", Type: checks.ParamString, Description: "Digest the image ID or one of its repository digests must match, e.g. sha256:4f1c..."},
+		checks.Parameter{Name: "host", Type: checks.ParamString, Description: "Address of the Docker daemon, defaults to DOCKER_HOST or " + defaultHost},
+	)
+}
+
+// normalizeDigest lowercases a digest and adds the sha256 algorithm if it has none
+func normalizeDigest(digest string) string {
+	digest = strings.ToLower(strings.TrimSpace(digest))
+	if digest != "" && !strings.Contains(digest, ":") {
+		digest = "sha256:" + digest
+	}
+	return digest
+}
+
+// imageDigests returns the ID and the repository digests of an image, without the
+// repository names of the latter
+func imageDigests(info imageInfo) []string {
+	digests := []string{normalizeDigest(info.ID)}
+	for _, repoDigest := range info.RepoDigests {
+		if _, digest, ok := strings.Cut(repoDigest, "@"); ok {
+			digests = append(digests, normalizeDigest(digest))
+		}
+	}
+	return digests
+}
+
+// CheckImageExists verifies that a Docker image is present locally, e.g. that a build
+// produced or pulled the image before deploying it. A missing image or a digest
+// mismatch is a failure, an unreachable daemon is an error.
+// Parameters:
+//   - image: reference of the image, e.g. nginx:1.27
+//   - expected_digest: digest the image ID or one of its repository digests must match
+//   - host: address of the Docker daemon, defaults to DOCKER_HOST or the local socket
+func CheckImageExists(item types.CheckItem) (types.CheckResult, error) {
+	image := item.Parameters["image"]
+	if image == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "image parameter is required",
+		}, nil
+	}
+
+	host := resolveHost(item.Parameters["host"])
+	client, err := newDockerClient(host)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  err.Error(),
+		}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	defer cancel()
+	info, err := client.InspectImage(ctx, image)
+	if errors.Is(err, errNotFound) {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("Image '%s' is not present", image),
+		}, nil
+	}
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Failed to inspect image '%s' on Docker daemon %s: %v", image, host, err),
+		}, nil
+	}
+
+	details := map[string]interface{}{
+		"id":           info.ID,
+		"repo_tags":    info.RepoTags,
+		"repo_digests": info.RepoDigests,
+	}
+
+	if expected := normalizeDigest(item.Parameters["expected_digest"]); expected != "" {
+		digests := imageDigests(info)
+		found := false
+		for _, digest := range digests {
+			if digest == expected {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return types.CheckResult{
+				Name:     item.Name,
+				Type:     item.Type,
+				Status:   types.Failure,
+				Output:   fmt.Sprintf("Image '%s' does not match digest %s", image, expected),
+				Expected: expected,
+				Actual:   strings.Join(digests, ", "),
+				Details:  details,
+			}, nil
+		}
+		return types.CheckResult{
+			Name:    item.Name,
+			Type:    item.Type,
+			Status:  types.Success,
+			Output:  fmt.Sprintf("Image '%s' is present with digest %s", image, expected),
+			Details: details,
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:    item.Name,
+		Type:    item.Type,
+		Status:  types.Success,
+		Output:  fmt.Sprintf("Image '%s' is present", image),
+		Details: details,
+	}, nil
+}
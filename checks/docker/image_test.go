@@ -0,0 +1,132 @@
+package docker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckImageExists(t *testing.T) {
+	api := imageInfo{
+		ID:          "sha256:0d2c4e6f",
+		RepoTags:    []string{"ghcr.io/acme/api:1.0"},
+		RepoDigests: []string{"ghcr.io/acme/api@sha256:9f8e7d6c"},
+	}
+	client := &fakeClient{images: map[string]imageInfo{"ghcr.io/acme/api:1.0": api}}
+	details := map[string]interface{}{
+		"id":           "sha256:0d2c4e6f",
+		"repo_tags":    []string{"ghcr.io/acme/api:1.0"},
+		"repo_digests": []string{"ghcr.io/acme/api@sha256:9f8e7d6c"},
+	}
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		client *fakeClient
+		want   types.CheckResult
+	}{
+		{
+			name:   "present",
+			params: map[string]string{"image": "ghcr.io/acme/api:1.0"},
+			client: client,
+			want: types.CheckResult{
+				Name:    "test-check",
+				Type:    "docker.image_exists",
+				Status:  types.Success,
+				Output:  "Image 'ghcr.io/acme/api:1.0' is present",
+				Details: details,
+			},
+		},
+		{
+			name:   "matching repository digest",
+			params: map[string]string{"image": "ghcr.io/acme/api:1.0", "expected_digest": "sha256:9F8E7D6C"},
+			client: client,
+			want: types.CheckResult{
+				Name:    "test-check",
+				Type:    "docker.image_exists",
+				Status:  types.Success,
+				Output:  "Image 'ghcr.io/acme/api:1.0' is present with digest sha256:9f8e7d6c",
+				Details: details,
+			},
+		},
+		{
+			name:   "matching image ID without algorithm",
+			params: map[string]string{"image": "ghcr.io/acme/api:1.0", "expected_digest": "0d2c4e6f"},
+			client: client,
+			want: types.CheckResult{
+				Name:    "test-check",
+				Type:    "docker.image_exists",
+				Status:  types.Success,
+				Output:  "Image 'ghcr.io/acme/api:1.0' is present with digest sha256:0d2c4e6f",
+				Details: details,
+			},
+		},
+		{
+			name:   "mismatching digest",
+			params: map[string]string{"image": "ghcr.io/acme/api:1.0", "expected_digest": "sha256:1234"},
+			client: client,
+			want: types.CheckResult{
+				Name:     "test-check",
+				Type:     "docker.image_exists",
+				Status:   types.Failure,
+				Output:   "Image 'ghcr.io/acme/api:1.0' does not match digest sha256:1234",
+				Expected: "sha256:1234",
+				Actual:   "sha256:0d2c4e6f, sha256:9f8e7d6c",
+				Details:  details,
+			},
+		},
+		{
+			name:   "absent",
+			params: map[string]string{"image": "ghcr.io/acme/api:2.0"},
+			client: client,
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "docker.image_exists",
+				Status: types.Failure,
+				Output: "Image 'ghcr.io/acme/api:2.0' is not present",
+			},
+		},
+		{
+			name:   "daemon unreachable",
+			params: map[string]string{"image": "ghcr.io/acme/api:1.0", "host": "tcp://localhost:2375"},
+			client: &fakeClient{err: errors.New("connection refused")},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "docker.image_exists",
+				Status: types.Error,
+				Error:  "Failed to inspect image 'ghcr.io/acme/api:1.0' on Docker daemon tcp://localhost:2375: connection refused",
+			},
+		},
+		{
+			name:   "missing image",
+			params: map[string]string{},
+			client: client,
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "docker.image_exists",
+				Status: types.Error,
+				Error:  "image parameter is required",
+			},
+		},
+	}
+
+	originalNewDockerClient := newDockerClient
+	defer func() { newDockerClient = originalNewDockerClient }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newDockerClient = func(host string) (dockerClient, error) {
+				return tt.client, nil
+			}
+			got, err := CheckImageExists(types.CheckItem{
+				Name:       "test-check",
+				Type:       "docker.image_exists",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
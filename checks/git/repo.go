@@ -2,18 +2,32 @@ package git
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/filesystem"
 	"github.com/seastar-consulting/checkers/checks"
 	"github.com/seastar-consulting/checkers/types"
 )
 
+// fetchMarkerFile is created in the git directory after each successful fetch
+const fetchMarkerFile = "checkers_last_fetch"
+
 func init() {
-	checks.Register("git.is_up_to_date", "Check if the current branch contains the latest changes from the default remote branch", CheckRepoUpToDate)
+	checks.Register("git.is_up_to_date", "Check if the current branch contains the latest changes from the default remote branch", CheckRepoUpToDate,
+		checks.Parameter{Name: "path", Type: checks.ParamString, Description: "Path to the git repository", Default: "."},
+		checks.Parameter{Name: "default_branch", Type: checks.ParamString, Description: "Name of the default branch, defaults to trying main then master"},
+		checks.Parameter{Name: "fail_out_of_date", Type: checks.ParamBool, Description: "Report a failure instead of a warning when the branch is not up to date", Default: "false"},
+		checks.Parameter{Name: "fetch_interval", Type: checks.ParamDuration, Description: "Skip fetching if the last fetch happened within this duration"},
+	)
+	// Fetching from the remote can take a while on slow connections or large repositories
+	checks.SetDefaultTimeout("git.is_up_to_date", 2*time.Minute)
 }
 
 // findDefaultBranch attempts to find the default branch reference. If defaultBranch is provided,
@@ -41,6 +55,42 @@ func findDefaultBranch(repo *git.Repository, defaultBranch string) (*plumbing.Re
 	return nil, fmt.Errorf("could not find default branch (main or master) in remote")
 }
 
+// gitDir returns the git directory of a repository opened from the filesystem
+func gitDir(repo *git.Repository) (string, bool) {
+	storage, ok := repo.Storer.(*filesystem.Storage)
+	if !ok {
+		return "", false
+	}
+	return storage.Filesystem().Root(), true
+}
+
+// lastFetchTime returns the time of the most recent fetch, based on the marker written by
+// this check and the FETCH_HEAD file written by the git CLI
+func lastFetchTime(repo *git.Repository) (time.Time, bool) {
+	dir, ok := gitDir(repo)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	var last time.Time
+	for _, name := range []string{fetchMarkerFile, "FETCH_HEAD"} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err == nil && info.ModTime().After(last) {
+			last = info.ModTime()
+		}
+	}
+	return last, !last.IsZero()
+}
+
+// recordFetch updates the fetch marker in the git directory
+func recordFetch(repo *git.Repository) error {
+	dir, ok := gitDir(repo)
+	if !ok {
+		return nil
+	}
+	return os.WriteFile(filepath.Join(dir, fetchMarkerFile), []byte(time.Now().Format(time.RFC3339)+"\n"), 0644)
+}
+
 // isAncestor checks if the potential ancestor commit is an ancestor of the target commit
 func isAncestor(repo *git.Repository, ancestorHash, targetHash plumbing.Hash) (bool, error) {
 	// Get commit history
@@ -94,6 +144,24 @@ func CheckRepoUpToDate(item types.CheckItem) (types.CheckResult, error) {
 	// Get default branch if specified
 	defaultBranch := item.Parameters["default_branch"]
 
+	// Skip fetching if the last fetch happened within the fetch interval
+	var fetchInterval time.Duration
+	if intervalStr, ok := item.Parameters["fetch_interval"]; ok {
+		var err error
+		fetchInterval, err = time.ParseDuration(intervalStr)
+		if err == nil && fetchInterval < 0 {
+			err = fmt.Errorf("must not be negative")
+		}
+		if err != nil {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid value for 'fetch_interval' parameter: %v", err),
+			}, nil
+		}
+	}
+
 	// Open repository
 	repo, err := git.PlainOpen(path)
 	if err != nil {
@@ -127,10 +195,18 @@ func CheckRepoUpToDate(item types.CheckItem) (types.CheckResult, error) {
 		}, nil
 	}
 
-	// Try to fetch latest changes
-	err = remote.Fetch(&git.FetchOptions{
-		Force: true,
-	})
+	// Try to fetch latest changes, unless they were fetched recently
+	skipFetch := false
+	if fetchInterval > 0 {
+		if last, ok := lastFetchTime(repo); ok && time.Since(last) < fetchInterval {
+			skipFetch = true
+		}
+	}
+	if !skipFetch {
+		err = remote.Fetch(&git.FetchOptions{
+			Force: true,
+		})
+	}
 	if err != nil && err != git.NoErrAlreadyUpToDate {
 		// Check if it's an authentication error
 		if err == transport.ErrAuthenticationRequired {
@@ -148,6 +224,10 @@ func CheckRepoUpToDate(item types.CheckItem) (types.CheckResult, error) {
 			Error:  fmt.Sprintf("Failed to fetch from remote: %v", err),
 		}, nil
 	}
+	if !skipFetch && fetchInterval > 0 {
+		// The marker only matters when a fetch interval is used, failing to write it is not fatal
+		_ = recordFetch(repo)
+	}
 
 	// Find the default branch reference
 	defaultRef, err := findDefaultBranch(repo, defaultBranch)
@@ -171,6 +251,13 @@ func CheckRepoUpToDate(item types.CheckItem) (types.CheckResult, error) {
 		}, nil
 	}
 
+	details := map[string]interface{}{
+		"branch":                head.Name().Short(),
+		"commit":                head.Hash().String(),
+		"default_branch":        defaultRef.Name().Short(),
+		"default_branch_commit": defaultRef.Hash().String(),
+	}
+
 	if isAncestorResult {
 		return types.CheckResult{
 			Name:   item.Name,
@@ -178,6 +265,7 @@ func CheckRepoUpToDate(item types.CheckItem) (types.CheckResult, error) {
 			Status: types.Success,
 			Output: fmt.Sprintf("Current branch '%s' contains all changes from default branch '%s'",
 				head.Name().Short(), defaultRef.Name().Short()),
+			Details: details,
 		}, nil
 	}
 
@@ -192,5 +280,7 @@ func CheckRepoUpToDate(item types.CheckItem) (types.CheckResult, error) {
 		Status: status,
 		Output: fmt.Sprintf("Current branch '%s' is missing changes from default branch '%s'. Please merge or rebase.",
 			head.Name().Short(), defaultRef.Name().Short()),
+		Remediation: fmt.Sprintf("git -C %s rebase %s", path, defaultRef.Name().Short()),
+		Details:     details,
 	}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
@@ -143,6 +144,7 @@ func TestCheckRepoUpToDate(t *testing.T) {
 		expectedStatus types.CheckStatus
 		expectedError  bool
 		checkOutput   func(t *testing.T, output string)
+		expectedRemediation string
 	}{
 		{
 			name: "Feature branch contains main branch changes",
@@ -213,6 +215,7 @@ func TestCheckRepoUpToDate(t *testing.T) {
 			checkOutput: func(t *testing.T, output string) {
 				assert.Contains(t, output, "missing changes from default branch")
 			},
+			expectedRemediation: "git -C " + tmpDir + " rebase origin/main",
 		},
 		{
 			name: "Invalid fail_out_of_date parameter",
@@ -317,6 +320,15 @@ func TestCheckRepoUpToDate(t *testing.T) {
 			}
 
 			assert.Equal(t, tt.expectedStatus, result.Status)
+			if tt.expectedRemediation != "" {
+				assert.Equal(t, tt.expectedRemediation, result.Remediation)
+			}
+			if result.Status != types.Error {
+				assert.NotEmpty(t, result.Details["default_branch"])
+				assert.Len(t, result.Details["commit"], 40)
+				assert.Len(t, result.Details["default_branch_commit"], 40)
+				assert.NotEmpty(t, result.Details["branch"])
+			}
 			if tt.checkOutput != nil {
 				if result.Error != "" {
 					tt.checkOutput(t, result.Error)
@@ -327,3 +339,100 @@ func TestCheckRepoUpToDate(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckRepoUpToDateFetchInterval(t *testing.T) {
+	tmpDir, repo := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	mainCommit := createTestCommit(t, repo, "main.txt", "main content")
+	createTestBranch(t, repo, "main", mainCommit)
+	remoteRef := plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", "main"), mainCommit)
+	if err := repo.Storer.SetReference(remoteRef); err != nil {
+		t.Fatal(err)
+	}
+
+	// Point the remote at a missing repository so that any fetch fails
+	_, err := repo.CreateRemote(&config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{"file://" + filepath.Join(tmpDir, "missing")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	markerPath := filepath.Join(tmpDir, ".git", fetchMarkerFile)
+
+	tests := []struct {
+		name           string
+		markerAge      time.Duration // zero means no marker
+		interval       string
+		expectedStatus types.CheckStatus
+		checkOutput    func(t *testing.T, output string)
+	}{
+		{
+			name:           "recent fetch skips fetching",
+			markerAge:      time.Minute,
+			interval:       "1h",
+			expectedStatus: types.Success,
+			checkOutput: func(t *testing.T, output string) {
+				assert.Contains(t, output, "contains all changes from default branch")
+			},
+		},
+		{
+			name:           "stale fetch fetches again",
+			markerAge:      2 * time.Hour,
+			interval:       "1h",
+			expectedStatus: types.Error,
+			checkOutput: func(t *testing.T, output string) {
+				assert.Contains(t, output, "Failed to fetch from remote")
+			},
+		},
+		{
+			name:           "no previous fetch",
+			interval:       "1h",
+			expectedStatus: types.Error,
+			checkOutput: func(t *testing.T, output string) {
+				assert.Contains(t, output, "Failed to fetch from remote")
+			},
+		},
+		{
+			name:           "invalid interval",
+			interval:       "often",
+			expectedStatus: types.Error,
+			checkOutput: func(t *testing.T, output string) {
+				assert.Contains(t, output, "Invalid value for 'fetch_interval' parameter")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Remove(markerPath)
+			if tt.markerAge > 0 {
+				if err := os.WriteFile(markerPath, nil, 0644); err != nil {
+					t.Fatal(err)
+				}
+				modTime := time.Now().Add(-tt.markerAge)
+				if err := os.Chtimes(markerPath, modTime, modTime); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			result, err := CheckRepoUpToDate(types.CheckItem{
+				Name: "git.is_up_to_date",
+				Type: "git",
+				Parameters: map[string]string{
+					"path":           tmpDir,
+					"fetch_interval": tt.interval,
+				},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, result.Status)
+			if result.Error != "" {
+				tt.checkOutput(t, result.Error)
+			} else {
+				tt.checkOutput(t, result.Output)
+			}
+		})
+	}
+}
@@ -22,7 +22,10 @@ var (
 )
 
 func init() {
-	checks.Register("k8s.namespace_access", "Verifies access to a Kubernetes namespace", CheckNamespaceAccess)
+	checks.Register("k8s.namespace_access", "Verifies access to a Kubernetes namespace", CheckNamespaceAccess,
+		checks.Parameter{Name: "namespace", Type: checks.ParamString, Description: "Kubernetes namespace to check", Default: "default"},
+		checks.Parameter{Name: "context", Type: checks.ParamString, Description: "Kubernetes context to use, defaults to the current context"},
+	)
 }
 
 // defaultNewKubeConfig creates a new kubernetes config from the given context
@@ -91,6 +94,10 @@ func CheckNamespaceAccess(item types.CheckItem) (types.CheckResult, error) {
 		}, nil
 	}
 	currentContext := rawConfig.CurrentContext
+	details := map[string]interface{}{
+		"context":   currentContext,
+		"namespace": namespaceParam,
+	}
 
 	// Create Kubernetes clientset
 	clientset, err := newClientset(kubeConfig)
@@ -112,10 +119,11 @@ func CheckNamespaceAccess(item types.CheckItem) (types.CheckResult, error) {
 			strings.Contains(err.Error(), "unauthorized") ||
 			strings.Contains(err.Error(), "access denied") {
 			return types.CheckResult{
-				Name:   item.Name,
-				Type:   item.Type,
-				Status: types.Failure,
-				Output: fmt.Sprintf("No access to namespace '%s': %v", namespaceParam, err),
+				Name:    item.Name,
+				Type:    item.Type,
+				Status:  types.Failure,
+				Output:  fmt.Sprintf("No access to namespace '%s': %v", namespaceParam, err),
+				Details: details,
 			}, nil
 		}
 		// For other errors (like namespace not found, network issues, etc.), return error
@@ -129,9 +137,10 @@ func CheckNamespaceAccess(item types.CheckItem) (types.CheckResult, error) {
 
 	// Return success with access details
 	return types.CheckResult{
-		Name:   item.Name,
-		Type:   item.Type,
-		Status: types.Success,
-		Output: fmt.Sprintf("Successfully verified access to namespace '%s' in context '%s'", namespaceParam, currentContext),
+		Name:    item.Name,
+		Type:    item.Type,
+		Status:  types.Success,
+		Output:  fmt.Sprintf("Successfully verified access to namespace '%s' in context '%s'", namespaceParam, currentContext),
+		Details: details,
 	}, nil
 }
@@ -51,6 +51,10 @@ func TestNamespaceAccess(t *testing.T) {
 				Type:   "k8s.namespace_access",
 				Status: types.Success,
 				Output: "Successfully verified access to namespace 'default' in context 'test-context'",
+				Details: map[string]interface{}{
+					"context":   "test-context",
+					"namespace": "default",
+				},
 			},
 		},
 		{
@@ -69,6 +73,10 @@ func TestNamespaceAccess(t *testing.T) {
 				Type:   "k8s.namespace_access",
 				Status: types.Success,
 				Output: "Successfully verified access to namespace 'custom-ns' in context 'custom-context'",
+				Details: map[string]interface{}{
+					"context":   "custom-context",
+					"namespace": "custom-ns",
+				},
 			},
 		},
 		{
@@ -87,6 +95,10 @@ func TestNamespaceAccess(t *testing.T) {
 				Type:   "k8s.namespace_access",
 				Status: types.Failure,
 				Output: "No access to namespace 'restricted-ns': pods is forbidden: User \"test\" cannot list resource \"pods\" in API group \"\" in the namespace \"restricted-ns\"",
+				Details: map[string]interface{}{
+					"context":   "test-context",
+					"namespace": "restricted-ns",
+				},
 			},
 		},
 		{
@@ -105,6 +117,10 @@ func TestNamespaceAccess(t *testing.T) {
 				Type:   "k8s.namespace_access",
 				Status: types.Failure,
 				Output: "No access to namespace 'secure-ns': unauthorized: unable to verify user \"test\" in namespace \"secure-ns\"",
+				Details: map[string]interface{}{
+					"context":   "test-context",
+					"namespace": "secure-ns",
+				},
 			},
 		},
 		{
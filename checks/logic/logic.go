@@ -0,0 +1,175 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/internal/executor"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// defaultTimeout is used for sub-checks that do not define their own timeout when the
+// logic check is not run by an executor
+const defaultTimeout = 30 * time.Second
+
+// for testing
+var runCheck = defaultRunCheck
+
+func init() {
+	checks.RegisterContext("logic.all_of", "Check that all of the sub-checks succeed", CheckAllOf)
+	checks.RegisterContext("logic.any_of", "Check that at least one of the sub-checks succeeds", CheckAnyOf)
+	checks.RegisterContext("logic.one_of", "Check that exactly one of the sub-checks succeeds", CheckOneOf)
+}
+
+// defaultRunCheck executes a sub-check with the executor running the logic check, so it
+// gets the same timeouts and options as top-level checks. Its timeout is capped by the
+// time the logic check has left, and it is cancelled together with the logic check.
+func defaultRunCheck(ctx context.Context, item types.CheckItem) types.CheckResult {
+	e := executor.FromContext(ctx)
+	if e == nil {
+		e = executor.NewExecutor(defaultTimeout)
+		e.SetTypeTimeouts(true)
+	}
+	result, err := e.ExecuteCheck(ctx, item)
+	if err != nil && result.Status == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  err.Error(),
+		}
+	}
+	return result
+}
+
+// runChecks executes all sub-checks of the item and returns their results in order
+// together with the names of the sub-checks that did and did not succeed
+func runChecks(ctx context.Context, item types.CheckItem) (results []types.CheckResult, passed, failed []string) {
+	results = make([]types.CheckResult, 0, len(item.Checks))
+	for _, sub := range item.Checks {
+		result := runCheck(ctx, sub)
+		if result.Name == "" {
+			result.Name = sub.Name
+		}
+		results = append(results, result)
+		if result.Status == types.Success {
+			passed = append(passed, result.Name)
+		} else {
+			failed = append(failed, result.Name)
+		}
+	}
+	return results, passed, failed
+}
+
+// missingChecksResult is returned when a logic check has no sub-checks
+func missingChecksResult(item types.CheckItem) types.CheckResult {
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Error,
+		Error:  "at least one sub-check is required",
+	}
+}
+
+// formatResults lists the status of every sub-check, one per line
+func formatResults(results []types.CheckResult) string {
+	lines := make([]string, 0, len(results))
+	for _, result := range results {
+		line := fmt.Sprintf("- %s: %s", result.Name, result.Status)
+		if result.Error != "" {
+			line += fmt.Sprintf(" (%s)", result.Error)
+		} else if result.Status != types.Success && result.Output != "" {
+			line += fmt.Sprintf(" (%s)", result.Output)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CheckAllOf checks that all sub-checks succeed
+// Sub-checks are defined in the checks field of the item, either inline or by
+// referencing the name of another check in the configuration.
+func CheckAllOf(ctx context.Context, item types.CheckItem) (types.CheckResult, error) {
+	if len(item.Checks) == 0 {
+		return missingChecksResult(item), nil
+	}
+
+	results, _, failed := runChecks(ctx, item)
+	if len(failed) > 0 {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("%d of %d checks did not succeed: %s\n%s",
+				len(failed), len(results), strings.Join(failed, ", "), formatResults(results)),
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Success,
+		Output: fmt.Sprintf("All %d checks succeeded\n%s", len(results), formatResults(results)),
+	}, nil
+}
+
+// CheckAnyOf checks that at least one sub-check succeeds
+func CheckAnyOf(ctx context.Context, item types.CheckItem) (types.CheckResult, error) {
+	if len(item.Checks) == 0 {
+		return missingChecksResult(item), nil
+	}
+
+	results, passed, _ := runChecks(ctx, item)
+	if len(passed) == 0 {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("None of the %d checks succeeded\n%s", len(results), formatResults(results)),
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Success,
+		Output: fmt.Sprintf("%d of %d checks succeeded: %s\n%s",
+			len(passed), len(results), strings.Join(passed, ", "), formatResults(results)),
+	}, nil
+}
+
+// CheckOneOf checks that exactly one sub-check succeeds
+func CheckOneOf(ctx context.Context, item types.CheckItem) (types.CheckResult, error) {
+	if len(item.Checks) == 0 {
+		return missingChecksResult(item), nil
+	}
+
+	results, passed, _ := runChecks(ctx, item)
+	switch len(passed) {
+	case 0:
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("None of the %d checks succeeded, expected exactly one\n%s", len(results), formatResults(results)),
+		}, nil
+	case 1:
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Success,
+			Output: fmt.Sprintf("Exactly one of %d checks succeeded: %s\n%s", len(results), passed[0], formatResults(results)),
+		}, nil
+	default:
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("%d of %d checks succeeded, expected exactly one: %s\n%s",
+				len(passed), len(results), strings.Join(passed, ", "), formatResults(results)),
+		}, nil
+	}
+}
@@ -0,0 +1,261 @@
+package logic
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/seastar-consulting/checkers/internal/executor"
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockRunCheck returns a result with the status given by the sub-check's description
+func mockRunCheck(ctx context.Context, item types.CheckItem) types.CheckResult {
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.CheckStatus(item.Description),
+	}
+}
+
+func subChecks(statuses ...types.CheckStatus) []types.CheckItem {
+	items := make([]types.CheckItem, 0, len(statuses))
+	for i, status := range statuses {
+		items = append(items, types.CheckItem{
+			Name:        string(rune('a' + i)),
+			Type:        "test",
+			Description: string(status),
+		})
+	}
+	return items
+}
+
+func TestCheckAllOf(t *testing.T) {
+	originalRunCheck := runCheck
+	defer func() { runCheck = originalRunCheck }()
+	runCheck = mockRunCheck
+
+	tests := []struct {
+		name   string
+		checks []types.CheckItem
+		want   types.CheckResult
+	}{
+		{
+			name:   "all succeed",
+			checks: subChecks(types.Success, types.Success),
+			want: types.CheckResult{
+				Name:   "group",
+				Type:   "logic.all_of",
+				Status: types.Success,
+				Output: "All 2 checks succeeded\n- a: Success\n- b: Success",
+			},
+		},
+		{
+			name:   "some fail",
+			checks: subChecks(types.Success, types.Failure, types.Error),
+			want: types.CheckResult{
+				Name:   "group",
+				Type:   "logic.all_of",
+				Status: types.Failure,
+				Output: "2 of 3 checks did not succeed: b, c\n- a: Success\n- b: Failure\n- c: Error",
+			},
+		},
+		{
+			name:   "warning is not success",
+			checks: subChecks(types.Warning),
+			want: types.CheckResult{
+				Name:   "group",
+				Type:   "logic.all_of",
+				Status: types.Failure,
+				Output: "1 of 1 checks did not succeed: a\n- a: Warning",
+			},
+		},
+		{
+			name: "no sub-checks",
+			want: types.CheckResult{
+				Name:   "group",
+				Type:   "logic.all_of",
+				Status: types.Error,
+				Error:  "at least one sub-check is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CheckAllOf(context.Background(), types.CheckItem{
+				Name:   "group",
+				Type:   "logic.all_of",
+				Checks: tt.checks,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func TestCheckAllOfCommands(t *testing.T) {
+	result, err := CheckAllOf(context.Background(), types.CheckItem{
+		Name: "group",
+		Type: "logic.all_of",
+		Checks: []types.CheckItem{
+			{Name: "passing", Type: "command", Command: `echo '{"status":"success","output":"ok"}'`},
+			{Name: "failing", Type: "command", Command: `echo '{"status":"failure","output":"broken"}'`},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, types.Failure, result.Status)
+	assert.Equal(t, "1 of 2 checks did not succeed: failing\n- passing: Success\n- failing: Failure (broken)", result.Output)
+}
+
+func TestSubCheckTimeouts(t *testing.T) {
+	t.Run("sub-checks get the timeout of the executor", func(t *testing.T) {
+		// The logic check itself may run longer than its slow sub-check
+		timeout := 5 * time.Second
+		e := executor.NewExecutor(100 * time.Millisecond)
+		start := time.Now()
+		result, err := e.ExecuteCheck(context.Background(), types.CheckItem{
+			Name:    "group",
+			Type:    "logic.all_of",
+			Timeout: &timeout,
+			Checks: []types.CheckItem{
+				{Name: "slow", Type: "command", Command: "sleep 2"},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, types.Failure, result.Status)
+		assert.Contains(t, result.Output, "- slow: Error (command execution timed out)")
+	})
+
+	t.Run("sub-checks stop with the logic check", func(t *testing.T) {
+		timeout := 100 * time.Millisecond
+		subTimeout := 5 * time.Second
+		marker := filepath.Join(t.TempDir(), "finished")
+		e := executor.NewExecutor(5 * time.Second)
+		_, err := e.ExecuteCheck(context.Background(), types.CheckItem{
+			Name:    "group",
+			Type:    "logic.all_of",
+			Timeout: &timeout,
+			Checks: []types.CheckItem{
+				{Name: "slow", Type: "command", Command: "sleep 0.5 && touch " + marker, Timeout: &subTimeout},
+			},
+		})
+		assert.Equal(t, context.DeadlineExceeded, err)
+
+		// The sub-check must have been killed instead of finishing in the background
+		time.Sleep(time.Second)
+		assert.NoFileExists(t, marker)
+	})
+}
+
+func TestCheckAnyOf(t *testing.T) {
+	originalRunCheck := runCheck
+	defer func() { runCheck = originalRunCheck }()
+	runCheck = mockRunCheck
+
+	tests := []struct {
+		name   string
+		checks []types.CheckItem
+		want   types.CheckResult
+	}{
+		{
+			name:   "one succeeds",
+			checks: subChecks(types.Failure, types.Success, types.Error),
+			want: types.CheckResult{
+				Name:   "replicas",
+				Type:   "logic.any_of",
+				Status: types.Success,
+				Output: "1 of 3 checks succeeded: b\n- a: Failure\n- b: Success\n- c: Error",
+			},
+		},
+		{
+			name:   "none succeed",
+			checks: subChecks(types.Failure, types.Warning),
+			want: types.CheckResult{
+				Name:   "replicas",
+				Type:   "logic.any_of",
+				Status: types.Failure,
+				Output: "None of the 2 checks succeeded\n- a: Failure\n- b: Warning",
+			},
+		},
+		{
+			name: "no sub-checks",
+			want: types.CheckResult{
+				Name:   "replicas",
+				Type:   "logic.any_of",
+				Status: types.Error,
+				Error:  "at least one sub-check is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CheckAnyOf(context.Background(), types.CheckItem{
+				Name:   "replicas",
+				Type:   "logic.any_of",
+				Checks: tt.checks,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func TestCheckOneOf(t *testing.T) {
+	originalRunCheck := runCheck
+	defer func() { runCheck = originalRunCheck }()
+	runCheck = mockRunCheck
+
+	tests := []struct {
+		name   string
+		checks []types.CheckItem
+		want   types.CheckResult
+	}{
+		{
+			name:   "exactly one succeeds",
+			checks: subChecks(types.Failure, types.Success),
+			want: types.CheckResult{
+				Name:   "primary",
+				Type:   "logic.one_of",
+				Status: types.Success,
+				Output: "Exactly one of 2 checks succeeded: b\n- a: Failure\n- b: Success",
+			},
+		},
+		{
+			name:   "several succeed",
+			checks: subChecks(types.Success, types.Success, types.Failure),
+			want: types.CheckResult{
+				Name:   "primary",
+				Type:   "logic.one_of",
+				Status: types.Failure,
+				Output: "2 of 3 checks succeeded, expected exactly one: a, b\n- a: Success\n- b: Success\n- c: Failure",
+			},
+		},
+		{
+			name:   "none succeed",
+			checks: subChecks(types.Error),
+			want: types.CheckResult{
+				Name:   "primary",
+				Type:   "logic.one_of",
+				Status: types.Failure,
+				Output: "None of the 1 checks succeeded, expected exactly one\n- a: Error",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CheckOneOf(context.Background(), types.CheckItem{
+				Name:   "primary",
+				Type:   "logic.one_of",
+				Checks: tt.checks,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
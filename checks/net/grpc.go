@@ -0,0 +1,131 @@
+package net
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+func init() {
+	checks.Register("net.grpc_health", "Verifies a gRPC service reports SERVING over the gRPC Health Checking Protocol", CheckGRPCHealth,
+		checks.Parameter{Name: "target", Type: checks.ParamString, Description: "Address of the gRPC server, e.g. api.internal:443", Required: true},
+		checks.Parameter{Name: "service", Type: checks.ParamString, Description: "Name of the service to check, the overall health of the server is checked if not set"},
+		checks.Parameter{Name: "tls", Type: checks.ParamBool, Description: "Whether to connect with TLS", Default: "false"},
+		checks.Parameter{Name: "insecure_skip_verify", Type: checks.ParamBool, Description: "Whether to skip verifying the certificate of the server when connecting with TLS", Default: "false"},
+	)
+}
+
+// CheckGRPCHealth calls the Check RPC of the standard gRPC Health Checking Protocol
+// (grpc.health.v1.Health) and verifies the service is SERVING
+// Parameters:
+//   - target: address of the gRPC server
+//   - service: name of the service to check, defaults to the overall health of the server
+//   - tls: whether to connect with TLS, defaults to false
+//   - insecure_skip_verify: whether to skip verifying the certificate of the server
+func CheckGRPCHealth(item types.CheckItem) (types.CheckResult, error) {
+	target := item.Parameters["target"]
+	if target == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "target parameter is required",
+		}, nil
+	}
+	service := item.Parameters["service"]
+
+	flags := map[string]bool{"tls": false, "insecure_skip_verify": false}
+	for name := range flags {
+		value, ok := item.Parameters[name]
+		if !ok {
+			continue
+		}
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid value for '%s' parameter: %s", name, value),
+			}, nil
+		}
+		flags[name] = enabled
+	}
+
+	creds := insecure.NewCredentials()
+	if flags["tls"] {
+		creds = credentials.NewTLS(&tls.Config{InsecureSkipVerify: flags["insecure_skip_verify"]})
+	}
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Invalid value for 'target' parameter: %v", err),
+		}, nil
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+
+	name := "server " + target
+	if service != "" {
+		name = fmt.Sprintf("service '%s' on %s", service, target)
+	}
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound:
+			// The server does not know the service
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Failure,
+				Output: fmt.Sprintf("Health of %s is unknown: %s", name, status.Convert(err).Message()),
+			}, nil
+		case codes.Unimplemented:
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Server %s does not implement the gRPC Health Checking Protocol", target),
+			}, nil
+		}
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Failed to check health of %s: %s", name, status.Convert(err).Message()),
+		}, nil
+	}
+
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("Health of %s is %s", name, resp.GetStatus()),
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Success,
+		Output: fmt.Sprintf("Health of %s is %s", name, resp.GetStatus()),
+	}, nil
+}
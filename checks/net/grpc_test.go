@@ -0,0 +1,150 @@
+package net
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// startHealthServer starts a gRPC server implementing the health checking protocol
+// with the given service statuses
+func startHealthServer(t *testing.T, statuses map[string]healthpb.HealthCheckResponse_ServingStatus) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	healthServer := health.NewServer()
+	for service, status := range statuses {
+		healthServer.SetServingStatus(service, status)
+	}
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func TestCheckGRPCHealth(t *testing.T) {
+	target := startHealthServer(t, map[string]healthpb.HealthCheckResponse_ServingStatus{
+		"payments.v1.Payments": healthpb.HealthCheckResponse_SERVING,
+		"orders.v1.Orders":     healthpb.HealthCheckResponse_NOT_SERVING,
+		"search.v1.Search":     healthpb.HealthCheckResponse_UNKNOWN,
+	})
+
+	// A server without the health service
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bare := grpc.NewServer()
+	go bare.Serve(listener)
+	defer bare.Stop()
+
+	// Find a port nothing listens on
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedTarget := closed.Addr().String()
+	closed.Close()
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   types.CheckResult
+	}{
+		{
+			name:   "server serving",
+			params: map[string]string{"target": target},
+			want: types.CheckResult{
+				Status: types.Success,
+				Output: "Health of server " + target + " is SERVING",
+			},
+		},
+		{
+			name:   "service serving",
+			params: map[string]string{"target": target, "service": "payments.v1.Payments"},
+			want: types.CheckResult{
+				Status: types.Success,
+				Output: "Health of service 'payments.v1.Payments' on " + target + " is SERVING",
+			},
+		},
+		{
+			name:   "service not serving",
+			params: map[string]string{"target": target, "service": "orders.v1.Orders"},
+			want: types.CheckResult{
+				Status: types.Failure,
+				Output: "Health of service 'orders.v1.Orders' on " + target + " is NOT_SERVING",
+			},
+		},
+		{
+			name:   "service status unknown",
+			params: map[string]string{"target": target, "service": "search.v1.Search"},
+			want: types.CheckResult{
+				Status: types.Failure,
+				Output: "Health of service 'search.v1.Search' on " + target + " is UNKNOWN",
+			},
+		},
+		{
+			name:   "unknown service",
+			params: map[string]string{"target": target, "service": "billing.v1.Billing"},
+			want: types.CheckResult{
+				Status: types.Failure,
+				Output: "Health of service 'billing.v1.Billing' on " + target + " is unknown: unknown service",
+			},
+		},
+		{
+			name:   "health protocol not implemented",
+			params: map[string]string{"target": listener.Addr().String()},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "Server " + listener.Addr().String() + " does not implement the gRPC Health Checking Protocol",
+			},
+		},
+		{
+			name:   "invalid tls",
+			params: map[string]string{"target": target, "tls": "maybe"},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "Invalid value for 'tls' parameter: maybe",
+			},
+		},
+		{
+			name:   "missing target",
+			params: map[string]string{},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "target parameter is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckGRPCHealth(types.CheckItem{Name: "test", Type: "net.grpc_health", Parameters: tt.params})
+			assert.NoError(t, err)
+			tt.want.Name = "test"
+			tt.want.Type = "net.grpc_health"
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("connection failure", func(t *testing.T) {
+		got, err := CheckGRPCHealth(types.CheckItem{Name: "test", Type: "net.grpc_health", Parameters: map[string]string{"target": closedTarget}})
+		assert.NoError(t, err)
+		assert.Equal(t, types.Error, got.Status)
+		assert.Contains(t, got.Error, "Failed to check health of server "+closedTarget)
+	})
+
+	t.Run("tls against plaintext server", func(t *testing.T) {
+		got, err := CheckGRPCHealth(types.CheckItem{Name: "test", Type: "net.grpc_health", Parameters: map[string]string{"target": target, "tls": "true", "insecure_skip_verify": "true"}})
+		assert.NoError(t, err)
+		assert.Equal(t, types.Error, got.Status)
+	})
+}
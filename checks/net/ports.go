@@ -0,0 +1,249 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+const (
+	// defaultPortConcurrency is how many ports are connected to at the same time
+	defaultPortConcurrency = 50
+	// defaultPortTimeout is how long a connection to a port may take before the port is
+	// considered filtered, and therefore closed
+	defaultPortTimeout = 2 * time.Second
+)
+
+// for testing
+var dialPort = func(ctx context.Context, network, address string, timeout time.Duration) (net.Conn, error) {
+	dialer := net.Dialer{Timeout: timeout}
+	return dialer.DialContext(ctx, network, address)
+}
+
+func init() {
+	checks.RegisterContext("net.ports_closed", "Verifies no port of a list or range of ports accepts connections", CheckPortsClosed,
+		checks.Parameter{Name: "host", Type: checks.ParamString, Description: "Host name or IP address to connect to", Required: true},
+		checks.Parameter{Name: "ports", Type: checks.ParamString, Description: "Comma-separated ports and port ranges that must be closed, e.g. 22,2375-2376", Required: true},
+		checks.Parameter{Name: "concurrency", Type: checks.ParamInt, Description: "Maximum number of ports connected to at the same time", Default: strconv.Itoa(defaultPortConcurrency)},
+		checks.Parameter{Name: "port_timeout", Type: checks.ParamDuration, Description: "Time to wait for a connection to a port before considering it closed", Default: defaultPortTimeout.String()},
+	)
+}
+
+// parsePorts parses comma-separated ports and port ranges like 22,8000-8100 into a sorted
+// list of distinct ports
+func parsePorts(value string) ([]int, error) {
+	var ports []int
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := parsePort(first)
+		if err != nil {
+			return nil, err
+		}
+		end := start
+		if isRange {
+			if end, err = parsePort(last); err != nil {
+				return nil, err
+			}
+			if end < start {
+				return nil, fmt.Errorf("port range %s ends before it starts", part)
+			}
+		}
+		for port := start; port <= end; port++ {
+			ports = append(ports, port)
+		}
+	}
+	slices.Sort(ports)
+	return slices.Compact(ports), nil
+}
+
+// parsePort parses a port number between 1 and 65535
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("%q is not a port between 1 and 65535", value)
+	}
+	return port, nil
+}
+
+// CheckPortsClosed verifies that none of the given ports of a host accepts connections,
+// e.g. that management ports are not exposed. Ports that refuse connections or do not
+// answer within the port timeout are closed, any other error connecting to a port is
+// reported as an error. No more ports are connected to once the context is done.
+// Parameters:
+//   - host: host name or IP address to connect to
+//   - ports: comma-separated ports and port ranges, e.g. 22,2375-2376
+//   - concurrency: maximum number of ports connected to at the same time, defaults to 50
+//   - port_timeout: time to wait for a connection to a port, defaults to 2s
+func CheckPortsClosed(ctx context.Context, item types.CheckItem) (types.CheckResult, error) {
+	host, portsValue := item.Parameters["host"], item.Parameters["ports"]
+	if host == "" || portsValue == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "host and ports parameters are required",
+		}, nil
+	}
+	ports, err := parsePorts(portsValue)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Invalid value for 'ports' parameter: %v", err),
+		}, nil
+	}
+
+	concurrency := defaultPortConcurrency
+	if value, ok := item.Parameters["concurrency"]; ok {
+		concurrency, err = strconv.Atoi(value)
+		if err != nil || concurrency <= 0 {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid value for 'concurrency' parameter: %s (must be a positive integer)", value),
+			}, nil
+		}
+	}
+
+	portTimeout := defaultPortTimeout
+	if value, ok := item.Parameters["port_timeout"]; ok {
+		portTimeout, err = time.ParseDuration(value)
+		if err == nil && portTimeout <= 0 {
+			err = fmt.Errorf("must be positive")
+		}
+		if err != nil {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid value for 'port_timeout' parameter: %v", err),
+			}, nil
+		}
+	}
+
+	// Every connection fails for a host that cannot be resolved, which must not pass as
+	// all ports being closed
+	lookupCtx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+	if _, err := net.DefaultResolver.LookupHost(lookupCtx, host); err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Failed to resolve %s: %v", host, err),
+		}, nil
+	}
+
+	open := make([]bool, len(ports))
+	dialErrs := make([]error, len(ports))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, port := range ports {
+		// No more ports are connected to once the check is stopped, e.g. on timeout
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			conn, err := dialPort(ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(port)), portTimeout)
+			if err == nil {
+				conn.Close()
+				open[i] = true
+			} else if !isClosed(err) {
+				dialErrs[i] = err
+			}
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Checking the ports of %s was stopped: %v", host, ctx.Err()),
+		}, nil
+	}
+
+	var openPorts, failedPorts []int
+	var firstErr error
+	for i, port := range ports {
+		if open[i] {
+			openPorts = append(openPorts, port)
+		}
+		if dialErrs[i] != nil {
+			failedPorts = append(failedPorts, port)
+			if firstErr == nil {
+				firstErr = dialErrs[i]
+			}
+		}
+	}
+	details := map[string]interface{}{"ports_checked": len(ports)}
+	if len(openPorts) > 0 {
+		details["open_ports"] = openPorts
+	}
+
+	// Ports that could not be connected to for another reason, e.g. because the network
+	// is unreachable, may be open and must not pass as closed
+	if len(failedPorts) > 0 {
+		details["failed_ports"] = failedPorts
+		return types.CheckResult{
+			Name:    item.Name,
+			Type:    item.Type,
+			Status:  types.Error,
+			Error:   fmt.Sprintf("Failed to connect to %d of %d ports of %s: %s: %v", len(failedPorts), len(ports), host, joinPorts(failedPorts), firstErr),
+			Details: details,
+		}, nil
+	}
+
+	if len(openPorts) > 0 {
+		return types.CheckResult{
+			Name:    item.Name,
+			Type:    item.Type,
+			Status:  types.Failure,
+			Output:  fmt.Sprintf("%d of %d ports of %s are open: %s", len(openPorts), len(ports), host, joinPorts(openPorts)),
+			Details: details,
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:    item.Name,
+		Type:    item.Type,
+		Status:  types.Success,
+		Output:  fmt.Sprintf("All %d ports of %s are closed", len(ports), host),
+		Details: details,
+	}, nil
+}
+
+// isClosed reports whether a connection error means the port is closed: the connection
+// was refused or timed out, e.g. because a firewall drops it
+func isClosed(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, errConnRefused) || (errors.As(err, &netErr) && netErr.Timeout())
+}
+
+// joinPorts formats ports as a comma-separated list
+func joinPorts(ports []int) string {
+	list := make([]string, len(ports))
+	for i, port := range ports {
+		list[i] = strconv.Itoa(port)
+	}
+	return strings.Join(list, ", ")
+}
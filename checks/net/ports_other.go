@@ -0,0 +1,8 @@
+//go:build !windows
+
+package net
+
+import "syscall"
+
+// errConnRefused is the error of a connection to a port nothing listens on
+const errConnRefused = syscall.ECONNREFUSED
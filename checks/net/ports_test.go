@@ -0,0 +1,228 @@
+package net
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+func TestParsePorts(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    []int
+		wantErr string
+	}{
+		{value: "22", want: []int{22}},
+		{value: "8080, 22,2375-2377", want: []int{22, 2375, 2376, 2377, 8080}},
+		{value: "22,20-23", want: []int{20, 21, 22, 23}},
+		{value: "ssh", wantErr: `"ssh" is not a port between 1 and 65535`},
+		{value: "0", wantErr: `"0" is not a port between 1 and 65535`},
+		{value: "22,", wantErr: `"" is not a port between 1 and 65535`},
+		{value: "9000-70000", wantErr: `"70000" is not a port between 1 and 65535`},
+		{value: "23-22", wantErr: "port range 23-22 ends before it starts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parsePorts(tt.value)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCheckPortsClosed(t *testing.T) {
+	// Find ports nothing listens on
+	var closedPorts []int
+	for range 2 {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		closedPorts = append(closedPorts, listener.Addr().(*net.TCPAddr).Port)
+		listener.Close()
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	openPort := listener.Addr().(*net.TCPAddr).Port
+
+	portList := func(ports ...int) string {
+		list := make([]string, len(ports))
+		for i, port := range ports {
+			list[i] = strconv.Itoa(port)
+		}
+		return strings.Join(list, ",")
+	}
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   types.CheckResult
+	}{
+		{
+			name:   "all ports closed",
+			params: map[string]string{"host": "127.0.0.1", "ports": portList(closedPorts...)},
+			want: types.CheckResult{
+				Status:  types.Success,
+				Output:  "All 2 ports of 127.0.0.1 are closed",
+				Details: map[string]interface{}{"ports_checked": 2},
+			},
+		},
+		{
+			name:   "open port",
+			params: map[string]string{"host": "127.0.0.1", "ports": portList(append(closedPorts, openPort)...), "concurrency": "1", "port_timeout": "1s"},
+			want: types.CheckResult{
+				Status:  types.Failure,
+				Output:  "1 of 3 ports of 127.0.0.1 are open: " + strconv.Itoa(openPort),
+				Details: map[string]interface{}{"ports_checked": 3, "open_ports": []int{openPort}},
+			},
+		},
+		{
+			name:   "missing ports",
+			params: map[string]string{"host": "127.0.0.1"},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "host and ports parameters are required",
+			},
+		},
+		{
+			name:   "invalid ports",
+			params: map[string]string{"host": "127.0.0.1", "ports": "22-ssh"},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "Invalid value for 'ports' parameter: \"ssh\" is not a port between 1 and 65535",
+			},
+		},
+		{
+			name:   "invalid concurrency",
+			params: map[string]string{"host": "127.0.0.1", "ports": "22", "concurrency": "0"},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "Invalid value for 'concurrency' parameter: 0 (must be a positive integer)",
+			},
+		},
+		{
+			name:   "invalid port timeout",
+			params: map[string]string{"host": "127.0.0.1", "ports": "22", "port_timeout": "-1s"},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "Invalid value for 'port_timeout' parameter: must be positive",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckPortsClosed(context.Background(), types.CheckItem{
+				Name:       "test-check",
+				Type:       "net.ports_closed",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			tt.want.Name = "test-check"
+			tt.want.Type = "net.ports_closed"
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCheckPortsClosedDialErrors(t *testing.T) {
+	originalDialPort := dialPort
+	defer func() { dialPort = originalDialPort }()
+
+	// Connections to port 1 are refused, to port 2 time out, and to the other ports fail
+	portErrs := map[string]error{
+		"1": errConnRefused,
+		"2": os.ErrDeadlineExceeded,
+		"3": syscall.ENETUNREACH,
+		"4": syscall.EMFILE,
+	}
+	dialErr := func(address string) error {
+		_, port, _ := net.SplitHostPort(address)
+		return &net.OpError{Op: "dial", Net: "tcp", Err: &os.SyscallError{Syscall: "connect", Err: portErrs[port]}}
+	}
+	dialPort = func(ctx context.Context, network, address string, timeout time.Duration) (net.Conn, error) {
+		return nil, dialErr(address)
+	}
+
+	tests := []struct {
+		name  string
+		ports string
+		want  types.CheckResult
+	}{
+		{
+			name:  "refused and timed out connections are closed ports",
+			ports: "1,2",
+			want: types.CheckResult{
+				Status:  types.Success,
+				Output:  "All 2 ports of 127.0.0.1 are closed",
+				Details: map[string]interface{}{"ports_checked": 2},
+			},
+		},
+		{
+			name:  "other errors are not closed ports",
+			ports: "1-4",
+			want: types.CheckResult{
+				Status:  types.Error,
+				Error:   "Failed to connect to 2 of 4 ports of 127.0.0.1: 3, 4: " + dialErr("127.0.0.1:3").Error(),
+				Details: map[string]interface{}{"ports_checked": 4, "failed_ports": []int{3, 4}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckPortsClosed(context.Background(), types.CheckItem{
+				Name:       "test-check",
+				Type:       "net.ports_closed",
+				Parameters: map[string]string{"host": "127.0.0.1", "ports": tt.ports},
+			})
+			assert.NoError(t, err)
+			tt.want.Name = "test-check"
+			tt.want.Type = "net.ports_closed"
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCheckPortsClosedStopped(t *testing.T) {
+	originalDialPort := dialPort
+	defer func() { dialPort = originalDialPort }()
+
+	// The check is stopped while connecting to the first port
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var dials int
+	dialPort = func(ctx context.Context, network, address string, timeout time.Duration) (net.Conn, error) {
+		dials++
+		cancel()
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	got, err := CheckPortsClosed(ctx, types.CheckItem{
+		Name:       "test-check",
+		Type:       "net.ports_closed",
+		Parameters: map[string]string{"host": "127.0.0.1", "ports": "1-100", "concurrency": "1"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, dials)
+	assert.Equal(t, types.Error, got.Status)
+	assert.Equal(t, "Checking the ports of 127.0.0.1 was stopped: context canceled", got.Error)
+}
@@ -0,0 +1,7 @@
+package net
+
+import "syscall"
+
+// errConnRefused is the error of a connection to a port nothing listens on,
+// WSAECONNREFUSED, which syscall.ECONNREFUSED does not match on Windows
+const errConnRefused = syscall.Errno(10061)
@@ -0,0 +1,155 @@
+package net
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+func init() {
+	checks.Register("net.http_redirect", "Verifies a URL redirects to the expected location", CheckHTTPRedirect,
+		checks.Parameter{Name: "url", Type: checks.ParamString, Description: "URL to request, e.g. http://example.com", Required: true},
+		checks.Parameter{Name: "expected_location", Type: checks.ParamString, Description: "Location the URL has to redirect to, relative locations are resolved against the URL", Required: true},
+		checks.Parameter{Name: "expected_status", Type: checks.ParamInt, Description: "Status code of the redirect, e.g. 301, defaults to any 3xx status code"},
+	)
+}
+
+// CheckHTTPRedirect verifies that a URL redirects to the expected location, e.g. from
+// HTTP to HTTPS or from a vanity URL, without following the redirect
+// Parameters:
+//   - url: URL to request, with the http or https scheme
+//   - expected_location: location the URL has to redirect to
+//   - expected_status: status code of the redirect, defaults to any 3xx status code
+func CheckHTTPRedirect(item types.CheckItem) (types.CheckResult, error) {
+	rawURL := item.Parameters["url"]
+	if rawURL == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "url parameter is required",
+		}, nil
+	}
+	requestURL, err := url.Parse(rawURL)
+	if err == nil && requestURL.Scheme != "http" && requestURL.Scheme != "https" {
+		err = fmt.Errorf("unsupported scheme %q (must be http or https)", requestURL.Scheme)
+	}
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Invalid value for 'url' parameter: %v", err),
+		}, nil
+	}
+
+	rawExpected := item.Parameters["expected_location"]
+	if rawExpected == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "expected_location parameter is required",
+		}, nil
+	}
+	expectedLocation, err := requestURL.Parse(rawExpected)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Invalid value for 'expected_location' parameter: %v", err),
+		}, nil
+	}
+
+	expectedStatus := 0
+	if value := item.Parameters["expected_status"]; value != "" {
+		expectedStatus, err = strconv.Atoi(value)
+		if err != nil || expectedStatus < 300 || expectedStatus > 399 {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid value for 'expected_status' parameter: %s (must be a 3xx status code)", value),
+			}, nil
+		}
+	}
+
+	// The redirect itself is checked, so it must not be followed
+	client := &http.Client{
+		Timeout: dialTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get(requestURL.String())
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Failed to connect to %s: %v", requestURL.Host, err),
+		}, nil
+	}
+	resp.Body.Close()
+
+	details := map[string]interface{}{"status_code": resp.StatusCode}
+	rawLocation := resp.Header.Get("Location")
+	if resp.StatusCode < 300 || resp.StatusCode > 399 || rawLocation == "" {
+		return types.CheckResult{
+			Name:     item.Name,
+			Type:     item.Type,
+			Status:   types.Failure,
+			Output:   fmt.Sprintf("%s did not redirect (status %d)", rawURL, resp.StatusCode),
+			Expected: expectedLocation.String(),
+			Details:  details,
+		}, nil
+	}
+
+	location, err := requestURL.Parse(rawLocation)
+	if err != nil {
+		return types.CheckResult{
+			Name:    item.Name,
+			Type:    item.Type,
+			Status:  types.Failure,
+			Output:  fmt.Sprintf("%s redirects to an invalid location %q: %v", rawURL, rawLocation, err),
+			Details: details,
+		}, nil
+	}
+	details["location"] = location.String()
+
+	if location.String() != expectedLocation.String() {
+		return types.CheckResult{
+			Name:     item.Name,
+			Type:     item.Type,
+			Status:   types.Failure,
+			Output:   fmt.Sprintf("%s redirects to %s, expected %s", rawURL, location, expectedLocation),
+			Expected: expectedLocation.String(),
+			Actual:   location.String(),
+			Details:  details,
+		}, nil
+	}
+	if expectedStatus != 0 && resp.StatusCode != expectedStatus {
+		return types.CheckResult{
+			Name:     item.Name,
+			Type:     item.Type,
+			Status:   types.Failure,
+			Output:   fmt.Sprintf("%s redirects to %s with status %d, expected %d", rawURL, location, resp.StatusCode, expectedStatus),
+			Expected: strconv.Itoa(expectedStatus),
+			Actual:   strconv.Itoa(resp.StatusCode),
+			Details:  details,
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:    item.Name,
+		Type:    item.Type,
+		Status:  types.Success,
+		Output:  fmt.Sprintf("%s redirects to %s with status %d", rawURL, location, resp.StatusCode),
+		Details: details,
+	}, nil
+}
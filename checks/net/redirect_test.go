@@ -0,0 +1,146 @@
+package net
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+func TestCheckHTTPRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://example.com/", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/docs", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/docs/latest/", http.StatusFound)
+	})
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	// Find a port nothing listens on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedAddress := listener.Addr().String()
+	listener.Close()
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   types.CheckResult
+	}{
+		{
+			name:   "redirect to https",
+			params: map[string]string{"url": server.URL, "expected_location": "https://example.com/", "expected_status": "301"},
+			want: types.CheckResult{
+				Status:  types.Success,
+				Output:  server.URL + " redirects to https://example.com/ with status 301",
+				Details: map[string]interface{}{"status_code": 301, "location": "https://example.com/"},
+			},
+		},
+		{
+			name:   "relative locations are resolved",
+			params: map[string]string{"url": server.URL + "/docs", "expected_location": "/docs/latest/"},
+			want: types.CheckResult{
+				Status:  types.Success,
+				Output:  server.URL + "/docs redirects to " + server.URL + "/docs/latest/ with status 302",
+				Details: map[string]interface{}{"status_code": 302, "location": server.URL + "/docs/latest/"},
+			},
+		},
+		{
+			name:   "wrong location",
+			params: map[string]string{"url": server.URL, "expected_location": "https://www.example.com/"},
+			want: types.CheckResult{
+				Status:   types.Failure,
+				Output:   server.URL + " redirects to https://example.com/, expected https://www.example.com/",
+				Expected: "https://www.example.com/",
+				Actual:   "https://example.com/",
+				Details:  map[string]interface{}{"status_code": 301, "location": "https://example.com/"},
+			},
+		},
+		{
+			name:   "wrong status",
+			params: map[string]string{"url": server.URL + "/docs", "expected_location": "/docs/latest/", "expected_status": "301"},
+			want: types.CheckResult{
+				Status:   types.Failure,
+				Output:   server.URL + "/docs redirects to " + server.URL + "/docs/latest/ with status 302, expected 301",
+				Expected: "301",
+				Actual:   "302",
+				Details:  map[string]interface{}{"status_code": 302, "location": server.URL + "/docs/latest/"},
+			},
+		},
+		{
+			name:   "no redirect",
+			params: map[string]string{"url": server.URL + "/ok", "expected_location": "https://example.com/"},
+			want: types.CheckResult{
+				Status:   types.Failure,
+				Output:   server.URL + "/ok did not redirect (status 200)",
+				Expected: "https://example.com/",
+				Details:  map[string]interface{}{"status_code": 200},
+			},
+		},
+		{
+			name:   "connection refused",
+			params: map[string]string{"url": "http://" + closedAddress, "expected_location": "https://example.com/"},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "Failed to connect to " + closedAddress + ": Get \"http://" + closedAddress + "\": dial tcp " + closedAddress + ": connect: connection refused",
+			},
+		},
+		{
+			name:   "missing url",
+			params: map[string]string{"expected_location": "https://example.com/"},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "url parameter is required",
+			},
+		},
+		{
+			name:   "unsupported scheme",
+			params: map[string]string{"url": "ftp://example.com", "expected_location": "https://example.com/"},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "Invalid value for 'url' parameter: unsupported scheme \"ftp\" (must be http or https)",
+			},
+		},
+		{
+			name:   "missing expected location",
+			params: map[string]string{"url": server.URL},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "expected_location parameter is required",
+			},
+		},
+		{
+			name:   "invalid expected status",
+			params: map[string]string{"url": server.URL, "expected_location": "https://example.com/", "expected_status": "200"},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "Invalid value for 'expected_status' parameter: 200 (must be a 3xx status code)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckHTTPRedirect(types.CheckItem{
+				Name:       "test-check",
+				Type:       "net.http_redirect",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			tt.want.Name = "test-check"
+			tt.want.Type = "net.http_redirect"
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
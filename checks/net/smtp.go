@@ -0,0 +1,189 @@
+package net
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// dialTimeout limits how long connecting to a server and each network operation may take
+const dialTimeout = 10 * time.Second
+
+func init() {
+	checks.Register("net.smtp_connect", "Verifies an SMTP handshake with a mail server completes", CheckSMTPConnect,
+		checks.Parameter{Name: "host", Type: checks.ParamString, Description: "Host name or IP address of the mail server", Required: true},
+		checks.Parameter{Name: "port", Type: checks.ParamInt, Description: "Port of the mail server, defaults to 587 when starttls is enabled and 25 otherwise"},
+		checks.Parameter{Name: "starttls", Type: checks.ParamBool, Description: "Whether to upgrade the connection with STARTTLS", Default: "false"},
+		checks.Parameter{Name: "username", Type: checks.ParamString, Description: "User to authenticate as, requires password"},
+		checks.Parameter{Name: "password", Type: checks.ParamString, Description: "Password of the user", Secret: true},
+	)
+}
+
+// recordingConn records the data read from a connection while recording is enabled
+type recordingConn struct {
+	net.Conn
+	buf       bytes.Buffer
+	recording bool
+}
+
+func (c *recordingConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	if c.recording {
+		c.buf.Write(p[:n])
+	}
+	return n, err
+}
+
+// parseGreeting extracts the text of a (possibly multi-line) 220 greeting
+func parseGreeting(data string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) > 4 {
+			lines = append(lines, line[4:])
+		}
+	}
+	return strings.Join(lines, " ")
+}
+
+// CheckSMTPConnect verifies that an SMTP handshake with a mail server completes
+// Parameters:
+//   - host: host name or IP address of the mail server
+//   - port: port of the mail server, defaults to 587 when starttls is enabled and 25 otherwise
+//   - starttls: whether to upgrade the connection with STARTTLS
+//   - username: user to authenticate as with AUTH PLAIN, requires password
+//   - password: password of the user
+func CheckSMTPConnect(item types.CheckItem) (types.CheckResult, error) {
+	host := item.Parameters["host"]
+	if host == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "host parameter is required",
+		}, nil
+	}
+
+	useTLS := false
+	if tlsStr, ok := item.Parameters["starttls"]; ok {
+		var err error
+		useTLS, err = strconv.ParseBool(tlsStr)
+		if err != nil {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid value for 'starttls' parameter: %v", err),
+			}, nil
+		}
+	}
+
+	port := item.Parameters["port"]
+	if port == "" {
+		port = "25"
+		if useTLS {
+			port = "587"
+		}
+	}
+
+	username, password := item.Parameters["username"], item.Parameters["password"]
+	if username != "" && password == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "password parameter is required when username is set",
+		}, nil
+	}
+
+	address := net.JoinHostPort(host, port)
+	conn, err := net.DialTimeout("tcp", address, dialTimeout)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Failed to connect to %s: %v", address, err),
+		}, nil
+	}
+	conn.SetDeadline(time.Now().Add(dialTimeout))
+
+	// Record the greeting, which the SMTP client reads but does not expose
+	recorder := &recordingConn{Conn: conn, recording: true}
+	client, err := smtp.NewClient(recorder, host)
+	if err != nil {
+		conn.Close()
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Failed to read greeting from %s: %v", address, err),
+		}, nil
+	}
+	defer client.Close()
+	recorder.recording = false
+	greeting := parseGreeting(recorder.buf.String())
+
+	if err := client.Hello("localhost"); err != nil {
+		return smtpErrorResult(item, "EHLO", greeting, err), nil
+	}
+
+	if useTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Failure,
+				Output: fmt.Sprintf("Server %s does not support STARTTLS (greeting: %s)", address, greeting),
+			}, nil
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return smtpErrorResult(item, "STARTTLS", greeting, err), nil
+		}
+	}
+
+	if username != "" {
+		if err := client.Auth(smtp.PlainAuth("", username, password, host)); err != nil {
+			return smtpErrorResult(item, "AUTH", greeting, err), nil
+		}
+	}
+
+	client.Quit()
+
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Success,
+		Output: fmt.Sprintf("SMTP handshake with %s completed (greeting: %s)", address, greeting),
+	}, nil
+}
+
+// smtpErrorResult reports rejections by the server as a failure and all other errors,
+// such as network or TLS errors, as an error
+func smtpErrorResult(item types.CheckItem, step, greeting string, err error) types.CheckResult {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("Server rejected %s: %d %s (greeting: %s)", step, protoErr.Code, protoErr.Msg, greeting),
+		}
+	}
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Error,
+		Error:  fmt.Sprintf("%s failed: %v", step, err),
+	}
+}
@@ -0,0 +1,156 @@
+package net
+
+import (
+	"bufio"
+	"encoding/base64"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// startSMTPServer starts a minimal SMTP server accepting the user "alice" with password "secret"
+func startSMTPServer(t *testing.T, extensions []string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, extensions)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func serveSMTP(conn net.Conn, extensions []string) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	write := func(lines ...string) {
+		conn.Write([]byte(strings.Join(lines, "\r\n") + "\r\n"))
+	}
+
+	write("220 mail.test ESMTP ready")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(command, "EHLO"):
+			lines := []string{"250-mail.test"}
+			for _, ext := range extensions {
+				lines = append(lines, "250-"+ext)
+			}
+			lines[len(lines)-1] = "250 " + lines[len(lines)-1][4:]
+			write(lines...)
+		case strings.HasPrefix(command, "AUTH PLAIN "):
+			credentials, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(command, "AUTH PLAIN "))
+			if string(credentials) == "\x00alice\x00secret" {
+				write("235 2.7.0 Authentication successful")
+			} else {
+				write("535 5.7.8 Authentication credentials invalid")
+			}
+		case command == "QUIT":
+			write("221 Bye")
+			return
+		default:
+			write("502 Command not implemented")
+		}
+	}
+}
+
+func TestCheckSMTPConnect(t *testing.T) {
+	address := startSMTPServer(t, []string{"AUTH PLAIN"})
+	host, port, _ := net.SplitHostPort(address)
+
+	// A port nothing listens on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedAddress := listener.Addr().String()
+	listener.Close()
+	_, closedPort, _ := net.SplitHostPort(closedAddress)
+
+	tests := []struct {
+		name       string
+		params     map[string]string
+		wantStatus types.CheckStatus
+		wantOutput string
+		wantError  string
+	}{
+		{
+			name:       "handshake without auth",
+			params:     map[string]string{"host": host, "port": port},
+			wantStatus: types.Success,
+			wantOutput: "SMTP handshake with " + address + " completed (greeting: mail.test ESMTP ready)",
+		},
+		{
+			name:       "successful auth",
+			params:     map[string]string{"host": host, "port": port, "username": "alice", "password": "secret"},
+			wantStatus: types.Success,
+			wantOutput: "SMTP handshake with " + address + " completed (greeting: mail.test ESMTP ready)",
+		},
+		{
+			name:       "rejected auth",
+			params:     map[string]string{"host": host, "port": port, "username": "alice", "password": "wrong"},
+			wantStatus: types.Failure,
+			wantOutput: "Server rejected AUTH: 535 5.7.8 Authentication credentials invalid (greeting: mail.test ESMTP ready)",
+		},
+		{
+			name:       "starttls not supported",
+			params:     map[string]string{"host": host, "port": port, "starttls": "true"},
+			wantStatus: types.Failure,
+			wantOutput: "Server " + address + " does not support STARTTLS (greeting: mail.test ESMTP ready)",
+		},
+		{
+			name:       "connection refused",
+			params:     map[string]string{"host": host, "port": closedPort},
+			wantStatus: types.Error,
+			wantError:  "Failed to connect to " + closedAddress,
+		},
+		{
+			name:       "missing host",
+			params:     map[string]string{"port": port},
+			wantStatus: types.Error,
+			wantError:  "host parameter is required",
+		},
+		{
+			name:       "missing password",
+			params:     map[string]string{"host": host, "port": port, "username": "alice"},
+			wantStatus: types.Error,
+			wantError:  "password parameter is required when username is set",
+		},
+		{
+			name:       "invalid starttls",
+			params:     map[string]string{"host": host, "port": port, "starttls": "maybe"},
+			wantStatus: types.Error,
+			wantError:  "Invalid value for 'starttls' parameter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CheckSMTPConnect(types.CheckItem{
+				Name:       "test-check",
+				Type:       "net.smtp_connect",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.Equal(t, tt.wantOutput, result.Output)
+			assert.Contains(t, result.Error, tt.wantError)
+		})
+	}
+}
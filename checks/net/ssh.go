@@ -0,0 +1,241 @@
+package net
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// for testing
+var dialSSH = defaultDialSSH
+
+func init() {
+	checks.Register("net.ssh_connect", "Verifies an SSH server accepts a login", CheckSSHConnect,
+		checks.Parameter{Name: "host", Type: checks.ParamString, Description: "Host name or IP address of the SSH server", Required: true},
+		checks.Parameter{Name: "port", Type: checks.ParamInt, Description: "Port of the SSH server", Default: "22"},
+		checks.Parameter{Name: "user", Type: checks.ParamString, Description: "User to log in as", Required: true},
+		checks.Parameter{Name: "private_key_path", Type: checks.ParamString, Description: "Path to the private key to authenticate with, at least one of private_key_path and password is required"},
+		checks.Parameter{Name: "private_key_passphrase", Type: checks.ParamString, Description: "Passphrase of an encrypted private key", Secret: true},
+		checks.Parameter{Name: "password", Type: checks.ParamString, Description: "Password to authenticate with", Secret: true},
+		checks.Parameter{Name: "known_hosts", Type: checks.ParamString, Description: "Path to a known_hosts file to verify the host key against, the host key is not verified when unset"},
+		checks.Parameter{Name: "command", Type: checks.ParamString, Description: "Command to run after logging in, which has to exit with code 0"},
+	)
+}
+
+// defaultDialSSH connects to an SSH server and completes the handshake and
+// authentication within the timeout of the client configuration
+func defaultDialSSH(address string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	conn, err := net.DialTimeout("tcp", address, config.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetDeadline(time.Now().Add(config.Timeout))
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, address, config)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	// Commands may run longer than the handshake, they are bounded by the check timeout
+	conn.SetDeadline(time.Time{})
+	return ssh.NewClient(sshConn, chans, reqs), nil
+}
+
+// sshAuthMethods returns the authentication methods configured by the parameters, the
+// private key being tried before the password
+func sshAuthMethods(params map[string]string) ([]ssh.AuthMethod, error) {
+	var methods []ssh.AuthMethod
+	if keyPath := params["private_key_path"]; keyPath != "" {
+		data, err := os.ReadFile(keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read private key: %v", err)
+		}
+		var signer ssh.Signer
+		if passphrase := params["private_key_passphrase"]; passphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(data, []byte(passphrase))
+		} else {
+			signer, err = ssh.ParsePrivateKey(data)
+		}
+		var missingErr *ssh.PassphraseMissingError
+		if errors.As(err, &missingErr) {
+			return nil, fmt.Errorf("Private key '%s' is encrypted, private_key_passphrase parameter is required", keyPath)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse private key '%s': %v", keyPath, err)
+		}
+		methods = append(methods, ssh.PublicKeys(signer))
+	}
+	if password := params["password"]; password != "" {
+		methods = append(methods, ssh.Password(password))
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("private_key_path or password parameter is required")
+	}
+	return methods, nil
+}
+
+// CheckSSHConnect verifies that an SSH server accepts a login, e.g. to validate access
+// to a bastion host, and optionally that a command succeeds on it
+// Parameters:
+//   - host: host name or IP address of the SSH server
+//   - port: port of the SSH server, defaults to 22
+//   - user: user to log in as
+//   - private_key_path: path to the private key to authenticate with
+//   - private_key_passphrase: passphrase of an encrypted private key
+//   - password: password to authenticate with
+//   - known_hosts: path to a known_hosts file to verify the host key against
+//   - command: command to run after logging in, which has to exit with code 0
+func CheckSSHConnect(item types.CheckItem) (types.CheckResult, error) {
+	host := item.Parameters["host"]
+	if host == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "host parameter is required",
+		}, nil
+	}
+
+	user := item.Parameters["user"]
+	if user == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "user parameter is required",
+		}, nil
+	}
+
+	port := item.Parameters["port"]
+	if port == "" {
+		port = "22"
+	}
+
+	methods, err := sshAuthMethods(item.Parameters)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  err.Error(),
+		}, nil
+	}
+
+	// Record the host key, so it can be reported, whether or not it is verified
+	var hostKey ssh.PublicKey
+	verify := ssh.InsecureIgnoreHostKey()
+	if knownHosts := item.Parameters["known_hosts"]; knownHosts != "" {
+		verify, err = knownhosts.New(knownHosts)
+		if err != nil {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Failed to read known_hosts file: %v", err),
+			}, nil
+		}
+	}
+	config := &ssh.ClientConfig{
+		User: user,
+		Auth: methods,
+		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			hostKey = key
+			return verify(hostname, remote, key)
+		},
+		Timeout: dialTimeout,
+	}
+
+	address := net.JoinHostPort(host, port)
+	client, err := dialSSH(address, config)
+	var keyErr *knownhosts.KeyError
+	switch {
+	case errors.As(err, &keyErr):
+		problem := "is not in the known_hosts file"
+		if len(keyErr.Want) > 0 {
+			problem = "does not match the known_hosts file"
+		}
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("Host key %s of %s %s", ssh.FingerprintSHA256(hostKey), address, problem),
+		}, nil
+	case err != nil && strings.Contains(err.Error(), "unable to authenticate"):
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("Server %s rejected the login of user '%s': %v", address, user, err),
+		}, nil
+	case err != nil:
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Failed to connect to %s: %v", address, err),
+		}, nil
+	}
+	defer client.Close()
+
+	details := map[string]interface{}{
+		"server_version":       string(client.ServerVersion()),
+		"host_key_fingerprint": ssh.FingerprintSHA256(hostKey),
+	}
+
+	command := item.Parameters["command"]
+	if command == "" {
+		return types.CheckResult{
+			Name:    item.Name,
+			Type:    item.Type,
+			Status:  types.Success,
+			Output:  fmt.Sprintf("Logged in to %s as '%s'", address, user),
+			Details: details,
+		}, nil
+	}
+
+	session, err := client.NewSession()
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Failed to open a session on %s: %v", address, err),
+		}, nil
+	}
+	defer session.Close()
+
+	output, err := session.CombinedOutput(command)
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) {
+		return types.CheckResult{
+			Name:    item.Name,
+			Type:    item.Type,
+			Status:  types.Failure,
+			Output:  fmt.Sprintf("Command '%s' exited with code %d on %s: %s", command, exitErr.ExitStatus(), address, strings.TrimSpace(string(output))),
+			Details: details,
+		}, nil
+	}
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Failed to run command '%s' on %s: %v", command, address, err),
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:    item.Name,
+		Type:    item.Type,
+		Status:  types.Success,
+		Output:  fmt.Sprintf("Logged in to %s as '%s' and ran '%s': %s", address, user, command, strings.TrimSpace(string(output))),
+		Details: details,
+	}, nil
+}
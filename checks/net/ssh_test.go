@@ -0,0 +1,295 @@
+package net
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// newSSHSigner generates an ed25519 key and writes it to a PEM file in dir
+func newSSHSigner(t *testing.T, dir, name string) (ssh.Signer, string) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return signer, path
+}
+
+// startSSHServer starts a minimal SSH server accepting the user "alice" with password
+// "secret" or the given client key. It runs the commands "true" and "false" only.
+func startSSHServer(t *testing.T, hostKey ssh.Signer, clientKey ssh.PublicKey) string {
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == "alice" && string(password) == "secret" {
+				return nil, nil
+			}
+			return nil, errors.New("access denied")
+		},
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if conn.User() == "alice" && bytes.Equal(key.Marshal(), clientKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, errors.New("access denied")
+		},
+		ServerVersion: "SSH-2.0-TestServer",
+	}
+	config.AddHostKey(hostKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSSH(conn, config)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func serveSSH(conn net.Conn, config *ssh.ServerConfig) {
+	defer conn.Close()
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer channel.Close()
+			for req := range requests {
+				if req.Type != "exec" || len(req.Payload) < 4 {
+					req.Reply(false, nil)
+					continue
+				}
+				req.Reply(true, nil)
+				var status uint32
+				switch command := string(req.Payload[4:]); command {
+				case "true":
+					fmt.Fprintln(channel, "ok")
+				case "false":
+					fmt.Fprintln(channel, "something went wrong")
+					status = 1
+				default:
+					fmt.Fprintf(channel.Stderr(), "%s: command not found\n", command)
+					status = 127
+				}
+				payload := make([]byte, 4)
+				binary.BigEndian.PutUint32(payload, status)
+				channel.SendRequest("exit-status", false, payload)
+				return
+			}
+		}()
+	}
+}
+
+func TestCheckSSHConnect(t *testing.T) {
+	dir := t.TempDir()
+	hostKey, _ := newSSHSigner(t, dir, "host_key")
+	otherHostKey, _ := newSSHSigner(t, dir, "other_host_key")
+	clientKey, clientKeyPath := newSSHSigner(t, dir, "id_ed25519")
+	_, unknownKeyPath := newSSHSigner(t, dir, "id_unknown")
+	address := startSSHServer(t, hostKey, clientKey.PublicKey())
+	host, port, _ := net.SplitHostPort(address)
+	fingerprint := ssh.FingerprintSHA256(hostKey.PublicKey())
+
+	writeKnownHosts := func(name string, key ssh.PublicKey) string {
+		path := filepath.Join(dir, name)
+		line := knownhosts.Line([]string{knownhosts.Normalize(address)}, key) + "\n"
+		if err := os.WriteFile(path, []byte(line), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	knownHosts := writeKnownHosts("known_hosts", hostKey.PublicKey())
+	mismatchedKnownHosts := writeKnownHosts("known_hosts_mismatch", otherHostKey.PublicKey())
+	emptyKnownHosts := filepath.Join(dir, "known_hosts_empty")
+	if err := os.WriteFile(emptyKnownHosts, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A port nothing listens on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedAddress := listener.Addr().String()
+	listener.Close()
+	_, closedPort, _ := net.SplitHostPort(closedAddress)
+
+	details := map[string]interface{}{
+		"server_version":       "SSH-2.0-TestServer",
+		"host_key_fingerprint": fingerprint,
+	}
+
+	tests := []struct {
+		name        string
+		params      map[string]string
+		wantStatus  types.CheckStatus
+		wantOutput  string
+		wantError   string
+		wantDetails map[string]interface{}
+	}{
+		{
+			name:        "password auth",
+			params:      map[string]string{"host": host, "port": port, "user": "alice", "password": "secret"},
+			wantStatus:  types.Success,
+			wantOutput:  "Logged in to " + address + " as 'alice'",
+			wantDetails: details,
+		},
+		{
+			name:        "private key auth with verified host key",
+			params:      map[string]string{"host": host, "port": port, "user": "alice", "private_key_path": clientKeyPath, "known_hosts": knownHosts},
+			wantStatus:  types.Success,
+			wantOutput:  "Logged in to " + address + " as 'alice'",
+			wantDetails: details,
+		},
+		{
+			name:        "successful command",
+			params:      map[string]string{"host": host, "port": port, "user": "alice", "password": "secret", "command": "true"},
+			wantStatus:  types.Success,
+			wantOutput:  "Logged in to " + address + " as 'alice' and ran 'true': ok",
+			wantDetails: details,
+		},
+		{
+			name:        "failing command",
+			params:      map[string]string{"host": host, "port": port, "user": "alice", "password": "secret", "command": "false"},
+			wantStatus:  types.Failure,
+			wantOutput:  "Command 'false' exited with code 1 on " + address + ": something went wrong",
+			wantDetails: details,
+		},
+		{
+			name:       "rejected password",
+			params:     map[string]string{"host": host, "port": port, "user": "alice", "password": "wrong"},
+			wantStatus: types.Failure,
+			wantOutput: "Server " + address + " rejected the login of user 'alice': ssh: handshake failed: ssh: unable to authenticate, attempted methods [none password], no supported methods remain",
+		},
+		{
+			name:       "rejected private key",
+			params:     map[string]string{"host": host, "port": port, "user": "alice", "private_key_path": unknownKeyPath},
+			wantStatus: types.Failure,
+			wantOutput: "Server " + address + " rejected the login of user 'alice': ssh: handshake failed: ssh: unable to authenticate, attempted methods [none publickey], no supported methods remain",
+		},
+		{
+			name:       "unknown host key",
+			params:     map[string]string{"host": host, "port": port, "user": "alice", "password": "secret", "known_hosts": emptyKnownHosts},
+			wantStatus: types.Failure,
+			wantOutput: "Host key " + fingerprint + " of " + address + " is not in the known_hosts file",
+		},
+		{
+			name:       "mismatched host key",
+			params:     map[string]string{"host": host, "port": port, "user": "alice", "password": "secret", "known_hosts": mismatchedKnownHosts},
+			wantStatus: types.Failure,
+			wantOutput: "Host key " + fingerprint + " of " + address + " does not match the known_hosts file",
+		},
+		{
+			name:       "connection refused",
+			params:     map[string]string{"host": host, "port": closedPort, "user": "alice", "password": "secret"},
+			wantStatus: types.Error,
+			wantError:  "Failed to connect to " + closedAddress,
+		},
+		{
+			name:       "missing host",
+			params:     map[string]string{"port": port, "user": "alice", "password": "secret"},
+			wantStatus: types.Error,
+			wantError:  "host parameter is required",
+		},
+		{
+			name:       "missing user",
+			params:     map[string]string{"host": host, "port": port, "password": "secret"},
+			wantStatus: types.Error,
+			wantError:  "user parameter is required",
+		},
+		{
+			name:       "missing auth",
+			params:     map[string]string{"host": host, "port": port, "user": "alice"},
+			wantStatus: types.Error,
+			wantError:  "private_key_path or password parameter is required",
+		},
+		{
+			name:       "missing private key",
+			params:     map[string]string{"host": host, "port": port, "user": "alice", "private_key_path": filepath.Join(dir, "missing")},
+			wantStatus: types.Error,
+			wantError:  "Failed to read private key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CheckSSHConnect(types.CheckItem{
+				Name:       "test-check",
+				Type:       "net.ssh_connect",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.Equal(t, tt.wantOutput, result.Output)
+			assert.Contains(t, result.Error, tt.wantError)
+			assert.Equal(t, tt.wantDetails, result.Details)
+		})
+	}
+}
+
+func TestCheckSSHConnectDialer(t *testing.T) {
+	originalDial := dialSSH
+	defer func() { dialSSH = originalDial }()
+
+	var gotAddress, gotUser string
+	dialSSH = func(address string, config *ssh.ClientConfig) (*ssh.Client, error) {
+		gotAddress, gotUser = address, config.User
+		return nil, errors.New("i/o timeout")
+	}
+
+	// The port defaults to 22
+	result, err := CheckSSHConnect(types.CheckItem{
+		Name:       "test-check",
+		Type:       "net.ssh_connect",
+		Parameters: map[string]string{"host": "bastion.example.com", "user": "deploy", "password": "secret"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "bastion.example.com:22", gotAddress)
+	assert.Equal(t, "deploy", gotUser)
+	assert.Equal(t, types.Error, result.Status)
+	assert.Equal(t, "Failed to connect to bastion.example.com:22: i/o timeout", result.Error)
+}
@@ -0,0 +1,199 @@
+package net
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+const (
+	// defaultBannerBytes is the maximum number of bytes of the banner that are read
+	defaultBannerBytes = 256
+	// defaultBannerTimeout is how long to wait for the server to send its banner
+	defaultBannerTimeout = 5 * time.Second
+)
+
+func init() {
+	checks.Register("net.tcp_banner", "Verifies a TCP server sends the expected banner on connect", CheckTCPBanner,
+		checks.Parameter{Name: "host", Type: checks.ParamString, Description: "Host name or IP address of the server", Required: true},
+		checks.Parameter{Name: "port", Type: checks.ParamInt, Description: "Port of the server", Required: true},
+		checks.Parameter{Name: "expected_banner", Type: checks.ParamString, Description: "Text or regular expression the banner must contain, any banner is accepted if not set"},
+		checks.Parameter{Name: "match", Type: checks.ParamString, Description: "How expected_banner is matched against the banner", Default: "substring",
+			Enum: []string{"substring", "regex"}},
+		checks.Parameter{Name: "read_bytes", Type: checks.ParamInt, Description: "Maximum number of bytes of the banner to read", Default: strconv.Itoa(defaultBannerBytes)},
+		checks.Parameter{Name: "read_timeout", Type: checks.ParamDuration, Description: "Time to wait for the server to send its banner", Default: defaultBannerTimeout.String()},
+	)
+}
+
+// readBanner reads from the connection until limit bytes were read, the read timeout
+// elapsed, or done reports that the data read so far is complete
+func readBanner(conn net.Conn, limit int, timeout time.Duration, done func([]byte) bool) ([]byte, error) {
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	banner := make([]byte, 0, limit)
+	buf := make([]byte, limit)
+	for len(banner) < limit {
+		n, err := conn.Read(buf[:limit-len(banner)])
+		banner = append(banner, buf[:n]...)
+		if err != nil {
+			// A timeout or a closed connection ends the banner
+			if errors.Is(err, os.ErrDeadlineExceeded) || errors.Is(err, io.EOF) {
+				return banner, nil
+			}
+			return banner, err
+		}
+		if done(banner) {
+			break
+		}
+	}
+	return banner, nil
+}
+
+// CheckTCPBanner verifies that a TCP server sends the expected banner on connect, which
+// distinguishes the right service listening on a port from any other
+// Parameters:
+//   - host: host name or IP address of the server
+//   - port: port of the server
+//   - expected_banner: text or regular expression the banner must contain
+//   - match: "substring" or "regex", defaults to substring
+//   - read_bytes: maximum number of bytes of the banner to read, defaults to 256
+//   - read_timeout: time to wait for the server to send its banner, defaults to 5s
+func CheckTCPBanner(item types.CheckItem) (types.CheckResult, error) {
+	host, port := item.Parameters["host"], item.Parameters["port"]
+	if host == "" || port == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "host and port parameters are required",
+		}, nil
+	}
+
+	expected := item.Parameters["expected_banner"]
+	matches := func(banner []byte) bool {
+		return strings.Contains(string(banner), expected)
+	}
+	switch match := item.Parameters["match"]; match {
+	case "", "substring":
+	case "regex":
+		pattern, err := regexp.Compile(expected)
+		if err != nil {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid value for 'expected_banner' parameter: %v", err),
+			}, nil
+		}
+		matches = pattern.Match
+	default:
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Invalid value for 'match' parameter: %s (must be substring or regex)", match),
+		}, nil
+	}
+
+	limit := defaultBannerBytes
+	if bytesStr, ok := item.Parameters["read_bytes"]; ok {
+		var err error
+		limit, err = strconv.Atoi(bytesStr)
+		if err != nil || limit <= 0 {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid value for 'read_bytes' parameter: %s", bytesStr),
+			}, nil
+		}
+	}
+
+	readTimeout := defaultBannerTimeout
+	if timeoutStr, ok := item.Parameters["read_timeout"]; ok {
+		var err error
+		readTimeout, err = time.ParseDuration(timeoutStr)
+		if err == nil && readTimeout <= 0 {
+			err = fmt.Errorf("must be positive")
+		}
+		if err != nil {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid value for 'read_timeout' parameter: %v", err),
+			}, nil
+		}
+	}
+
+	address := net.JoinHostPort(host, port)
+	conn, err := net.DialTimeout("tcp", address, dialTimeout)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Failed to connect to %s: %v", address, err),
+		}, nil
+	}
+	defer conn.Close()
+
+	// Stop reading once the expected banner was received, or after the first line
+	// when any banner is accepted
+	banner, err := readBanner(conn, limit, readTimeout, func(banner []byte) bool {
+		if expected != "" {
+			return matches(banner)
+		}
+		return strings.Contains(string(banner), "\n")
+	})
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Failed to read banner from %s: %v", address, err),
+		}, nil
+	}
+
+	if len(banner) == 0 {
+		if expected == "" {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Success,
+				Output: fmt.Sprintf("Connected to %s, no banner received within %s", address, readTimeout),
+			}, nil
+		}
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("No banner received from %s within %s", address, readTimeout),
+		}, nil
+	}
+
+	text := strings.TrimSpace(string(banner))
+	if expected != "" && !matches(banner) {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("Banner %q from %s does not match %q", text, address, expected),
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Success,
+		Output: fmt.Sprintf("Received banner %q from %s", text, address),
+	}, nil
+}
@@ -0,0 +1,170 @@
+package net
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// startBannerServer starts a TCP server sending the banner to every client and keeping
+// the connection open until the client closes it
+func startBannerServer(t *testing.T, banner string) (string, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				conn.Write([]byte(banner))
+				conn.Read(make([]byte, 1))
+			}()
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	return host, port
+}
+
+func TestCheckTCPBanner(t *testing.T) {
+	host, sshPort := startBannerServer(t, "SSH-2.0-OpenSSH_9.6\r\n")
+	_, silentPort := startBannerServer(t, "")
+
+	// Find a port nothing listens on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, closedPort, _ := net.SplitHostPort(listener.Addr().String())
+	listener.Close()
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   types.CheckResult
+	}{
+		{
+			name:   "banner contains expected text",
+			params: map[string]string{"host": host, "port": sshPort, "expected_banner": "SSH-2.0"},
+			want: types.CheckResult{
+				Status: types.Success,
+				Output: `Received banner "SSH-2.0-OpenSSH_9.6" from ` + net.JoinHostPort(host, sshPort),
+			},
+		},
+		{
+			name:   "banner matches regex",
+			params: map[string]string{"host": host, "port": sshPort, "expected_banner": `^SSH-2\.0-OpenSSH_\d`, "match": "regex"},
+			want: types.CheckResult{
+				Status: types.Success,
+				Output: `Received banner "SSH-2.0-OpenSSH_9.6" from ` + net.JoinHostPort(host, sshPort),
+			},
+		},
+		{
+			name:   "banner does not match",
+			params: map[string]string{"host": host, "port": sshPort, "expected_banner": "220 ", "read_timeout": "200ms"},
+			want: types.CheckResult{
+				Status: types.Failure,
+				Output: `Banner "SSH-2.0-OpenSSH_9.6" from ` + net.JoinHostPort(host, sshPort) + ` does not match "220 "`,
+			},
+		},
+		{
+			name:   "banner is truncated to read_bytes",
+			params: map[string]string{"host": host, "port": sshPort, "expected_banner": "OpenSSH", "read_bytes": "4"},
+			want: types.CheckResult{
+				Status: types.Failure,
+				Output: `Banner "SSH-" from ` + net.JoinHostPort(host, sshPort) + ` does not match "OpenSSH"`,
+			},
+		},
+		{
+			name:   "any banner accepted",
+			params: map[string]string{"host": host, "port": sshPort},
+			want: types.CheckResult{
+				Status: types.Success,
+				Output: `Received banner "SSH-2.0-OpenSSH_9.6" from ` + net.JoinHostPort(host, sshPort),
+			},
+		},
+		{
+			name:   "no banner sent",
+			params: map[string]string{"host": host, "port": silentPort, "expected_banner": "SSH", "read_timeout": "100ms"},
+			want: types.CheckResult{
+				Status: types.Failure,
+				Output: "No banner received from " + net.JoinHostPort(host, silentPort) + " within 100ms",
+			},
+		},
+		{
+			name:   "no banner sent and none expected",
+			params: map[string]string{"host": host, "port": silentPort, "read_timeout": "100ms"},
+			want: types.CheckResult{
+				Status: types.Success,
+				Output: "Connected to " + net.JoinHostPort(host, silentPort) + ", no banner received within 100ms",
+			},
+		},
+		{
+			name:   "connection refused",
+			params: map[string]string{"host": host, "port": closedPort},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "Failed to connect to " + net.JoinHostPort(host, closedPort) + ": dial tcp " + net.JoinHostPort(host, closedPort) + ": connect: connection refused",
+			},
+		},
+		{
+			name:   "invalid regex",
+			params: map[string]string{"host": host, "port": sshPort, "expected_banner": "(", "match": "regex"},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "Invalid value for 'expected_banner' parameter: error parsing regexp: missing closing ): `(`",
+			},
+		},
+		{
+			name:   "invalid match",
+			params: map[string]string{"host": host, "port": sshPort, "match": "glob"},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "Invalid value for 'match' parameter: glob (must be substring or regex)",
+			},
+		},
+		{
+			name:   "invalid read_bytes",
+			params: map[string]string{"host": host, "port": sshPort, "read_bytes": "0"},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "Invalid value for 'read_bytes' parameter: 0",
+			},
+		},
+		{
+			name:   "missing port",
+			params: map[string]string{"host": host},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "host and port parameters are required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			got, err := CheckTCPBanner(types.CheckItem{
+				Name:       "test-check",
+				Type:       "net.tcp_banner",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			tt.want.Name = "test-check"
+			tt.want.Type = "net.tcp_banner"
+			assert.Equal(t, tt.want, got)
+			// Reading stops as soon as the banner is complete
+			assert.Less(t, time.Since(start), 2*time.Second)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package net
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// defaultMessageTimeout is how long to wait for the expected message
+const defaultMessageTimeout = 5 * time.Second
+
+func init() {
+	checks.Register("net.websocket", "Verifies a WebSocket handshake with a server completes, optionally exchanging a message", CheckWebSocket,
+		checks.Parameter{Name: "url", Type: checks.ParamString, Description: "URL of the WebSocket endpoint, e.g. wss://example.com/ws", Required: true},
+		checks.Parameter{Name: "headers", Type: checks.ParamString, Description: "Headers of the handshake request, one \"Name: value\" pair per line", Secret: true},
+		checks.Parameter{Name: "send", Type: checks.ParamString, Description: "Text message to send after the handshake"},
+		checks.Parameter{Name: "expect", Type: checks.ParamString, Description: "Text a message received from the server must contain"},
+		checks.Parameter{Name: "read_timeout", Type: checks.ParamDuration, Description: "Time to wait for the expected message", Default: defaultMessageTimeout.String()},
+	)
+}
+
+// parseHeaders parses "Name: value" pairs, one per line
+func parseHeaders(value string) (http.Header, error) {
+	headers := http.Header{}
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, val, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("header %q is not a \"Name: value\" pair", line)
+		}
+		headers.Add(strings.TrimSpace(name), strings.TrimSpace(val))
+	}
+	return headers, nil
+}
+
+// CheckWebSocket verifies that a WebSocket handshake with a server completes and,
+// optionally, that the server answers a message with the expected one
+// Parameters:
+//   - url: URL of the WebSocket endpoint, with the ws or wss scheme
+//   - headers: headers of the handshake request, one "Name: value" pair per line
+//   - send: text message to send after the handshake
+//   - expect: text a message received from the server must contain
+//   - read_timeout: time to wait for the expected message, defaults to 5s
+func CheckWebSocket(item types.CheckItem) (types.CheckResult, error) {
+	rawURL := item.Parameters["url"]
+	if rawURL == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "url parameter is required",
+		}, nil
+	}
+	endpoint, err := url.Parse(rawURL)
+	if err == nil && endpoint.Scheme != "ws" && endpoint.Scheme != "wss" {
+		err = fmt.Errorf("unsupported scheme %q (must be ws or wss)", endpoint.Scheme)
+	}
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Invalid value for 'url' parameter: %v", err),
+		}, nil
+	}
+
+	headers, err := parseHeaders(item.Parameters["headers"])
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Invalid value for 'headers' parameter: %v", err),
+		}, nil
+	}
+
+	readTimeout := defaultMessageTimeout
+	if timeoutStr, ok := item.Parameters["read_timeout"]; ok {
+		readTimeout, err = time.ParseDuration(timeoutStr)
+		if err == nil && readTimeout <= 0 {
+			err = fmt.Errorf("must be positive")
+		}
+		if err != nil {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid value for 'read_timeout' parameter: %v", err),
+			}, nil
+		}
+	}
+
+	dialer := websocket.Dialer{HandshakeTimeout: dialTimeout, Proxy: http.ProxyFromEnvironment}
+	conn, resp, err := dialer.Dial(rawURL, headers)
+	if err != nil {
+		// A response means the server was reached but refused the upgrade
+		if resp != nil {
+			resp.Body.Close()
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Failure,
+				Output: fmt.Sprintf("Handshake with %s rejected: %s", rawURL, resp.Status),
+			}, nil
+		}
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Failed to connect to %s: %v", rawURL, err),
+		}, nil
+	}
+	defer conn.Close()
+
+	if send := item.Parameters["send"]; send != "" {
+		conn.SetWriteDeadline(time.Now().Add(dialTimeout))
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(send)); err != nil {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Failed to send message to %s: %v", rawURL, err),
+			}, nil
+		}
+	}
+
+	expect := item.Parameters["expect"]
+	if expect == "" {
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Success,
+			Output: fmt.Sprintf("Handshake with %s completed", rawURL),
+		}, nil
+	}
+
+	// Skip unrelated messages, e.g. a welcome message, until the expected one arrives
+	conn.SetReadDeadline(time.Now().Add(readTimeout))
+	var last string
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			var netErr net.Error
+			timedOut := errors.As(err, &netErr) && netErr.Timeout()
+			if !timedOut && !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				return types.CheckResult{
+					Name:   item.Name,
+					Type:   item.Type,
+					Status: types.Error,
+					Error:  fmt.Sprintf("Failed to read message from %s: %v", rawURL, err),
+				}, nil
+			}
+			output := fmt.Sprintf("No message containing %q received from %s within %s", expect, rawURL, readTimeout)
+			if last != "" {
+				output += fmt.Sprintf(", last message: %q", last)
+			}
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Failure,
+				Output: output,
+			}, nil
+		}
+		last = string(message)
+		if strings.Contains(last, expect) {
+			break
+		}
+	}
+
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Success,
+		Output: fmt.Sprintf("Handshake with %s completed, received %q", rawURL, last),
+	}, nil
+}
@@ -0,0 +1,136 @@
+package net
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// startWebSocketServer starts a server that requires a bearer token, greets every client
+// and answers "ping" with "pong"
+func startWebSocketServer(t *testing.T) string {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cr3t" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.WriteMessage(websocket.TextMessage, []byte("welcome"))
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if string(message) == "ping" {
+				conn.WriteMessage(websocket.TextMessage, []byte("pong"))
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestCheckWebSocket(t *testing.T) {
+	endpoint := startWebSocketServer(t)
+	auth := "Authorization: Bearer s3cr3t"
+
+	// Find a port nothing listens on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedURL := "ws://" + listener.Addr().String() + "/ws"
+	listener.Close()
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   types.CheckResult
+	}{
+		{
+			name:   "handshake completes",
+			params: map[string]string{"url": endpoint, "headers": auth},
+			want: types.CheckResult{
+				Status: types.Success,
+				Output: "Handshake with " + endpoint + " completed",
+			},
+		},
+		{
+			name:   "round trip",
+			params: map[string]string{"url": endpoint, "headers": "X-Client: checkers\n" + auth, "send": "ping", "expect": "pong"},
+			want: types.CheckResult{
+				Status: types.Success,
+				Output: "Handshake with " + endpoint + ` completed, received "pong"`,
+			},
+		},
+		{
+			name:   "expected message not received",
+			params: map[string]string{"url": endpoint, "headers": auth, "send": "hello", "expect": "pong", "read_timeout": "200ms"},
+			want: types.CheckResult{
+				Status: types.Failure,
+				Output: `No message containing "pong" received from ` + endpoint + ` within 200ms, last message: "welcome"`,
+			},
+		},
+		{
+			name:   "handshake rejected",
+			params: map[string]string{"url": endpoint},
+			want: types.CheckResult{
+				Status: types.Failure,
+				Output: "Handshake with " + endpoint + " rejected: 401 Unauthorized",
+			},
+		},
+		{
+			name:   "invalid scheme",
+			params: map[string]string{"url": "https://example.com/ws"},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  `Invalid value for 'url' parameter: unsupported scheme "https" (must be ws or wss)`,
+			},
+		},
+		{
+			name:   "invalid headers",
+			params: map[string]string{"url": endpoint, "headers": "Bearer s3cr3t"},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  `Invalid value for 'headers' parameter: header "Bearer s3cr3t" is not a "Name: value" pair`,
+			},
+		},
+		{
+			name:   "missing url",
+			params: map[string]string{},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "url parameter is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckWebSocket(types.CheckItem{Name: "test", Type: "net.websocket", Parameters: tt.params})
+			assert.NoError(t, err)
+			tt.want.Name = "test"
+			tt.want.Type = "net.websocket"
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("connection failure", func(t *testing.T) {
+		got, err := CheckWebSocket(types.CheckItem{Name: "test", Type: "net.websocket", Parameters: map[string]string{"url": closedURL}})
+		assert.NoError(t, err)
+		assert.Equal(t, types.Error, got.Status)
+		assert.Contains(t, got.Error, "Failed to connect to "+closedURL)
+	})
+}
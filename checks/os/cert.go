@@ -0,0 +1,139 @@
+package os
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// defaultCertWarnDays is the number of days before expiry from which a certificate is reported as a warning
+const defaultCertWarnDays = 30
+
+func init() {
+	checks.Register("os.cert_file_expiry", "Check if a PEM certificate file is valid and not about to expire", CheckCertFileExpiry,
+		checks.Parameter{Name: "path", Type: checks.ParamString, Description: "Path to the PEM certificate file", Required: true},
+		checks.Parameter{Name: "warn_days", Type: checks.ParamInt, Description: "Number of days before expiry from which a warning is reported", Default: strconv.Itoa(defaultCertWarnDays)},
+	)
+}
+
+// CheckCertFileExpiry checks if the certificates in a PEM file are valid and not about to
+// expire. For files with several certificates, e.g. a chain, the certificate expiring
+// first is reported.
+// Parameters:
+//   - path: path to the PEM certificate file
+//   - warn_days: number of days before expiry from which a warning is reported, defaults to 30
+func CheckCertFileExpiry(item types.CheckItem) (types.CheckResult, error) {
+	path, ok := item.Parameters["path"]
+	if !ok || path == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "path parameter is required",
+		}, nil
+	}
+
+	warnDays := defaultCertWarnDays
+	if warnStr, ok := item.Parameters["warn_days"]; ok {
+		var err error
+		warnDays, err = strconv.Atoi(warnStr)
+		if err != nil || warnDays < 0 {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid value for 'warn_days' parameter: %s", warnStr),
+			}, nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Error reading certificate file '%s': %v", path, err),
+		}, nil
+	}
+
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Error parsing certificate in '%s': %v", path, err),
+			}, nil
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("No PEM certificate found in '%s'", path),
+		}, nil
+	}
+
+	now := timeNow()
+	for _, cert := range certs {
+		if now.Before(cert.NotBefore) {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Failure,
+				Output: fmt.Sprintf("Certificate '%s' is not valid before %s",
+					cert.Subject, cert.NotBefore.UTC().Format(time.RFC3339)),
+			}, nil
+		}
+	}
+
+	cert := certs[0]
+	for _, c := range certs[1:] {
+		if c.NotAfter.Before(cert.NotAfter) {
+			cert = c
+		}
+	}
+	expiry := cert.NotAfter.UTC().Format(time.RFC3339)
+	remaining := cert.NotAfter.Sub(now)
+
+	if remaining <= 0 {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("Certificate '%s' expired at %s", cert.Subject, expiry),
+		}, nil
+	}
+
+	days := int(math.Floor(remaining.Hours() / 24))
+	if remaining < time.Duration(warnDays)*24*time.Hour {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Warning,
+			Output: fmt.Sprintf("Certificate '%s' expires in %d days at %s", cert.Subject, days, expiry),
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Success,
+		Output: fmt.Sprintf("Certificate '%s' is valid for %d more days until %s", cert.Subject, days, expiry),
+	}, nil
+}
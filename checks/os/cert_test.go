@@ -0,0 +1,190 @@
+package os
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeCert writes a self-signed PEM certificate for the common name and validity period
+func writeCert(t *testing.T, path, commonName string, notBefore, notAfter time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := pem.Encode(f, &pem.Block{Type: "CERTIFICATE", Bytes: der}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckCertFileExpiry(t *testing.T) {
+	// Save original values and restore them after test
+	originalTimeNow := timeNow
+	defer func() { timeNow = originalTimeNow }()
+
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+
+	tmpDir := t.TempDir()
+	valid := filepath.Join(tmpDir, "valid.pem")
+	writeCert(t, valid, "valid.example.com", now.AddDate(0, -1, 0), now.AddDate(0, 0, 90))
+	expiring := filepath.Join(tmpDir, "expiring.pem")
+	writeCert(t, expiring, "expiring.example.com", now.AddDate(0, -1, 0), now.AddDate(0, 0, 10))
+	expired := filepath.Join(tmpDir, "expired.pem")
+	writeCert(t, expired, "expired.example.com", now.AddDate(0, -2, 0), now.AddDate(0, 0, -1))
+	future := filepath.Join(tmpDir, "future.pem")
+	writeCert(t, future, "future.example.com", now.AddDate(0, 0, 1), now.AddDate(0, 0, 90))
+	chain := filepath.Join(tmpDir, "chain.pem")
+	writeCert(t, chain, "leaf.example.com", now.AddDate(0, -1, 0), now.AddDate(0, 0, 90))
+	writeCert(t, chain, "Intermediate CA", now.AddDate(-1, 0, 0), now.AddDate(0, 0, 20))
+	notPEM := filepath.Join(tmpDir, "not.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   types.CheckResult
+	}{
+		{
+			name:   "valid certificate",
+			params: map[string]string{"path": valid},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cert_file_expiry",
+				Status: types.Success,
+				Output: "Certificate 'CN=valid.example.com' is valid for 90 more days until 2025-06-08T12:00:00Z",
+			},
+		},
+		{
+			name:   "certificate expiring soon",
+			params: map[string]string{"path": expiring},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cert_file_expiry",
+				Status: types.Warning,
+				Output: "Certificate 'CN=expiring.example.com' expires in 10 days at 2025-03-20T12:00:00Z",
+			},
+		},
+		{
+			name:   "custom warn_days",
+			params: map[string]string{"path": expiring, "warn_days": "7"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cert_file_expiry",
+				Status: types.Success,
+				Output: "Certificate 'CN=expiring.example.com' is valid for 10 more days until 2025-03-20T12:00:00Z",
+			},
+		},
+		{
+			name:   "expired certificate",
+			params: map[string]string{"path": expired},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cert_file_expiry",
+				Status: types.Failure,
+				Output: "Certificate 'CN=expired.example.com' expired at 2025-03-09T12:00:00Z",
+			},
+		},
+		{
+			name:   "certificate not yet valid",
+			params: map[string]string{"path": future},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cert_file_expiry",
+				Status: types.Failure,
+				Output: "Certificate 'CN=future.example.com' is not valid before 2025-03-11T12:00:00Z",
+			},
+		},
+		{
+			name:   "chain reports certificate expiring first",
+			params: map[string]string{"path": chain},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cert_file_expiry",
+				Status: types.Warning,
+				Output: "Certificate 'CN=Intermediate CA' expires in 20 days at 2025-03-30T12:00:00Z",
+			},
+		},
+		{
+			name:   "file without certificate",
+			params: map[string]string{"path": notPEM},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cert_file_expiry",
+				Status: types.Error,
+				Error:  "No PEM certificate found in '" + notPEM + "'",
+			},
+		},
+		{
+			name:   "missing file",
+			params: map[string]string{"path": filepath.Join(tmpDir, "missing.pem")},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cert_file_expiry",
+				Status: types.Error,
+				Error:  "Error reading certificate file '" + filepath.Join(tmpDir, "missing.pem") + "': open " + filepath.Join(tmpDir, "missing.pem") + ": no such file or directory",
+			},
+		},
+		{
+			name:   "invalid warn_days",
+			params: map[string]string{"path": valid, "warn_days": "soon"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cert_file_expiry",
+				Status: types.Error,
+				Error:  "Invalid value for 'warn_days' parameter: soon",
+			},
+		},
+		{
+			name:   "missing path",
+			params: map[string]string{},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cert_file_expiry",
+				Status: types.Error,
+				Error:  "path parameter is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckCertFileExpiry(types.CheckItem{
+				Name:       "test-check",
+				Type:       "os.cert_file_expiry",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
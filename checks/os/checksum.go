@@ -0,0 +1,113 @@
+package os
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// checksumAlgorithms are the supported hash algorithms of os.file_checksum
+var checksumAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+}
+
+func init() {
+	checks.Register("os.file_checksum", "Check if the checksum of a file matches the expected digest", CheckFileChecksum,
+		checks.Parameter{Name: "path", Type: checks.ParamString, Description: "Path of the file", Required: true},
+		checks.Parameter{Name: "expected", Type: checks.ParamString, Description: "Expected hex digest of the file", Required: true},
+		checks.Parameter{Name: "algorithm", Type: checks.ParamString, Description: "Hash algorithm", Default: "sha256",
+			Enum: []string{"sha256", "sha1", "md5"}},
+	)
+}
+
+// CheckFileChecksum checks if the digest of a file matches the expected digest. The
+// file is streamed, so large artifacts are not loaded into memory.
+// Parameters:
+//   - path: path of the file
+//   - expected: expected hex digest, compared case-insensitively
+//   - algorithm: hash algorithm, one of sha256 (default), sha1 and md5
+func CheckFileChecksum(item types.CheckItem) (types.CheckResult, error) {
+	path := item.Parameters["path"]
+	if path == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "path parameter is required",
+		}, nil
+	}
+	expected := strings.ToLower(strings.TrimSpace(item.Parameters["expected"]))
+	if expected == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "expected parameter is required",
+		}, nil
+	}
+
+	algorithm := item.Parameters["algorithm"]
+	if algorithm == "" {
+		algorithm = "sha256"
+	}
+	newHash, ok := checksumAlgorithms[strings.ToLower(algorithm)]
+	if !ok {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Invalid value for 'algorithm' parameter: %s (supported: sha256, sha1, md5)", algorithm),
+		}, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Error reading file '%s': %v", path, err),
+		}, nil
+	}
+	defer f.Close()
+
+	h := newHash()
+	if _, err := io.Copy(h, f); err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Error reading file '%s': %v", path, err),
+		}, nil
+	}
+	actual := hex.EncodeToString(h.Sum(nil))
+
+	if actual != expected {
+		return types.CheckResult{
+			Name:     item.Name,
+			Type:     item.Type,
+			Status:   types.Failure,
+			Output:   fmt.Sprintf("%s checksum of '%s' is %s, expected %s", algorithm, path, actual, expected),
+			Expected: expected,
+			Actual:   actual,
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Success,
+		Output: fmt.Sprintf("%s checksum of '%s' matches %s", algorithm, path, actual),
+	}, nil
+}
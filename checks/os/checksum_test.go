@@ -0,0 +1,115 @@
+package os
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckFileChecksum(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "artifact.txt")
+	if err := os.WriteFile(path, []byte("hello world\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	const (
+		sha256Sum = "a948904f2f0f479b8f8197694b30184b0d2ed1c1cd2a1ec0fb85d299a192a447"
+		sha1Sum   = "22596363b3de40b06f981fb85d82312e8c0ed511"
+		md5Sum    = "6f5902ac237024bdd0c176cb93063dc4"
+	)
+	missing := filepath.Join(tmpDir, "missing.txt")
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   types.CheckResult
+	}{
+		{
+			name:   "matching sha256 by default",
+			params: map[string]string{"path": path, "expected": sha256Sum},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.file_checksum",
+				Status: types.Success,
+				Output: "sha256 checksum of '" + path + "' matches " + sha256Sum,
+			},
+		},
+		{
+			name:   "matching sha1 ignoring case",
+			params: map[string]string{"path": path, "expected": "22596363B3DE40B06F981FB85D82312E8C0ED511", "algorithm": "sha1"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.file_checksum",
+				Status: types.Success,
+				Output: "sha1 checksum of '" + path + "' matches " + sha1Sum,
+			},
+		},
+		{
+			name:   "mismatching md5",
+			params: map[string]string{"path": path, "expected": "d41d8cd98f00b204e9800998ecf8427e", "algorithm": "md5"},
+			want: types.CheckResult{
+				Name:     "test-check",
+				Type:     "os.file_checksum",
+				Status:   types.Failure,
+				Output:   "md5 checksum of '" + path + "' is " + md5Sum + ", expected d41d8cd98f00b204e9800998ecf8427e",
+				Expected: "d41d8cd98f00b204e9800998ecf8427e",
+				Actual:   md5Sum,
+			},
+		},
+		{
+			name:   "unknown algorithm",
+			params: map[string]string{"path": path, "expected": sha256Sum, "algorithm": "crc32"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.file_checksum",
+				Status: types.Error,
+				Error:  "Invalid value for 'algorithm' parameter: crc32 (supported: sha256, sha1, md5)",
+			},
+		},
+		{
+			name:   "missing file",
+			params: map[string]string{"path": missing, "expected": sha256Sum},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.file_checksum",
+				Status: types.Error,
+				Error:  "Error reading file '" + missing + "': open " + missing + ": no such file or directory",
+			},
+		},
+		{
+			name:   "missing expected",
+			params: map[string]string{"path": path},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.file_checksum",
+				Status: types.Error,
+				Error:  "expected parameter is required",
+			},
+		},
+		{
+			name:   "missing path",
+			params: map[string]string{"expected": sha256Sum},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.file_checksum",
+				Status: types.Error,
+				Error:  "path parameter is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckFileChecksum(types.CheckItem{
+				Name:       "test-check",
+				Type:       "os.file_checksum",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
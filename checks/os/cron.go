@@ -0,0 +1,165 @@
+package os
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// defaultCronGrace is the time a job is given to update its marker file after a scheduled run
+const defaultCronGrace = 5 * time.Minute
+
+// maxCronLookback limits how far back the last scheduled run of a cron expression is searched
+const maxCronLookback = 5 * 366 * 24 * time.Hour
+
+// for testing
+var timeNow = time.Now
+
+func init() {
+	checks.Register("os.cron_freshness", "Check if a cron job has run since its last scheduled time", CheckCronFreshness,
+		checks.Parameter{Name: "cron", Type: checks.ParamString, Description: "Cron expression of the job's schedule, e.g. \"0 3 * * *\" or \"@hourly\"", Required: true},
+		checks.Parameter{Name: "marker_file", Type: checks.ParamString, Description: "Path to the file the job touches when it runs", Required: true},
+		checks.Parameter{Name: "grace", Type: checks.ParamDuration, Description: "Time the job has to update the marker after a scheduled run", Default: defaultCronGrace.String()},
+		checks.Parameter{Name: "timezone", Type: checks.ParamString, Description: "IANA time zone the schedule is evaluated in, defaults to the local time zone"},
+	)
+}
+
+// previousRun returns the last time before t at which the schedule fired
+func previousRun(schedule cron.Schedule, t time.Time) (time.Time, bool) {
+	// Search backwards with a growing window, then walk forward to the last run before t
+	for window := time.Hour; window <= maxCronLookback; window *= 2 {
+		run := schedule.Next(t.Add(-window))
+		if run.IsZero() || run.After(t) {
+			continue
+		}
+		for {
+			next := schedule.Next(run)
+			if next.IsZero() || next.After(t) {
+				return run, true
+			}
+			run = next
+		}
+	}
+	return time.Time{}, false
+}
+
+// CheckCronFreshness checks if a cron job has run since its last scheduled time, based on
+// the modification time of a marker file the job updates on every run
+// Parameters:
+//   - cron: cron expression of the job's schedule, e.g. "0 3 * * *" or "@hourly"
+//   - marker_file: path to the file the job touches when it runs
+//   - grace: time the job has to update the marker after a scheduled run, defaults to 5m
+//   - timezone: IANA time zone the schedule is evaluated in, defaults to the local time zone
+func CheckCronFreshness(item types.CheckItem) (types.CheckResult, error) {
+	expr, ok := item.Parameters["cron"]
+	if !ok || expr == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "cron parameter is required",
+		}, nil
+	}
+
+	markerFile, ok := item.Parameters["marker_file"]
+	if !ok || markerFile == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "marker_file parameter is required",
+		}, nil
+	}
+
+	grace := defaultCronGrace
+	if graceStr, ok := item.Parameters["grace"]; ok {
+		var err error
+		grace, err = time.ParseDuration(graceStr)
+		if err != nil || grace < 0 {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid grace duration '%s'", graceStr),
+			}, nil
+		}
+	}
+
+	location := time.Local
+	if tz, ok := item.Parameters["timezone"]; ok && tz != "" {
+		var err error
+		location, err = time.LoadLocation(tz)
+		if err != nil {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid timezone '%s': %v", tz, err),
+			}, nil
+		}
+	}
+
+	schedule, err := cron.ParseStandard(expr)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Invalid cron expression '%s': %v", expr, err),
+		}, nil
+	}
+
+	// Runs within the grace period may still be in progress, so the job is
+	// expected to have completed the last run before that
+	lastRun, ok := previousRun(schedule, timeNow().In(location).Add(-grace))
+	if !ok {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Cron expression '%s' has no scheduled run in the past", expr),
+		}, nil
+	}
+
+	info, err := os.Stat(markerFile)
+	if os.IsNotExist(err) {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("Marker file '%s' does not exist, the job was scheduled to run at %s",
+				markerFile, lastRun.Format(time.RFC3339)),
+		}, nil
+	}
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Error checking marker file '%s': %v", markerFile, err),
+		}, nil
+	}
+
+	modTime := info.ModTime().In(location)
+	if modTime.Before(lastRun) {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("Marker file '%s' was last updated at %s, but the job was scheduled to run at %s",
+				markerFile, modTime.Format(time.RFC3339), lastRun.Format(time.RFC3339)),
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Success,
+		Output: fmt.Sprintf("Marker file '%s' was updated at %s, after the last scheduled run at %s",
+			markerFile, modTime.Format(time.RFC3339), lastRun.Format(time.RFC3339)),
+	}, nil
+}
@@ -0,0 +1,154 @@
+package os
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCronFreshness(t *testing.T) {
+	// Save original values and restore them after test
+	originalTimeNow := timeNow
+	defer func() { timeNow = originalTimeNow }()
+
+	now := time.Date(2025, 3, 10, 12, 30, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+
+	tmpDir := t.TempDir()
+	writeMarker := func(name string, modTime time.Time) string {
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	fresh := writeMarker("fresh", time.Date(2025, 3, 10, 3, 2, 0, 0, time.UTC))
+	stale := writeMarker("stale", time.Date(2025, 3, 9, 3, 2, 0, 0, time.UTC))
+	missing := filepath.Join(tmpDir, "missing")
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   types.CheckResult
+	}{
+		{
+			name:   "ran after last scheduled run",
+			params: map[string]string{"cron": "0 3 * * *", "marker_file": fresh, "timezone": "UTC"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cron_freshness",
+				Status: types.Success,
+				Output: "Marker file '" + fresh + "' was updated at 2025-03-10T03:02:00Z, after the last scheduled run at 2025-03-10T03:00:00Z",
+			},
+		},
+		{
+			name:   "missed last scheduled run",
+			params: map[string]string{"cron": "@daily", "marker_file": stale, "timezone": "UTC"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cron_freshness",
+				Status: types.Failure,
+				Output: "Marker file '" + stale + "' was last updated at 2025-03-09T03:02:00Z, but the job was scheduled to run at 2025-03-10T00:00:00Z",
+			},
+		},
+		{
+			name:   "run within grace period is not expected yet",
+			params: map[string]string{"cron": "0 12 * * *", "marker_file": fresh, "timezone": "UTC", "grace": "1h"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cron_freshness",
+				Status: types.Success,
+				Output: "Marker file '" + fresh + "' was updated at 2025-03-10T03:02:00Z, after the last scheduled run at 2025-03-09T12:00:00Z",
+			},
+		},
+		{
+			name:   "schedule in another timezone",
+			params: map[string]string{"cron": "0 3 * * *", "marker_file": fresh, "timezone": "America/New_York"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cron_freshness",
+				Status: types.Failure,
+				Output: "Marker file '" + fresh + "' was last updated at 2025-03-09T23:02:00-04:00, but the job was scheduled to run at 2025-03-10T03:00:00-04:00",
+			},
+		},
+		{
+			name:   "missing marker file",
+			params: map[string]string{"cron": "0 3 * * *", "marker_file": missing, "timezone": "UTC"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cron_freshness",
+				Status: types.Failure,
+				Output: "Marker file '" + missing + "' does not exist, the job was scheduled to run at 2025-03-10T03:00:00Z",
+			},
+		},
+		{
+			name:   "invalid cron expression",
+			params: map[string]string{"cron": "61 * * * *", "marker_file": fresh},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cron_freshness",
+				Status: types.Error,
+				Error:  "Invalid cron expression '61 * * * *': end of range (61) above maximum (59): 61",
+			},
+		},
+		{
+			name:   "invalid timezone",
+			params: map[string]string{"cron": "0 3 * * *", "marker_file": fresh, "timezone": "Mars/Olympus"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cron_freshness",
+				Status: types.Error,
+				Error:  "Invalid timezone 'Mars/Olympus': unknown time zone Mars/Olympus",
+			},
+		},
+		{
+			name:   "invalid grace",
+			params: map[string]string{"cron": "0 3 * * *", "marker_file": fresh, "grace": "soon"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cron_freshness",
+				Status: types.Error,
+				Error:  "Invalid grace duration 'soon'",
+			},
+		},
+		{
+			name:   "missing cron parameter",
+			params: map[string]string{"marker_file": fresh},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cron_freshness",
+				Status: types.Error,
+				Error:  "cron parameter is required",
+			},
+		},
+		{
+			name:   "missing marker_file parameter",
+			params: map[string]string{"cron": "0 3 * * *"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.cron_freshness",
+				Status: types.Error,
+				Error:  "marker_file parameter is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckCronFreshness(types.CheckItem{
+				Name:       "test-check",
+				Type:       "os.cron_freshness",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package os
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+func init() {
+	checks.Register("os.dir_file_count", "Check if a directory contains the expected number of files", CheckDirFileCount,
+		checks.Parameter{Name: "path", Type: checks.ParamString, Description: "Path to the directory", Required: true},
+		checks.Parameter{Name: "pattern", Type: checks.ParamString, Description: "Glob pattern the file names have to match, e.g. *.tar.gz, defaults to all files"},
+		checks.Parameter{Name: "min", Type: checks.ParamInt, Description: "Minimum number of files, at least one of min and max is required"},
+		checks.Parameter{Name: "max", Type: checks.ParamInt, Description: "Maximum number of files, at least one of min and max is required"},
+	)
+}
+
+// countFiles counts the entries of a directory that are not directories themselves and
+// whose name matches the pattern, without descending into subdirectories
+func countFiles(dir, pattern string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if pattern != "" {
+			// The pattern was validated before, so matching cannot fail
+			if matched, _ := filepath.Match(pattern, entry.Name()); !matched {
+				continue
+			}
+		}
+		count++
+	}
+	return count, nil
+}
+
+// parseCountBound parses the optional min or max parameter, -1 meaning unset
+func parseCountBound(params map[string]string, name string) (int, error) {
+	value, ok := params[name]
+	if !ok || value == "" {
+		return -1, nil
+	}
+	bound, err := strconv.Atoi(value)
+	if err != nil || bound < 0 {
+		return 0, fmt.Errorf("Invalid value for '%s' parameter: %s", name, value)
+	}
+	return bound, nil
+}
+
+// CheckDirFileCount checks if the number of files in a directory is within the expected
+// range, e.g. that a backup directory holds enough artifacts or a spool directory is not
+// backing up
+// Parameters:
+//   - path: path to the directory
+//   - pattern: glob pattern the file names have to match, defaults to all files
+//   - min: minimum number of files
+//   - max: maximum number of files
+func CheckDirFileCount(item types.CheckItem) (types.CheckResult, error) {
+	dir := item.Parameters["path"]
+	if dir == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "path parameter is required",
+		}, nil
+	}
+
+	pattern := item.Parameters["pattern"]
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Invalid value for 'pattern' parameter: %s", pattern),
+		}, nil
+	}
+
+	minCount, err := parseCountBound(item.Parameters, "min")
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  err.Error(),
+		}, nil
+	}
+	maxCount, err := parseCountBound(item.Parameters, "max")
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  err.Error(),
+		}, nil
+	}
+	if minCount < 0 && maxCount < 0 {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "min or max parameter is required",
+		}, nil
+	}
+	if maxCount >= 0 && minCount > maxCount {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("min (%d) must not be greater than max (%d)", minCount, maxCount),
+		}, nil
+	}
+
+	count, err := countFiles(dir, pattern)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Error reading directory '%s': %v", dir, err),
+		}, nil
+	}
+
+	files := "files"
+	if pattern != "" {
+		files = fmt.Sprintf("files matching '%s'", pattern)
+	}
+	var expected string
+	switch {
+	case minCount >= 0 && maxCount >= 0:
+		expected = fmt.Sprintf("between %d and %d", minCount, maxCount)
+	case minCount >= 0:
+		expected = fmt.Sprintf("at least %d", minCount)
+	default:
+		expected = fmt.Sprintf("at most %d", maxCount)
+	}
+	details := map[string]interface{}{"count": count}
+
+	if (minCount >= 0 && count < minCount) || (maxCount >= 0 && count > maxCount) {
+		return types.CheckResult{
+			Name:     item.Name,
+			Type:     item.Type,
+			Status:   types.Failure,
+			Output:   fmt.Sprintf("Directory '%s' contains %d %s, expected %s", dir, count, files, expected),
+			Expected: expected,
+			Actual:   strconv.Itoa(count),
+			Details:  details,
+		}, nil
+	}
+	return types.CheckResult{
+		Name:    item.Name,
+		Type:    item.Type,
+		Status:  types.Success,
+		Output:  fmt.Sprintf("Directory '%s' contains %d %s", dir, count, files),
+		Details: details,
+	}, nil
+}
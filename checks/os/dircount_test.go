@@ -0,0 +1,153 @@
+package os
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckDirFileCount(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"backup-1.tar.gz", "backup-2.tar.gz", "backup-3.tar.gz", "README", ".lock"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Subdirectories are not counted, even when their name matches
+	if err := os.Mkdir(filepath.Join(dir, "old.tar.gz"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing")
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   types.CheckResult
+	}{
+		{
+			name:   "within range",
+			params: map[string]string{"path": dir, "pattern": "*.tar.gz", "min": "3", "max": "7"},
+			want: types.CheckResult{
+				Name:    "test-check",
+				Type:    "os.dir_file_count",
+				Status:  types.Success,
+				Output:  "Directory '" + dir + "' contains 3 files matching '*.tar.gz'",
+				Details: map[string]interface{}{"count": 3},
+			},
+		},
+		{
+			name:   "too few files",
+			params: map[string]string{"path": dir, "pattern": "*.tar.gz", "min": "7"},
+			want: types.CheckResult{
+				Name:     "test-check",
+				Type:     "os.dir_file_count",
+				Status:   types.Failure,
+				Output:   "Directory '" + dir + "' contains 3 files matching '*.tar.gz', expected at least 7",
+				Expected: "at least 7",
+				Actual:   "3",
+				Details:  map[string]interface{}{"count": 3},
+			},
+		},
+		{
+			name:   "too many files",
+			params: map[string]string{"path": dir, "max": "4"},
+			want: types.CheckResult{
+				Name:     "test-check",
+				Type:     "os.dir_file_count",
+				Status:   types.Failure,
+				Output:   "Directory '" + dir + "' contains 5 files, expected at most 4",
+				Expected: "at most 4",
+				Actual:   "5",
+				Details:  map[string]interface{}{"count": 5},
+			},
+		},
+		{
+			name:   "out of range",
+			params: map[string]string{"path": dir, "pattern": "*.log", "min": "1", "max": "10"},
+			want: types.CheckResult{
+				Name:     "test-check",
+				Type:     "os.dir_file_count",
+				Status:   types.Failure,
+				Output:   "Directory '" + dir + "' contains 0 files matching '*.log', expected between 1 and 10",
+				Expected: "between 1 and 10",
+				Actual:   "0",
+				Details:  map[string]interface{}{"count": 0},
+			},
+		},
+		{
+			name:   "missing directory",
+			params: map[string]string{"path": missing, "min": "1"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.dir_file_count",
+				Status: types.Error,
+				Error:  "Error reading directory '" + missing + "': open " + missing + ": no such file or directory",
+			},
+		},
+		{
+			name:   "missing path parameter",
+			params: map[string]string{"min": "1"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.dir_file_count",
+				Status: types.Error,
+				Error:  "path parameter is required",
+			},
+		},
+		{
+			name:   "missing bounds",
+			params: map[string]string{"path": dir},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.dir_file_count",
+				Status: types.Error,
+				Error:  "min or max parameter is required",
+			},
+		},
+		{
+			name:   "invalid min",
+			params: map[string]string{"path": dir, "min": "-1"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.dir_file_count",
+				Status: types.Error,
+				Error:  "Invalid value for 'min' parameter: -1",
+			},
+		},
+		{
+			name:   "min greater than max",
+			params: map[string]string{"path": dir, "min": "5", "max": "2"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.dir_file_count",
+				Status: types.Error,
+				Error:  "min (5) must not be greater than max (2)",
+			},
+		},
+		{
+			name:   "invalid pattern",
+			params: map[string]string{"path": dir, "pattern": "[", "min": "1"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.dir_file_count",
+				Status: types.Error,
+				Error:  "Invalid value for 'pattern' parameter: [",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckDirFileCount(types.CheckItem{
+				Name:       "test-check",
+				Type:       "os.dir_file_count",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package os
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// compareChunkSize is the number of bytes of each file compared at a time
+const compareChunkSize = 64 * 1024
+
+func init() {
+	checks.Register("os.files_equal", "Check if two files have identical contents", CheckFilesEqual,
+		checks.Parameter{Name: "path_a", Type: checks.ParamString, Description: "Path of the first file", Required: true},
+		checks.Parameter{Name: "path_b", Type: checks.ParamString, Description: "Path of the second file, e.g. a reference copy", Required: true},
+	)
+}
+
+// firstDifference streams both readers and returns the offset of the first byte in
+// which they differ, or -1 if they are identical. When one is a prefix of the other,
+// the difference is at the end of the shorter one.
+func firstDifference(a, b io.Reader) (int64, error) {
+	bufA := make([]byte, compareChunkSize)
+	bufB := make([]byte, compareChunkSize)
+	var offset int64
+	for {
+		nA, errA := io.ReadFull(a, bufA)
+		if errA != nil && !errors.Is(errA, io.EOF) && !errors.Is(errA, io.ErrUnexpectedEOF) {
+			return 0, errA
+		}
+		nB, errB := io.ReadFull(b, bufB)
+		if errB != nil && !errors.Is(errB, io.EOF) && !errors.Is(errB, io.ErrUnexpectedEOF) {
+			return 0, errB
+		}
+
+		n := min(nA, nB)
+		if !bytes.Equal(bufA[:n], bufB[:n]) {
+			for i := 0; i < n; i++ {
+				if bufA[i] != bufB[i] {
+					return offset + int64(i), nil
+				}
+			}
+		}
+		if nA != nB {
+			return offset + int64(n), nil
+		}
+		// A short read means both files ended
+		if nA < compareChunkSize {
+			return -1, nil
+		}
+		offset += int64(n)
+	}
+}
+
+// CheckFilesEqual checks if two files have identical contents, e.g. that a deployed
+// file matches its reference copy. Both files are streamed, so large files are not
+// loaded into memory.
+// Parameters:
+//   - path_a: path of the first file
+//   - path_b: path of the second file
+func CheckFilesEqual(item types.CheckItem) (types.CheckResult, error) {
+	pathA, pathB := item.Parameters["path_a"], item.Parameters["path_b"]
+	if pathA == "" || pathB == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "path_a and path_b parameters are required",
+		}, nil
+	}
+
+	fileA, err := os.Open(pathA)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Error reading file '%s': %v", pathA, err),
+		}, nil
+	}
+	defer fileA.Close()
+
+	fileB, err := os.Open(pathB)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Error reading file '%s': %v", pathB, err),
+		}, nil
+	}
+	defer fileB.Close()
+
+	offset, err := firstDifference(fileA, fileB)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Error comparing '%s' and '%s': %v", pathA, pathB, err),
+		}, nil
+	}
+
+	if offset >= 0 {
+		return types.CheckResult{
+			Name:    item.Name,
+			Type:    item.Type,
+			Status:  types.Failure,
+			Output:  fmt.Sprintf("'%s' and '%s' differ at byte offset %d", pathA, pathB, offset),
+			Details: map[string]interface{}{"offset": offset},
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Success,
+		Output: fmt.Sprintf("'%s' and '%s' are identical", pathA, pathB),
+	}, nil
+}
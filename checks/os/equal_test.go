@@ -0,0 +1,101 @@
+package os
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckFilesEqual(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(name string, content []byte) string {
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	// Spans several chunks, so differences past the first chunk are found
+	large := bytes.Repeat([]byte("0123456789abcdef"), compareChunkSize/8)
+	changed := append([]byte{}, large...)
+	changed[compareChunkSize+5] = 'X'
+
+	reference := write("reference.conf", large)
+	identical := write("identical.conf", large)
+	modified := write("modified.conf", changed)
+	truncated := write("truncated.conf", large[:compareChunkSize])
+	empty := write("empty.conf", nil)
+	missing := filepath.Join(tmpDir, "missing.conf")
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   types.CheckResult
+	}{
+		{
+			name:   "identical files",
+			params: map[string]string{"path_a": identical, "path_b": reference},
+			want: types.CheckResult{
+				Status: types.Success,
+				Output: "'" + identical + "' and '" + reference + "' are identical",
+			},
+		},
+		{
+			name:   "different byte",
+			params: map[string]string{"path_a": modified, "path_b": reference},
+			want: types.CheckResult{
+				Status:  types.Failure,
+				Output:  "'" + modified + "' and '" + reference + "' differ at byte offset 65541",
+				Details: map[string]interface{}{"offset": int64(compareChunkSize + 5)},
+			},
+		},
+		{
+			name:   "shorter file",
+			params: map[string]string{"path_a": truncated, "path_b": reference},
+			want: types.CheckResult{
+				Status:  types.Failure,
+				Output:  "'" + truncated + "' and '" + reference + "' differ at byte offset 65536",
+				Details: map[string]interface{}{"offset": int64(compareChunkSize)},
+			},
+		},
+		{
+			name:   "empty files",
+			params: map[string]string{"path_a": empty, "path_b": empty},
+			want: types.CheckResult{
+				Status: types.Success,
+				Output: "'" + empty + "' and '" + empty + "' are identical",
+			},
+		},
+		{
+			name:   "unreadable file",
+			params: map[string]string{"path_a": reference, "path_b": missing},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "Error reading file '" + missing + "': open " + missing + ": no such file or directory",
+			},
+		},
+		{
+			name:   "missing path",
+			params: map[string]string{"path_a": reference},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "path_a and path_b parameters are required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckFilesEqual(types.CheckItem{Name: "test-check", Type: "os.files_equal", Parameters: tt.params})
+			assert.NoError(t, err)
+			tt.want.Name = "test-check"
+			tt.want.Type = "os.files_equal"
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
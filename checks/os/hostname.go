@@ -0,0 +1,124 @@
+package os
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// for testing
+var (
+	hostname    = os.Hostname
+	lookupCNAME = net.LookupCNAME
+)
+
+func init() {
+	checks.Register("os.hostname", "Check if the hostname of the machine is the expected one", CheckHostname,
+		checks.Parameter{Name: "expected", Type: checks.ParamString, Description: "Expected hostname, compared case-insensitively"},
+		checks.Parameter{Name: "regex", Type: checks.ParamString, Description: "Regular expression the hostname must match, instead of expected"},
+		checks.Parameter{Name: "fqdn", Type: checks.ParamBool, Description: "Compare the fully qualified domain name instead of the hostname", Default: "false"},
+	)
+}
+
+// CheckHostname checks if the hostname of the machine is the expected one, e.g. to verify
+// that a provisioned host got the right name
+// Parameters:
+//   - expected: expected hostname, compared case-insensitively
+//   - regex: regular expression the hostname must match, instead of expected
+//   - fqdn: resolve and compare the fully qualified domain name, defaults to false
+func CheckHostname(item types.CheckItem) (types.CheckResult, error) {
+	expected, pattern := item.Parameters["expected"], item.Parameters["regex"]
+	if (expected == "") == (pattern == "") {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "exactly one of the expected and regex parameters is required",
+		}, nil
+	}
+
+	var re *regexp.Regexp
+	if pattern != "" {
+		var err error
+		re, err = regexp.Compile(pattern)
+		if err != nil {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid value for 'regex' parameter: %v", err),
+			}, nil
+		}
+	}
+
+	fqdn := false
+	if value, ok := item.Parameters["fqdn"]; ok {
+		var err error
+		fqdn, err = strconv.ParseBool(value)
+		if err != nil {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid value for 'fqdn' parameter: %s", value),
+			}, nil
+		}
+	}
+
+	name, err := hostname()
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Error getting hostname: %v", err),
+		}, nil
+	}
+	kind := "Hostname"
+	if fqdn {
+		// The canonical name of the hostname is its fully qualified domain name
+		cname, err := lookupCNAME(name)
+		if err != nil {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Error resolving the FQDN of '%s': %v", name, err),
+			}, nil
+		}
+		name, kind = strings.TrimSuffix(cname, "."), "FQDN"
+	}
+
+	if re != nil {
+		if !re.MatchString(name) {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Failure,
+				Output: fmt.Sprintf("%s '%s' does not match '%s'", kind, name, pattern),
+			}, nil
+		}
+	} else if !strings.EqualFold(name, expected) {
+		return types.CheckResult{
+			Name:     item.Name,
+			Type:     item.Type,
+			Status:   types.Failure,
+			Output:   fmt.Sprintf("%s is '%s', expected '%s'", kind, name, expected),
+			Expected: expected,
+			Actual:   name,
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Success,
+		Output: fmt.Sprintf("%s is '%s'", kind, name),
+	}, nil
+}
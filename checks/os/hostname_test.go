@@ -0,0 +1,159 @@
+package os
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckHostname(t *testing.T) {
+	origHostname, origLookupCNAME := hostname, lookupCNAME
+	defer func() { hostname, lookupCNAME = origHostname, origLookupCNAME }()
+
+	tests := []struct {
+		name        string
+		params      map[string]string
+		hostnameErr error
+		cname       string
+		cnameErr    error
+		want        types.CheckResult
+	}{
+		{
+			name:   "matching hostname ignoring case",
+			params: map[string]string{"expected": "Web-01"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.hostname",
+				Status: types.Success,
+				Output: "Hostname is 'web-01'",
+			},
+		},
+		{
+			name:   "mismatching hostname",
+			params: map[string]string{"expected": "web-02"},
+			want: types.CheckResult{
+				Name:     "test-check",
+				Type:     "os.hostname",
+				Status:   types.Failure,
+				Output:   "Hostname is 'web-01', expected 'web-02'",
+				Expected: "web-02",
+				Actual:   "web-01",
+			},
+		},
+		{
+			name:   "matching regex",
+			params: map[string]string{"regex": `^web-\d+$`},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.hostname",
+				Status: types.Success,
+				Output: "Hostname is 'web-01'",
+			},
+		},
+		{
+			name:   "mismatching regex",
+			params: map[string]string{"regex": `^db-\d+$`},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.hostname",
+				Status: types.Failure,
+				Output: `Hostname 'web-01' does not match '^db-\d+$'`,
+			},
+		},
+		{
+			name:   "matching FQDN",
+			params: map[string]string{"expected": "web-01.prod.example.com", "fqdn": "true"},
+			cname:  "web-01.prod.example.com.",
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.hostname",
+				Status: types.Success,
+				Output: "FQDN is 'web-01.prod.example.com'",
+			},
+		},
+		{
+			name:     "unresolvable FQDN",
+			params:   map[string]string{"expected": "web-01.prod.example.com", "fqdn": "true"},
+			cnameErr: errors.New("no such host"),
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.hostname",
+				Status: types.Error,
+				Error:  "Error resolving the FQDN of 'web-01': no such host",
+			},
+		},
+		{
+			name:        "hostname error",
+			params:      map[string]string{"expected": "web-01"},
+			hostnameErr: errors.New("permission denied"),
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.hostname",
+				Status: types.Error,
+				Error:  "Error getting hostname: permission denied",
+			},
+		},
+		{
+			name:   "invalid regex",
+			params: map[string]string{"regex": "web-("},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.hostname",
+				Status: types.Error,
+				Error:  "Invalid value for 'regex' parameter: error parsing regexp: missing closing ): `web-(`",
+			},
+		},
+		{
+			name:   "invalid fqdn",
+			params: map[string]string{"expected": "web-01", "fqdn": "maybe"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.hostname",
+				Status: types.Error,
+				Error:  "Invalid value for 'fqdn' parameter: maybe",
+			},
+		},
+		{
+			name:   "expected and regex",
+			params: map[string]string{"expected": "web-01", "regex": "web-.*"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.hostname",
+				Status: types.Error,
+				Error:  "exactly one of the expected and regex parameters is required",
+			},
+		},
+		{
+			name:   "neither expected nor regex",
+			params: map[string]string{},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.hostname",
+				Status: types.Error,
+				Error:  "exactly one of the expected and regex parameters is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hostname = func() (string, error) {
+				return "web-01", tt.hostnameErr
+			}
+			lookupCNAME = func(host string) (string, error) {
+				assert.Equal(t, "web-01", host)
+				return tt.cname, tt.cnameErr
+			}
+
+			got, err := CheckHostname(types.CheckItem{
+				Name:       "test-check",
+				Type:       "os.hostname",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package os
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// for testing
+var (
+	procModulesPath = "/proc/modules"
+	libModulesRoot  = "/lib/modules"
+)
+
+func init() {
+	checks.Register("os.kernel_module", "Check if a kernel module is loaded or built into the kernel (Linux only)", CheckKernelModule,
+		checks.Parameter{Name: "name", Type: checks.ParamString, Description: "Name of the kernel module, e.g. br_netfilter", Required: true},
+	)
+}
+
+// loadedModule looks up a module in /proc/modules, returning its state, e.g. "Live",
+// and whether it is loaded
+func loadedModule(name string) (string, bool, error) {
+	f, err := os.Open(procModulesPath)
+	if err != nil {
+		return "", false, err
+	}
+	defer f.Close()
+
+	// Lines are "name size refcount dependencies state address"
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 0 && fields[0] == name {
+			state := ""
+			if len(fields) > 4 {
+				state = fields[4]
+			}
+			return state, true, nil
+		}
+	}
+	return "", false, scanner.Err()
+}
+
+// builtinModule reports whether a module is built into the running kernel according to
+// the modules.builtin file of its release. Missing files are treated as not built in.
+func builtinModule(name string) bool {
+	release, err := os.ReadFile(filepath.Join(procSysRoot, "kernel", "osrelease"))
+	if err != nil {
+		return false
+	}
+	data, err := os.ReadFile(filepath.Join(libModulesRoot, strings.TrimSpace(string(release)), "modules.builtin"))
+	if err != nil {
+		return false
+	}
+	// Lines are paths, e.g. kernel/fs/overlayfs/overlay.ko
+	for _, line := range strings.Split(string(data), "\n") {
+		module := strings.TrimSuffix(filepath.Base(strings.TrimSpace(line)), ".ko")
+		if strings.ReplaceAll(module, "-", "_") == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckKernelModule checks if a kernel module is loaded, e.g. overlay or br_netfilter
+// needed by container runtimes. Modules built into the kernel are also accepted.
+// Parameters:
+//   - name: name of the kernel module, dashes and underscores are interchangeable
+func CheckKernelModule(item types.CheckItem) (types.CheckResult, error) {
+	name := item.Parameters["name"]
+	if name == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "name parameter is required",
+		}, nil
+	}
+
+	if goos != "linux" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("kernel module check is only supported on Linux, not %s", goos),
+		}, nil
+	}
+
+	// The kernel lists modules with underscores, but modprobe accepts both
+	module := strings.ReplaceAll(name, "-", "_")
+	state, loaded, err := loadedModule(module)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Error reading loaded kernel modules: %v", err),
+		}, nil
+	}
+
+	if loaded {
+		return types.CheckResult{
+			Name:    item.Name,
+			Type:    item.Type,
+			Status:  types.Success,
+			Output:  fmt.Sprintf("Kernel module '%s' is loaded (state: %s)", name, state),
+			Details: map[string]interface{}{"kind": "loadable", "state": state},
+		}, nil
+	}
+
+	if builtinModule(module) {
+		return types.CheckResult{
+			Name:    item.Name,
+			Type:    item.Type,
+			Status:  types.Success,
+			Output:  fmt.Sprintf("Kernel module '%s' is built into the kernel", name),
+			Details: map[string]interface{}{"kind": "builtin"},
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Failure,
+		Output: fmt.Sprintf("Kernel module '%s' is not loaded", name),
+	}, nil
+}
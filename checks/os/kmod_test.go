@@ -0,0 +1,137 @@
+package os
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckKernelModule(t *testing.T) {
+	// Save original values and restore them after test
+	originalModules, originalLibModules, originalRoot, originalGOOS := procModulesPath, libModulesRoot, procSysRoot, goos
+	defer func() {
+		procModulesPath, libModulesRoot, procSysRoot, goos = originalModules, originalLibModules, originalRoot, originalGOOS
+	}()
+
+	tmpDir := t.TempDir()
+	procModulesPath = filepath.Join(tmpDir, "modules")
+	modules := "br_netfilter 32768 0 - Live 0x0000000000000000\n" +
+		"bridge 311296 1 br_netfilter, Live 0x0000000000000000\n" +
+		"nf_conntrack 172032 0 - Loading 0x0000000000000000\n"
+	if err := os.WriteFile(procModulesPath, []byte(modules), 0644); err != nil {
+		t.Fatal(err)
+	}
+	procSysRoot = filepath.Join(tmpDir, "sys")
+	if err := os.MkdirAll(filepath.Join(procSysRoot, "kernel"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(procSysRoot, "kernel", "osrelease"), []byte("6.8.0-45-generic\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	libModulesRoot = filepath.Join(tmpDir, "lib")
+	if err := os.MkdirAll(filepath.Join(libModulesRoot, "6.8.0-45-generic"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	builtin := "kernel/fs/overlayfs/overlay.ko\nkernel/drivers/md/dm-mod.ko\n"
+	if err := os.WriteFile(filepath.Join(libModulesRoot, "6.8.0-45-generic", "modules.builtin"), []byte(builtin), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		goos   string
+		params map[string]string
+		want   types.CheckResult
+	}{
+		{
+			name:   "loaded module",
+			goos:   "linux",
+			params: map[string]string{"name": "br_netfilter"},
+			want: types.CheckResult{
+				Status:  types.Success,
+				Output:  "Kernel module 'br_netfilter' is loaded (state: Live)",
+				Details: map[string]interface{}{"kind": "loadable", "state": "Live"},
+			},
+		},
+		{
+			name:   "loaded module with dashes",
+			goos:   "linux",
+			params: map[string]string{"name": "nf-conntrack"},
+			want: types.CheckResult{
+				Status:  types.Success,
+				Output:  "Kernel module 'nf-conntrack' is loaded (state: Loading)",
+				Details: map[string]interface{}{"kind": "loadable", "state": "Loading"},
+			},
+		},
+		{
+			name:   "builtin module",
+			goos:   "linux",
+			params: map[string]string{"name": "overlay"},
+			want: types.CheckResult{
+				Status:  types.Success,
+				Output:  "Kernel module 'overlay' is built into the kernel",
+				Details: map[string]interface{}{"kind": "builtin"},
+			},
+		},
+		{
+			name:   "builtin module with dashes in its file name",
+			goos:   "linux",
+			params: map[string]string{"name": "dm_mod"},
+			want: types.CheckResult{
+				Status:  types.Success,
+				Output:  "Kernel module 'dm_mod' is built into the kernel",
+				Details: map[string]interface{}{"kind": "builtin"},
+			},
+		},
+		{
+			name:   "module not loaded",
+			goos:   "linux",
+			params: map[string]string{"name": "ip_vs"},
+			want: types.CheckResult{
+				Status: types.Failure,
+				Output: "Kernel module 'ip_vs' is not loaded",
+			},
+		},
+		{
+			name:   "not linux",
+			goos:   "darwin",
+			params: map[string]string{"name": "overlay"},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "kernel module check is only supported on Linux, not darwin",
+			},
+		},
+		{
+			name:   "missing name",
+			goos:   "linux",
+			params: map[string]string{},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "name parameter is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goos = tt.goos
+			got, err := CheckKernelModule(types.CheckItem{Name: "test-check", Type: "os.kernel_module", Parameters: tt.params})
+			assert.NoError(t, err)
+			tt.want.Name = "test-check"
+			tt.want.Type = "os.kernel_module"
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("unreadable module list", func(t *testing.T) {
+		goos = "linux"
+		procModulesPath = filepath.Join(tmpDir, "missing")
+		got, err := CheckKernelModule(types.CheckItem{Name: "test-check", Type: "os.kernel_module", Parameters: map[string]string{"name": "overlay"}})
+		assert.NoError(t, err)
+		assert.Equal(t, types.Error, got.Status)
+		assert.Contains(t, got.Error, "Error reading loaded kernel modules")
+	})
+}
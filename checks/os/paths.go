@@ -10,8 +10,13 @@ import (
 )
 
 func init() {
-	checks.Register("os.file_exists", "Check if a file exists at the given path", CheckFileExists)
-	checks.Register("os.executable_exists", "Check if an executable exists and has proper permissions", CheckExecutableExists)
+	checks.Register("os.file_exists", "Check if a file exists at the given path", CheckFileExists,
+		checks.Parameter{Name: "path", Type: checks.ParamString, Description: "Path of the file", Required: true},
+	)
+	checks.Register("os.executable_exists", "Check if an executable exists and has proper permissions", CheckExecutableExists,
+		checks.Parameter{Name: "name", Type: checks.ParamString, Description: "Name of the executable to find", Required: true},
+		checks.Parameter{Name: "custom_path", Type: checks.ParamString, Description: "Directory to look for the executable in, defaults to the system PATH"},
+	)
 }
 
 // CheckFileExists checks if a file exists at the given path
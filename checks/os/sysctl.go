@@ -0,0 +1,100 @@
+package os
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// for testing
+var (
+	procSysRoot = "/proc/sys"
+	goos        = runtime.GOOS
+)
+
+func init() {
+	checks.Register("os.sysctl", "Check if a kernel parameter has the expected value (Linux only)", CheckSysctl,
+		checks.Parameter{Name: "key", Type: checks.ParamString, Description: "Name of the kernel parameter, e.g. net.ipv4.ip_forward", Required: true},
+		checks.Parameter{Name: "expected", Type: checks.ParamString, Description: "Expected value of the kernel parameter", Required: true},
+	)
+}
+
+// CheckSysctl checks if a kernel parameter has the expected value
+// Parameters:
+//   - key: name of the kernel parameter, e.g. net.ipv4.ip_forward
+//   - expected: expected value of the kernel parameter
+func CheckSysctl(item types.CheckItem) (types.CheckResult, error) {
+	key, ok := item.Parameters["key"]
+	if !ok || key == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "key parameter is required",
+		}, nil
+	}
+
+	expected, ok := item.Parameters["expected"]
+	if !ok {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "expected parameter is required",
+		}, nil
+	}
+
+	if goos != "linux" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("sysctl check is only supported on Linux, not %s", goos),
+		}, nil
+	}
+
+	// Keys map to files below /proc/sys, e.g. net.ipv4.ip_forward -> net/ipv4/ip_forward
+	path := filepath.Join(procSysRoot, strings.ReplaceAll(key, ".", "/"))
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Kernel parameter '%s' does not exist", key),
+		}, nil
+	}
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Error reading kernel parameter '%s': %v", key, err),
+		}, nil
+	}
+
+	// Multi-value parameters are tab separated, compare them field by field
+	actual := strings.Join(strings.Fields(string(data)), " ")
+	if actual != strings.Join(strings.Fields(expected), " ") {
+		return types.CheckResult{
+			Name:     item.Name,
+			Type:     item.Type,
+			Status:   types.Failure,
+			Output:   fmt.Sprintf("Kernel parameter '%s' is '%s', expected '%s'", key, actual, expected),
+			Expected: expected,
+			Actual:   actual,
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Success,
+		Output: fmt.Sprintf("Kernel parameter '%s' is '%s'", key, actual),
+	}, nil
+}
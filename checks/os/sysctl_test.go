@@ -0,0 +1,129 @@
+package os
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSysctl(t *testing.T) {
+	// Save original values and restore them after test
+	originalRoot, originalGOOS := procSysRoot, goos
+	defer func() {
+		procSysRoot, goos = originalRoot, originalGOOS
+	}()
+
+	procSysRoot = t.TempDir()
+	if err := os.MkdirAll(filepath.Join(procSysRoot, "net", "ipv4"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(procSysRoot, "net", "ipv4", "ip_forward"), []byte("1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(procSysRoot, "net", "ipv4", "tcp_rmem"), []byte("4096\t131072\t6291456\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		goos   string
+		params map[string]string
+		want   types.CheckResult
+	}{
+		{
+			name:   "matching value",
+			goos:   "linux",
+			params: map[string]string{"key": "net.ipv4.ip_forward", "expected": "1"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.sysctl",
+				Status: types.Success,
+				Output: "Kernel parameter 'net.ipv4.ip_forward' is '1'",
+			},
+		},
+		{
+			name:   "matching multi-value parameter",
+			goos:   "linux",
+			params: map[string]string{"key": "net.ipv4.tcp_rmem", "expected": "4096 131072 6291456"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.sysctl",
+				Status: types.Success,
+				Output: "Kernel parameter 'net.ipv4.tcp_rmem' is '4096 131072 6291456'",
+			},
+		},
+		{
+			name:   "mismatching value",
+			goos:   "linux",
+			params: map[string]string{"key": "net.ipv4.ip_forward", "expected": "0"},
+			want: types.CheckResult{
+				Name:     "test-check",
+				Type:     "os.sysctl",
+				Status:   types.Failure,
+				Output:   "Kernel parameter 'net.ipv4.ip_forward' is '1', expected '0'",
+				Expected: "0",
+				Actual:   "1",
+			},
+		},
+		{
+			name:   "unknown key",
+			goos:   "linux",
+			params: map[string]string{"key": "net.ipv4.unknown", "expected": "1"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.sysctl",
+				Status: types.Error,
+				Error:  "Kernel parameter 'net.ipv4.unknown' does not exist",
+			},
+		},
+		{
+			name:   "missing key parameter",
+			goos:   "linux",
+			params: map[string]string{"expected": "1"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.sysctl",
+				Status: types.Error,
+				Error:  "key parameter is required",
+			},
+		},
+		{
+			name:   "missing expected parameter",
+			goos:   "linux",
+			params: map[string]string{"key": "net.ipv4.ip_forward"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.sysctl",
+				Status: types.Error,
+				Error:  "expected parameter is required",
+			},
+		},
+		{
+			name:   "unsupported platform",
+			goos:   "darwin",
+			params: map[string]string{"key": "net.ipv4.ip_forward", "expected": "1"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.sysctl",
+				Status: types.Error,
+				Error:  "sysctl check is only supported on Linux, not darwin",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goos = tt.goos
+			got, err := CheckSysctl(types.CheckItem{
+				Name:       "test-check",
+				Type:       "os.sysctl",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package os
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// for testing
+var (
+	// systemdRunDir only exists when the system was booted with systemd
+	systemdRunDir = "/run/systemd/system"
+	runSystemctl  = defaultRunSystemctl
+)
+
+// enablementStates are the unit file states reported by systemctl is-enabled.
+// Any other expected state is compared against systemctl is-active.
+var enablementStates = []string{
+	"enabled", "enabled-runtime", "linked", "linked-runtime", "alias", "masked", "masked-runtime",
+	"static", "indirect", "disabled", "generated", "transient", "bad",
+}
+
+func init() {
+	checks.Register("os.systemd_unit", "Check if a systemd unit is in the expected state (Linux only)", CheckSystemdUnit,
+		checks.Parameter{Name: "unit", Type: checks.ParamString, Description: "Name of the unit, e.g. docker.service", Required: true},
+		checks.Parameter{Name: "state", Type: checks.ParamString, Description: "Expected active or unit file state of the unit", Default: "active"},
+	)
+}
+
+// defaultRunSystemctl runs systemctl and returns its trimmed output. systemctl exits
+// with a non-zero code for units that are not active or enabled, so the output is
+// returned together with the error.
+func defaultRunSystemctl(args ...string) (string, error) {
+	out, err := exec.Command("systemctl", args...).Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// CheckSystemdUnit checks if a systemd unit is in the expected state
+// Parameters:
+//   - unit: name of the unit, e.g. docker.service
+//   - state: expected state, defaults to active. Unit file states such as enabled
+//     or disabled are checked with systemctl is-enabled, all other states with
+//     systemctl is-active.
+func CheckSystemdUnit(item types.CheckItem) (types.CheckResult, error) {
+	unit, ok := item.Parameters["unit"]
+	if !ok || unit == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "unit parameter is required",
+		}, nil
+	}
+
+	expected := item.Parameters["state"]
+	if expected == "" {
+		expected = "active"
+	}
+
+	if goos != "linux" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("systemd unit check is only supported on Linux, not %s", goos),
+		}, nil
+	}
+	if _, err := os.Stat(systemdRunDir); err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "System is not running systemd",
+		}, nil
+	}
+
+	command := "is-active"
+	if slices.Contains(enablementStates, expected) {
+		command = "is-enabled"
+	}
+
+	actual, err := runSystemctl(command, unit)
+	if actual == "" {
+		if err == nil {
+			err = fmt.Errorf("no output")
+		}
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Failed to get state of unit '%s': %v", unit, err),
+		}, nil
+	}
+
+	if actual != expected {
+		return types.CheckResult{
+			Name:     item.Name,
+			Type:     item.Type,
+			Status:   types.Failure,
+			Output:   fmt.Sprintf("Unit '%s' is '%s', expected '%s'", unit, actual, expected),
+			Expected: expected,
+			Actual:   actual,
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Success,
+		Output: fmt.Sprintf("Unit '%s' is '%s'", unit, actual),
+	}, nil
+}
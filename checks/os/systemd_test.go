@@ -0,0 +1,164 @@
+package os
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSystemdUnit(t *testing.T) {
+	// Save original values and restore them after test
+	originalRunDir, originalRunSystemctl, originalGOOS := systemdRunDir, runSystemctl, goos
+	defer func() {
+		systemdRunDir, runSystemctl, goos = originalRunDir, originalRunSystemctl, originalGOOS
+	}()
+
+	// Fake systemctl reporting fixed states for a couple of units
+	states := map[string]map[string]string{
+		"is-active":  {"docker.service": "active", "cups.service": "inactive"},
+		"is-enabled": {"docker.service": "enabled", "cups.service": "disabled"},
+	}
+	runSystemctl = func(args ...string) (string, error) {
+		state, ok := states[args[0]][args[1]]
+		if !ok {
+			return "", errors.New("exit status 4")
+		}
+		if state != "active" && state != "enabled" {
+			return state, errors.New("exit status 3")
+		}
+		return state, nil
+	}
+
+	tests := []struct {
+		name      string
+		goos      string
+		noSystemd bool
+		params    map[string]string
+		want      types.CheckResult
+	}{
+		{
+			name:   "active unit",
+			goos:   "linux",
+			params: map[string]string{"unit": "docker.service"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.systemd_unit",
+				Status: types.Success,
+				Output: "Unit 'docker.service' is 'active'",
+			},
+		},
+		{
+			name:   "inactive unit",
+			goos:   "linux",
+			params: map[string]string{"unit": "cups.service"},
+			want: types.CheckResult{
+				Name:     "test-check",
+				Type:     "os.systemd_unit",
+				Status:   types.Failure,
+				Output:   "Unit 'cups.service' is 'inactive', expected 'active'",
+				Expected: "active",
+				Actual:   "inactive",
+			},
+		},
+		{
+			name:   "expected inactive unit",
+			goos:   "linux",
+			params: map[string]string{"unit": "cups.service", "state": "inactive"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.systemd_unit",
+				Status: types.Success,
+				Output: "Unit 'cups.service' is 'inactive'",
+			},
+		},
+		{
+			name:   "enabled unit",
+			goos:   "linux",
+			params: map[string]string{"unit": "docker.service", "state": "enabled"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.systemd_unit",
+				Status: types.Success,
+				Output: "Unit 'docker.service' is 'enabled'",
+			},
+		},
+		{
+			name:   "disabled unit expected enabled",
+			goos:   "linux",
+			params: map[string]string{"unit": "cups.service", "state": "enabled"},
+			want: types.CheckResult{
+				Name:     "test-check",
+				Type:     "os.systemd_unit",
+				Status:   types.Failure,
+				Output:   "Unit 'cups.service' is 'disabled', expected 'enabled'",
+				Expected: "enabled",
+				Actual:   "disabled",
+			},
+		},
+		{
+			name:   "systemctl error",
+			goos:   "linux",
+			params: map[string]string{"unit": "unknown.service"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.systemd_unit",
+				Status: types.Error,
+				Error:  "Failed to get state of unit 'unknown.service': exit status 4",
+			},
+		},
+		{
+			name:   "missing unit parameter",
+			goos:   "linux",
+			params: map[string]string{},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.systemd_unit",
+				Status: types.Error,
+				Error:  "unit parameter is required",
+			},
+		},
+		{
+			name:      "non-systemd system",
+			goos:      "linux",
+			noSystemd: true,
+			params:    map[string]string{"unit": "docker.service"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.systemd_unit",
+				Status: types.Error,
+				Error:  "System is not running systemd",
+			},
+		},
+		{
+			name:   "unsupported platform",
+			goos:   "darwin",
+			params: map[string]string{"unit": "docker.service"},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "os.systemd_unit",
+				Status: types.Error,
+				Error:  "systemd unit check is only supported on Linux, not darwin",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goos = tt.goos
+			systemdRunDir = t.TempDir()
+			if tt.noSystemd {
+				systemdRunDir = filepath.Join(systemdRunDir, "missing")
+			}
+			got, err := CheckSystemdUnit(types.CheckItem{
+				Name:       "test-check",
+				Type:       "os.systemd_unit",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package os
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+const (
+	defaultNTPServer   = "pool.ntp.org"
+	defaultMaxDrift    = time.Second
+	defaultNTPTimeout  = 5 * time.Second
+	ntpPacketSize      = 48
+	ntpEpochOffset     = 2208988800 // seconds between 1900-01-01 and 1970-01-01
+	ntpModeServer      = 4
+	ntpClientHeader    = 0x23 // leap indicator 0, version 4, client mode
+	ntpModeMask        = 0x07
+	ntpKissOfDeathCode = 0
+)
+
+func init() {
+	checks.Register("os.time_sync", "Check if the local clock is in sync with an NTP server", CheckTimeSync,
+		checks.Parameter{Name: "server", Type: checks.ParamString, Description: "NTP server, optionally with a port", Default: defaultNTPServer},
+		checks.Parameter{Name: "max_drift", Type: checks.ParamDuration, Description: "Maximum offset of the local clock from the server time", Default: defaultMaxDrift.String()},
+		checks.Parameter{Name: "timeout", Type: checks.ParamDuration, Description: "Time to wait for the server to respond", Default: defaultNTPTimeout.String()},
+	)
+}
+
+// CheckTimeSync checks if the local clock is in sync with an NTP server, since clock skew
+// breaks TLS and authentication. The offset is measured with a single SNTP request,
+// compensating for the network delay.
+// Parameters:
+//   - server: NTP server, optionally with a port, defaults to pool.ntp.org
+//   - max_drift: maximum offset of the local clock from the server time, defaults to 1s
+//   - timeout: time to wait for the server to respond, defaults to 5s
+func CheckTimeSync(item types.CheckItem) (types.CheckResult, error) {
+	server := item.Parameters["server"]
+	if server == "" {
+		server = defaultNTPServer
+	}
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "123")
+	}
+
+	maxDrift := defaultMaxDrift
+	if value, ok := item.Parameters["max_drift"]; ok {
+		var err error
+		maxDrift, err = time.ParseDuration(value)
+		if err == nil && maxDrift <= 0 {
+			err = fmt.Errorf("must be positive")
+		}
+		if err != nil {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid value for 'max_drift' parameter: %v", err),
+			}, nil
+		}
+	}
+
+	timeout := defaultNTPTimeout
+	if value, ok := item.Parameters["timeout"]; ok {
+		var err error
+		timeout, err = time.ParseDuration(value)
+		if err == nil && timeout <= 0 {
+			err = fmt.Errorf("must be positive")
+		}
+		if err != nil {
+			return types.CheckResult{
+				Name:   item.Name,
+				Type:   item.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("Invalid value for 'timeout' parameter: %v", err),
+			}, nil
+		}
+	}
+
+	offset, err := ntpOffset(server, timeout)
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Error querying NTP server %s: %v", server, err),
+		}, nil
+	}
+
+	drift := offset
+	if drift < 0 {
+		drift = -drift
+	}
+	if drift > maxDrift {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Failure,
+			Output: fmt.Sprintf("Local clock is off by %v from %s, more than the maximum drift of %v", offset, server, maxDrift),
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:   item.Name,
+		Type:   item.Type,
+		Status: types.Success,
+		Output: fmt.Sprintf("Local clock is off by %v from %s", offset, server),
+	}, nil
+}
+
+// ntpOffset sends an SNTP request to the server and returns the offset of the server
+// time from the local clock
+func ntpOffset(server string, timeout time.Duration) (time.Duration, error) {
+	conn, err := net.DialTimeout("udp", server, timeout)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(timeNow().Add(timeout)); err != nil {
+		return 0, err
+	}
+
+	// The transmit timestamp of the request is echoed as the origin timestamp of the response
+	request := make([]byte, ntpPacketSize)
+	request[0] = ntpClientHeader
+	sent := timeNow()
+	binary.BigEndian.PutUint64(request[40:], toNTPTime(sent))
+	if _, err := conn.Write(request); err != nil {
+		return 0, err
+	}
+
+	response := make([]byte, ntpPacketSize)
+	n, err := conn.Read(response)
+	if err != nil {
+		return 0, err
+	}
+	received := timeNow()
+	if n < ntpPacketSize {
+		return 0, fmt.Errorf("short response of %d bytes", n)
+	}
+	if response[0]&ntpModeMask != ntpModeServer {
+		return 0, fmt.Errorf("unexpected response mode %d", response[0]&ntpModeMask)
+	}
+	if response[1] == ntpKissOfDeathCode {
+		return 0, fmt.Errorf("server refused the request (kiss code %q)", response[12:16])
+	}
+	if binary.BigEndian.Uint64(response[24:]) != binary.BigEndian.Uint64(request[40:]) {
+		return 0, fmt.Errorf("response does not match the request")
+	}
+
+	// offset = ((serverReceived - sent) + (serverTransmitted - received)) / 2
+	serverReceived := fromNTPTime(binary.BigEndian.Uint64(response[32:]))
+	serverTransmitted := fromNTPTime(binary.BigEndian.Uint64(response[40:]))
+	return (serverReceived.Sub(sent) + serverTransmitted.Sub(received)) / 2, nil
+}
+
+// toNTPTime converts a time to an NTP timestamp: seconds since 1900 in the upper 32 bits
+// and the fraction of a second in the lower 32 bits
+func toNTPTime(t time.Time) uint64 {
+	seconds := uint64(t.Unix() + ntpEpochOffset)
+	fraction := uint64(t.Nanosecond()) << 32 / uint64(time.Second)
+	return seconds<<32 | fraction
+}
+
+// fromNTPTime converts an NTP timestamp to a time
+func fromNTPTime(ntp uint64) time.Time {
+	seconds := int64(ntp>>32) - ntpEpochOffset
+	nanos := (ntp & 0xffffffff) * uint64(time.Second) >> 32
+	return time.Unix(seconds, int64(nanos))
+}
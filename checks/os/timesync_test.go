@@ -0,0 +1,123 @@
+package os
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// startNTPServer starts an NTP server on localhost answering with its clock skewed by
+// skew, using the given stratum, or not answering at all when silent
+func startNTPServer(t *testing.T, skew time.Duration, stratum byte, silent bool) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		request := make([]byte, ntpPacketSize)
+		for {
+			_, addr, err := conn.ReadFrom(request)
+			if err != nil {
+				return
+			}
+			if silent {
+				continue
+			}
+			response := make([]byte, ntpPacketSize)
+			response[0] = 0x24 // version 4, server mode
+			response[1] = stratum
+			copy(response[12:16], "RATE")
+			copy(response[24:32], request[40:48])
+			now := toNTPTime(time.Now().Add(skew))
+			binary.BigEndian.PutUint64(response[32:], now)
+			binary.BigEndian.PutUint64(response[40:], now)
+			conn.WriteTo(response, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestCheckTimeSync(t *testing.T) {
+	inSync := startNTPServer(t, 0, 2, false)
+	skewed := startNTPServer(t, -10*time.Second, 2, false)
+	refusing := startNTPServer(t, 0, 0, false)
+	silent := startNTPServer(t, 0, 2, true)
+
+	tests := []struct {
+		name       string
+		params     map[string]string
+		wantStatus types.CheckStatus
+		wantOutput string
+		wantError  string
+	}{
+		{
+			name:       "clock in sync",
+			params:     map[string]string{"server": inSync},
+			wantStatus: types.Success,
+			wantOutput: "Local clock is off by ",
+		},
+		{
+			name:       "clock drifted",
+			params:     map[string]string{"server": skewed, "max_drift": "500ms"},
+			wantStatus: types.Failure,
+			wantOutput: "more than the maximum drift of 500ms",
+		},
+		{
+			name:       "drift within a large maximum",
+			params:     map[string]string{"server": skewed, "max_drift": "1m"},
+			wantStatus: types.Success,
+			wantOutput: "Local clock is off by -",
+		},
+		{
+			name:       "server refusing the request",
+			params:     map[string]string{"server": refusing},
+			wantStatus: types.Error,
+			wantError:  `server refused the request (kiss code "RATE")`,
+		},
+		{
+			name:       "server not responding",
+			params:     map[string]string{"server": silent, "timeout": "100ms"},
+			wantStatus: types.Error,
+			wantError:  "Error querying NTP server " + silent,
+		},
+		{
+			name:       "invalid max drift",
+			params:     map[string]string{"server": inSync, "max_drift": "-1s"},
+			wantStatus: types.Error,
+			wantError:  "Invalid value for 'max_drift' parameter: must be positive",
+		},
+		{
+			name:       "invalid timeout",
+			params:     map[string]string{"server": inSync, "timeout": "soon"},
+			wantStatus: types.Error,
+			wantError:  `Invalid value for 'timeout' parameter: time: invalid duration "soon"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckTimeSync(types.CheckItem{
+				Name:       "test-check",
+				Type:       "os.time_sync",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.Contains(t, got.Output, tt.wantOutput)
+			assert.Contains(t, got.Error, tt.wantError)
+		})
+	}
+}
+
+func TestNTPTimeConversion(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 30, 15, 500_000_000, time.UTC)
+	assert.WithinDuration(t, now, fromNTPTime(toNTPTime(now)), time.Microsecond)
+	assert.Equal(t, uint64(ntpEpochOffset)<<32, toNTPTime(time.Unix(0, 0)))
+}
@@ -0,0 +1,125 @@
+package os
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// for testing
+var (
+	localtimePath = "/etc/localtime"
+	timezonePath  = "/etc/timezone"
+	localZone     = func() string { return time.Local.String() }
+)
+
+// utcAliases are the names of UTC in the zoneinfo database, which match each other even
+// where the database is not installed
+var utcAliases = []string{"UTC", "Etc/UTC", "Zulu", "Etc/Zulu", "UCT", "Etc/UCT", "Universal", "Etc/Universal"}
+
+func init() {
+	checks.Register("os.timezone", "Check if the system timezone is the expected one", CheckTimezone,
+		checks.Parameter{Name: "expected", Type: checks.ParamString, Description: "Expected IANA timezone name, e.g. Europe/Berlin or UTC", Required: true},
+	)
+}
+
+// systemTimezone returns the IANA name of the configured timezone of the system and
+// where it was read from. The /etc/localtime symlink into the zoneinfo database is
+// preferred, followed by /etc/timezone of Debian-based systems and the local zone of
+// the process. An empty name means the timezone could not be determined.
+func systemTimezone() (string, string) {
+	if target, err := os.Readlink(localtimePath); err == nil {
+		if _, zone, ok := strings.Cut(target, "zoneinfo/"); ok && zone != "" {
+			return zone, localtimePath
+		}
+	}
+	if data, err := os.ReadFile(timezonePath); err == nil {
+		if zone := strings.TrimSpace(string(data)); zone != "" {
+			return zone, timezonePath
+		}
+	}
+	// Go names the zone "Local" when it was loaded from /etc/localtime without a name
+	if zone := localZone(); zone != "" && zone != "Local" {
+		return zone, "process"
+	}
+	return "", ""
+}
+
+// sameZone reports whether two timezone names refer to the same zone, e.g. the aliases
+// UTC and Etc/UTC or Asia/Calcutta and Asia/Kolkata. Like the zoneinfo database, which
+// links zones that agree since 1970, zones with the same offsets and abbreviations since
+// 1970 are the same.
+func sameZone(a, b string) bool {
+	if a == b || (slices.Contains(utcAliases, a) && slices.Contains(utcAliases, b)) {
+		return true
+	}
+	locA, err := time.LoadLocation(a)
+	if err != nil {
+		return false
+	}
+	locB, err := time.LoadLocation(b)
+	if err != nil {
+		return false
+	}
+	end := time.Date(2038, 1, 1, 0, 0, 0, 0, time.UTC)
+	for t := time.Unix(0, 0); t.Before(end); t = t.Add(24 * time.Hour) {
+		nameA, offsetA := t.In(locA).Zone()
+		nameB, offsetB := t.In(locB).Zone()
+		if nameA != nameB || offsetA != offsetB {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckTimezone checks if the system timezone is the expected one, since misconfigured
+// timezones cause subtle bugs in logs and scheduling. Aliases of the expected timezone,
+// e.g. Etc/UTC for UTC, match as well.
+// Parameters:
+//   - expected: expected IANA timezone name, e.g. Europe/Berlin
+func CheckTimezone(item types.CheckItem) (types.CheckResult, error) {
+	expected := strings.TrimSpace(item.Parameters["expected"])
+	if expected == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "expected parameter is required",
+		}, nil
+	}
+
+	actual, source := systemTimezone()
+	if actual == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("Could not determine the system timezone from %s or %s", localtimePath, timezonePath),
+		}, nil
+	}
+
+	if !sameZone(actual, expected) {
+		return types.CheckResult{
+			Name:     item.Name,
+			Type:     item.Type,
+			Status:   types.Failure,
+			Output:   fmt.Sprintf("Timezone is '%s', expected '%s'", actual, expected),
+			Expected: expected,
+			Actual:   actual,
+			Details:  map[string]interface{}{"source": source},
+		}, nil
+	}
+
+	return types.CheckResult{
+		Name:    item.Name,
+		Type:    item.Type,
+		Status:  types.Success,
+		Output:  fmt.Sprintf("Timezone is '%s'", actual),
+		Details: map[string]interface{}{"source": source},
+	}, nil
+}
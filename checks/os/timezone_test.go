@@ -0,0 +1,161 @@
+package os
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckTimezone(t *testing.T) {
+	// Save original values and restore them after test
+	originalLocaltime, originalTimezone, originalLocalZone := localtimePath, timezonePath, localZone
+	defer func() {
+		localtimePath, timezonePath, localZone = originalLocaltime, originalTimezone, originalLocalZone
+	}()
+
+	tmpDir := t.TempDir()
+	berlin := filepath.Join(tmpDir, "localtime-berlin")
+	if err := os.Symlink("/usr/share/zoneinfo/Europe/Berlin", berlin); err != nil {
+		t.Skipf("symlinks are not supported: %v", err)
+	}
+	etcUTC := filepath.Join(tmpDir, "localtime-etc-utc")
+	if err := os.Symlink("/usr/share/zoneinfo/Etc/UTC", etcUTC); err != nil {
+		t.Fatal(err)
+	}
+	copied := filepath.Join(tmpDir, "localtime-copy")
+	if err := os.WriteFile(copied, []byte("TZif2"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	timezoneFile := filepath.Join(tmpDir, "timezone")
+	if err := os.WriteFile(timezoneFile, []byte("America/New_York\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(tmpDir, "missing")
+
+	tests := []struct {
+		name      string
+		localtime string
+		timezone  string
+		localZone string
+		expected  string
+		want      types.CheckResult
+	}{
+		{
+			name:      "matching localtime symlink",
+			localtime: berlin,
+			timezone:  timezoneFile,
+			expected:  "Europe/Berlin",
+			want: types.CheckResult{
+				Status:  types.Success,
+				Output:  "Timezone is 'Europe/Berlin'",
+				Details: map[string]interface{}{"source": berlin},
+			},
+		},
+		{
+			name:      "mismatching localtime symlink",
+			localtime: berlin,
+			timezone:  timezoneFile,
+			expected:  "UTC",
+			want: types.CheckResult{
+				Status:   types.Failure,
+				Output:   "Timezone is 'Europe/Berlin', expected 'UTC'",
+				Expected: "UTC",
+				Actual:   "Europe/Berlin",
+				Details:  map[string]interface{}{"source": berlin},
+			},
+		},
+		{
+			name:      "alias of UTC",
+			localtime: etcUTC,
+			timezone:  timezoneFile,
+			expected:  "UTC",
+			want: types.CheckResult{
+				Status:  types.Success,
+				Output:  "Timezone is 'Etc/UTC'",
+				Details: map[string]interface{}{"source": etcUTC},
+			},
+		},
+		{
+			name:      "timezone file when localtime is a copy",
+			localtime: copied,
+			timezone:  timezoneFile,
+			expected:  "America/New_York",
+			want: types.CheckResult{
+				Status:  types.Success,
+				Output:  "Timezone is 'America/New_York'",
+				Details: map[string]interface{}{"source": timezoneFile},
+			},
+		},
+		{
+			name:      "local zone of the process",
+			localtime: missing,
+			timezone:  missing,
+			localZone: "UTC",
+			expected:  "UTC",
+			want: types.CheckResult{
+				Status:  types.Success,
+				Output:  "Timezone is 'UTC'",
+				Details: map[string]interface{}{"source": "process"},
+			},
+		},
+		{
+			name:      "undetermined timezone",
+			localtime: missing,
+			timezone:  missing,
+			localZone: "Local",
+			expected:  "UTC",
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "Could not determine the system timezone from " + missing + " or " + missing,
+			},
+		},
+		{
+			name: "missing expected",
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "expected parameter is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			localtimePath, timezonePath = tt.localtime, tt.timezone
+			localZone = func() string { return tt.localZone }
+
+			got, err := CheckTimezone(types.CheckItem{Name: "test-check", Type: "os.timezone", Parameters: map[string]string{"expected": tt.expected}})
+			assert.NoError(t, err)
+			tt.want.Name = "test-check"
+			tt.want.Type = "os.timezone"
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSameZone(t *testing.T) {
+	if _, err := time.LoadLocation("Asia/Kolkata"); err != nil {
+		t.Skipf("the zoneinfo database is not available: %v", err)
+	}
+
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "Europe/Berlin", b: "Europe/Berlin", want: true},
+		{a: "UTC", b: "Etc/Zulu", want: true},
+		{a: "Asia/Calcutta", b: "Asia/Kolkata", want: true},
+		{a: "Europe/Berlin", b: "Europe/Paris", want: false},
+		{a: "UTC", b: "Europe/London", want: false},
+		{a: "UTC", b: "Mars/Olympus_Mons", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.want, sameZone(tt.a, tt.b))
+		})
+	}
+}
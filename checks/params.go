@@ -0,0 +1,109 @@
+package checks
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// ResolveParameters validates the parameters of a check against the parameters its type
+// declares, the way the check would interpret them when run. It returns the parameters
+// with defaults filled in and typed values in their canonical form, e.g. "1m0s" for a
+// duration of "60s". Problems that make the check fail when run are returned as errors,
+// suspicious but harmless ones, like parameters the type does not declare, as warnings.
+func ResolveParameters(item types.CheckItem) (params map[string]string, warnings []string, errs []error) {
+	params = make(map[string]string, len(item.Parameters))
+	for name, value := range item.Parameters {
+		params[name] = value
+	}
+
+	// Parameters of command checks are passed to the command, which may use any of them
+	if item.Type == "command" {
+		return params, nil, nil
+	}
+	check, err := Get(item.Type)
+	if err != nil {
+		return params, nil, []error{fmt.Errorf("unknown check type %q", item.Type)}
+	}
+
+	declared := make(map[string]bool, len(check.Parameters))
+	for _, param := range check.Parameters {
+		declared[param.Name] = true
+		value, err := resolveParameter(param, params[param.Name])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if value != "" {
+			params[param.Name] = value
+		}
+	}
+
+	// Checks that declare no parameters may still read some, so only the parameters of
+	// checks declaring theirs can be told apart from typos
+	if len(check.Parameters) > 0 && !check.AnyParameters {
+		var unknown []string
+		for name := range params {
+			if !declared[name] {
+				unknown = append(unknown, name)
+			}
+		}
+		sort.Strings(unknown)
+		for _, name := range unknown {
+			warnings = append(warnings, fmt.Sprintf("parameter %q is not a parameter of %s and is ignored", name, item.Type))
+		}
+	}
+	return params, warnings, errs
+}
+
+// resolveParameter validates the value of a parameter and returns it in canonical form,
+// or the parameter's default if the value is empty
+func resolveParameter(param Parameter, value string) (string, error) {
+	if value == "" {
+		if param.Required {
+			return "", fmt.Errorf("%s parameter is required", param.Name)
+		}
+		return param.Default, nil
+	}
+
+	switch param.Type {
+	case ParamInt:
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return "", fmt.Errorf("Invalid value for '%s' parameter: %s is not an integer", param.Name, value)
+		}
+		value = strconv.Itoa(n)
+	case ParamBool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return "", fmt.Errorf("Invalid value for '%s' parameter: %s is not a boolean", param.Name, value)
+		}
+		value = strconv.FormatBool(b)
+	case ParamDuration:
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return "", fmt.Errorf("Invalid value for '%s' parameter: %s is not a duration", param.Name, value)
+		}
+		value = d.String()
+	}
+
+	if len(param.Enum) > 0 && !slices.Contains(param.Enum, value) {
+		return "", fmt.Errorf("Invalid value for '%s' parameter: %s (allowed values: %s)", param.Name, value, strings.Join(param.Enum, ", "))
+	}
+	if param.Pattern != "" {
+		pattern, err := regexp.Compile("^(?:" + param.Pattern + ")$")
+		if err != nil {
+			return "", fmt.Errorf("invalid pattern of '%s' parameter: %v", param.Name, err)
+		}
+		if !pattern.MatchString(value) {
+			return "", fmt.Errorf("Invalid value for '%s' parameter: %s does not match %s", param.Name, value, param.Pattern)
+		}
+	}
+	return value, nil
+}
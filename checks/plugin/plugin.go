@@ -0,0 +1,108 @@
+// Package plugin implements checks run by external executables, so checks can be added
+// in any language without recompiling checkers
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/internal/processor"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+func init() {
+	checks.RegisterContext("exec", "Runs an external check plugin, passing the parameters as JSON on stdin", CheckExec,
+		checks.Parameter{Name: "plugin", Type: checks.ParamString, Description: "Path of the plugin executable, or its name to look it up in PATH", Required: true},
+	)
+	checks.SetAnyParameters("exec")
+	checks.SetDocURL("exec", "https://seastar-consulting.github.io/checkers/writing-your-own-checks.html#plugins")
+}
+
+// Request is the JSON object a plugin reads from stdin
+type Request struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Parameters are the parameters of the check, except for plugin
+	Parameters map[string]string `json:"parameters"`
+}
+
+// CheckExec runs a plugin executable, which reads a Request from stdin and writes its
+// result as a JSON object to stdout, with the same fields as the JSON output of command
+// checks: status, output, error, expected, actual and details. The plugin is killed when
+// the check times out or the run is cancelled.
+// Parameters:
+//   - plugin: path of the plugin executable, or its name to look it up in PATH
+//   - any other parameter is passed on to the plugin
+func CheckExec(ctx context.Context, item types.CheckItem) (types.CheckResult, error) {
+	plugin := item.Parameters["plugin"]
+	if plugin == "" {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  "plugin parameter is required",
+		}, nil
+	}
+
+	request := Request{Name: item.Name, Type: item.Type, Parameters: map[string]string{}}
+	for name, value := range item.Parameters {
+		if name != "plugin" {
+			request.Parameters[name] = value
+		}
+	}
+	input, err := json.Marshal(request)
+	if err != nil {
+		return types.CheckResult{}, fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, plugin)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	if ctx.Err() != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("plugin '%s' was stopped: %v", plugin, ctx.Err()),
+		}, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Output: strings.TrimSpace(stderr.String()),
+			Error:  fmt.Sprintf("plugin '%s' failed with exit code %d", plugin, exitErr.ExitCode()),
+		}, nil
+	}
+	if err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Error:  fmt.Sprintf("failed to run plugin '%s': %v", plugin, err),
+		}, nil
+	}
+
+	var output map[string]interface{}
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return types.CheckResult{
+			Name:   item.Name,
+			Type:   item.Type,
+			Status: types.Error,
+			Output: strings.TrimSpace(stdout.String()),
+			Error:  fmt.Sprintf("plugin '%s' did not write a JSON result: %v", plugin, err),
+		}, nil
+	}
+	return processor.NewProcessor().ProcessOutput(item.Name, item.Type, output), nil
+}
@@ -0,0 +1,161 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/seastar-consulting/checkers/internal/executor"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// writePlugin writes a shell script plugin to a temporary directory and returns its path
+func writePlugin(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "plugin")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCheckExec(t *testing.T) {
+	// The plugin only succeeds when it receives the expected request
+	request := `{"name":"test-check","type":"exec","parameters":{"region":"eu-west-1"}}`
+	echo := writePlugin(t, `read -r request
+if [ "$request" != '`+request+`' ]; then
+  echo "unexpected request: $request" >&2
+  exit 1
+fi
+echo '{"status": "success", "output": "region is available", "details": {"zones": 3}}'
+`)
+	failure := writePlugin(t, `cat >/dev/null
+echo '{"status": "failure", "output": "region is not available", "expected": "available", "actual": "unavailable"}'
+`)
+	crash := writePlugin(t, `cat >/dev/null
+echo "cannot reach the API" >&2
+exit 3
+`)
+	garbage := writePlugin(t, `cat >/dev/null
+echo "all good"
+`)
+	slow := writePlugin(t, `exec sleep 10
+`)
+	timeout := 100 * time.Millisecond
+
+	tests := []struct {
+		name      string
+		params    map[string]string
+		timeout   time.Duration
+		want      types.CheckResult
+		wantError string
+	}{
+		{
+			name:   "success",
+			params: map[string]string{"plugin": echo, "region": "eu-west-1"},
+			want: types.CheckResult{
+				Status:  types.Success,
+				Output:  "region is available",
+				Details: map[string]interface{}{"zones": float64(3)},
+			},
+		},
+		{
+			name:   "failure",
+			params: map[string]string{"plugin": failure},
+			want: types.CheckResult{
+				Status:   types.Failure,
+				Output:   "region is not available",
+				Expected: "available",
+				Actual:   "unavailable",
+			},
+		},
+		{
+			name:   "non-zero exit code",
+			params: map[string]string{"plugin": crash},
+			want: types.CheckResult{
+				Status: types.Error,
+				Output: "cannot reach the API",
+				Error:  "plugin '" + crash + "' failed with exit code 3",
+			},
+		},
+		{
+			name:   "output is not JSON",
+			params: map[string]string{"plugin": garbage},
+			want: types.CheckResult{
+				Status: types.Error,
+				Output: "all good",
+				Error:  "plugin '" + garbage + "' did not write a JSON result: invalid character 'a' looking for beginning of value",
+			},
+		},
+		{
+			name:    "timeout",
+			params:  map[string]string{"plugin": slow},
+			timeout: timeout,
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "plugin '" + slow + "' was stopped: context deadline exceeded",
+			},
+		},
+		{
+			name:   "plugin not found",
+			params: map[string]string{"plugin": filepath.Join(t.TempDir(), "missing")},
+			want: types.CheckResult{
+				Status: types.Error,
+			},
+			wantError: "failed to run plugin",
+		},
+		{
+			name:   "missing plugin",
+			params: map[string]string{"region": "eu-west-1"},
+			want: types.CheckResult{
+				Status: types.Error,
+				Error:  "plugin parameter is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			got, err := CheckExec(ctx, types.CheckItem{
+				Name:       "test-check",
+				Type:       "exec",
+				Parameters: tt.params,
+			})
+			assert.NoError(t, err)
+			tt.want.Name = "test-check"
+			tt.want.Type = "exec"
+			if tt.wantError != "" {
+				assert.Contains(t, got.Error, tt.wantError)
+				tt.want.Error = got.Error
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCheckExecStopsWithCheck(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "finished")
+	slow := writePlugin(t, "sleep 0.5 && touch "+marker+"\n")
+
+	e := executor.NewExecutor(100 * time.Millisecond)
+	_, err := e.ExecuteCheck(context.Background(), types.CheckItem{
+		Name:       "test-check",
+		Type:       "exec",
+		Parameters: map[string]string{"plugin": slow},
+	})
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// The plugin must have been killed instead of finishing in the background
+	time.Sleep(time.Second)
+	assert.NoFileExists(t, marker)
+}
@@ -2,25 +2,116 @@ package checks
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
+	"time"
+
+	"github.com/seastar-consulting/checkers/types"
 )
 
+// builtInDocsURL is the page documenting the built-in checks, with a section per check
+const builtInDocsURL = "https://seastar-consulting.github.io/checkers/built-in-checks.html"
+
+// builtInFamilies are the families of the built-in checks, the part of their name before
+// the dot
+var builtInFamilies = []string{"cloud", "db", "docker", "git", "k8s", "logic", "net", "os"}
+
 var (
 	Registry = make(map[string]Check)
 	mu       sync.RWMutex
 )
 
-// Register adds a new check to the registry
-func Register(name, description string, fn CheckFunc) {
+// Register adds a new check to the registry, together with the parameters it accepts
+func Register(name, description string, fn CheckFunc, params ...Parameter) {
 	mu.Lock()
 	defer mu.Unlock()
 	Registry[name] = Check{
 		Name:        name,
 		Description: description,
+		Parameters:  params,
 		Func:        fn,
+		DocURL:      defaultDocURL(name),
+	}
+}
+
+// RegisterMulti adds a new check producing multiple results to the registry, together
+// with the parameters it accepts
+func RegisterMulti(name, description string, fn MultiCheckFunc, params ...Parameter) {
+	mu.Lock()
+	defer mu.Unlock()
+	Registry[name] = Check{
+		Name:        name,
+		Description: description,
+		Parameters:  params,
+		MultiFunc:   fn,
+		DocURL:      defaultDocURL(name),
 	}
 }
 
+// RegisterContext adds a new check that receives the context of the check to the
+// registry, together with the parameters it accepts
+func RegisterContext(name, description string, fn ContextCheckFunc, params ...Parameter) {
+	mu.Lock()
+	defer mu.Unlock()
+	Registry[name] = Check{
+		Name:        name,
+		Description: description,
+		Parameters:  params,
+		ContextFunc: fn,
+		DocURL:      defaultDocURL(name),
+	}
+}
+
+// SetDefaultTimeout sets the timeout used for checks of a registered type when neither
+// the check nor the user set a timeout, e.g. for inherently slow checks
+func SetDefaultTimeout(name string, timeout time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+	if check, ok := Registry[name]; ok {
+		check.DefaultTimeout = timeout
+		Registry[name] = check
+	}
+}
+
+// SetAnyParameters marks a registered check as accepting parameters it does not declare,
+// e.g. because it passes them on to a plugin
+func SetAnyParameters(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if check, ok := Registry[name]; ok {
+		check.AnyParameters = true
+		Registry[name] = check
+	}
+}
+
+// SetDocURL sets the URL of the documentation on how to fix failures of a registered check,
+// which is linked from the results of checks of this type that did not succeed. Built-in
+// checks link to their section of the documentation of the built-in checks by default.
+func SetDocURL(name, url string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if check, ok := Registry[name]; ok {
+		check.DocURL = url
+		Registry[name] = check
+	}
+}
+
+// BuiltInDocURL returns the URL of the documentation of a built-in check
+func BuiltInDocURL(name string) string {
+	return builtInDocsURL + "#" + strings.ReplaceAll(name, ".", "")
+}
+
+// defaultDocURL returns the doc URL of a check when it is registered: the section of the
+// check in the documentation of the built-in checks for built-in checks, none otherwise
+func defaultDocURL(name string) string {
+	family, _, ok := strings.Cut(name, ".")
+	if !ok || !slices.Contains(builtInFamilies, family) {
+		return ""
+	}
+	return BuiltInDocURL(name)
+}
+
 // Get returns a registered check
 func Get(name string) (Check, error) {
 	mu.RLock()
@@ -32,6 +123,23 @@ func Get(name string) (Check, error) {
 	return check, nil
 }
 
+// SecretParameters returns the names of the parameters and environment variables of a
+// check whose values are secret: the parameters its type declares as secret and those
+// listed in its secret_params
+func SecretParameters(item types.CheckItem) []string {
+	names := append([]string{}, item.SecretParams...)
+	check, err := Get(item.Type)
+	if err != nil {
+		return names
+	}
+	for _, param := range check.Parameters {
+		if param.Secret {
+			names = append(names, param.Name)
+		}
+	}
+	return names
+}
+
 // List returns all registered checks
 func List() []Check {
 	mu.RLock()
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/seastar-consulting/checkers/internal/executor"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// baselineDurations indexes how long the checks of a previous run took by their ID and
+// by their name. Checks without timestamps, such as those that never started before
+// the run timed out, are left out.
+func baselineDurations(results []types.CheckResult) (byID, byName map[string]time.Duration) {
+	byID = make(map[string]time.Duration, len(results))
+	byName = make(map[string]time.Duration, len(results))
+	for _, result := range results {
+		if result.StartedAt == nil || result.FinishedAt == nil {
+			continue
+		}
+		if result.ID != "" {
+			byID[result.ID] = checkDuration(result)
+		}
+		byName[result.Name] = checkDuration(result)
+	}
+	return byID, byName
+}
+
+// warnShortTimeouts warns about the checks whose timeout is shorter than their duration
+// in the baseline, since they are likely to time out. Checks are matched by their stable
+// ID, and by name for baselines written before results had IDs.
+func warnShortTimeouts(w io.Writer, executor *executor.Executor, checks []types.CheckItem, baseline []types.CheckResult) {
+	byID, byName := baselineDurations(baseline)
+	for _, check := range checks {
+		duration, ok := byID[check.StableID()]
+		if !ok {
+			duration, ok = byName[check.Name]
+		}
+		if !ok {
+			continue
+		}
+		if timeout := executor.CheckTimeout(check); timeout < duration {
+			fmt.Fprintf(w, "[WARN] Check '%s' has a timeout (%v) shorter than its duration in the baseline (%v) and will likely time out\n",
+				check.Name, timeout, duration.Round(time.Millisecond))
+		}
+	}
+}
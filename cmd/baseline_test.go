@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seastar-consulting/checkers/internal/executor"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+func TestWarnShortTimeouts(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	timed := func(id, name string, duration time.Duration) types.CheckResult {
+		finish := start.Add(duration)
+		return types.CheckResult{ID: id, Name: name, StartedAt: &start, FinishedAt: &finish}
+	}
+	long := 20 * time.Second
+
+	checks := []types.CheckItem{
+		{Name: "renamed", Type: "command", ID: "migrations"},
+		{Name: "slow", Type: "command"},
+		{Name: "own-timeout", Type: "command", Timeout: &long},
+		{Name: "fast", Type: "command"},
+		{Name: "timed-out", Type: "command"},
+		{Name: "new", Type: "command"},
+	}
+	baseline := []types.CheckResult{
+		timed("migrations", "old name", 8*time.Second),
+		timed("", "slow", 6500*time.Millisecond),
+		timed("", "own-timeout", 12*time.Second),
+		timed("", "fast", time.Second),
+		{Name: "timed-out", Status: types.Error, Output: "check execution timed out"},
+	}
+
+	var buf bytes.Buffer
+	warnShortTimeouts(&buf, executor.NewExecutor(5*time.Second), checks, baseline)
+
+	want := "[WARN] Check 'renamed' has a timeout (5s) shorter than its duration in the baseline (8s) and will likely time out\n" +
+		"[WARN] Check 'slow' has a timeout (5s) shorter than its duration in the baseline (6.5s) and will likely time out\n"
+	if buf.String() != want {
+		t.Errorf("warnShortTimeouts() wrote:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestBaselineFlag(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "baseline-test.yaml")
+	config := `
+timeout: 1s
+checks:
+  - name: build
+    type: command
+    command: echo ok
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	baselinePath := filepath.Join(tmpDir, "results.json")
+	baseline := `{"results": [{"name": "build", "type": "command", "status": "Error", "output": "",
+  "started_at": "2024-03-01T12:00:00Z", "finished_at": "2024-03-01T12:00:03Z"}], "metadata": {}}`
+	if err := os.WriteFile(baselinePath, []byte(baseline), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := NewRootCommand()
+	errBuf := new(bytes.Buffer)
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(errBuf)
+	cmd.SetArgs([]string{"--config", configPath, "--baseline", baselinePath})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("cmd.Execute() error = %v", err)
+	}
+	if !strings.Contains(errBuf.String(), "Check 'build' has a timeout (1s) shorter than its duration in the baseline (3s)") {
+		t.Errorf("missing timeout warning, got:\n%s", errBuf.String())
+	}
+
+	cmd = NewRootCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--baseline", filepath.Join(tmpDir, "missing.json")})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "baseline error") {
+		t.Errorf("cmd.Execute() error = %v, want a baseline error", err)
+	}
+}
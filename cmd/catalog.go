@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/spf13/cobra"
+)
+
+// catalogVersion is the version of the catalog format, increased on incompatible changes
+const catalogVersion = 1
+
+// catalog is the machine-readable description of all registered checks
+type catalog struct {
+	Version int            `json:"version"`
+	Checks  []catalogCheck `json:"checks"`
+}
+
+// catalogCheck describes a single check of the catalog
+type catalogCheck struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Parameters  []checks.Parameter `json:"parameters"`
+	// DefaultTimeout is the default timeout of the check type, e.g. "2m0s", if it declares one
+	DefaultTimeout string `json:"default_timeout,omitempty"`
+	// DocURL links to the documentation on how to fix failures of the check type, if set
+	DocURL string `json:"doc_url,omitempty"`
+}
+
+// newCatalogCommand creates the command exporting the check catalog
+func newCatalogCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "catalog",
+		Short: "Export the catalog of built-in checks as JSON",
+		Long: `Export the name, description and parameters of every built-in check as JSON,
+to generate documentation or configuration editors. Checks are sorted by name,
+and the field names are stable within a catalog version.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := json.MarshalIndent(buildCatalog(), "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode catalog: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return nil
+		},
+	}
+}
+
+// buildCatalog describes all registered checks, sorted by name
+func buildCatalog() catalog {
+	registered := checks.List()
+	sort.Slice(registered, func(i, j int) bool {
+		return registered[i].Name < registered[j].Name
+	})
+
+	c := catalog{Version: catalogVersion, Checks: make([]catalogCheck, 0, len(registered))}
+	for _, check := range registered {
+		params := check.Parameters
+		if params == nil {
+			// Always encode an array, so consumers don't need to handle null
+			params = []checks.Parameter{}
+		}
+		entry := catalogCheck{
+			Name:        check.Name,
+			Description: check.Description,
+			Parameters:  params,
+			DocURL:      check.DocURL,
+		}
+		if check.DefaultTimeout > 0 {
+			entry.DefaultTimeout = check.DefaultTimeout.String()
+		}
+		c.Checks = append(c.Checks, entry)
+	}
+	return c
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+func TestCatalog(t *testing.T) {
+	noop := func(item types.CheckItem) (types.CheckResult, error) {
+		return types.CheckResult{}, nil
+	}
+	checks.Register("test.catalog_b", "Second check", noop,
+		checks.Parameter{Name: "path", Type: checks.ParamString, Description: "Path to check", Required: true},
+		checks.Parameter{Name: "mode", Type: checks.ParamString, Description: "Mode of the check", Default: "fast", Enum: []string{"fast", "slow"}},
+	)
+	checks.Register("test.catalog_a", "First check", noop)
+	checks.SetDocURL("test.catalog_a", "https://docs.example.com/catalog_a")
+	defer delete(checks.Registry, "test.catalog_a")
+	defer delete(checks.Registry, "test.catalog_b")
+
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetArgs([]string{"catalog"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	// Compare the part of the catalog describing the test checks, other tests may register checks too
+	output := outBuf.String()
+	if !strings.Contains(output, `"version": 1`) {
+		t.Errorf("catalog is missing the version:\n%s", output)
+	}
+	want := `{
+      "name": "test.catalog_a",
+      "description": "First check",
+      "parameters": [],
+      "doc_url": "https://docs.example.com/catalog_a"
+    },
+    {
+      "name": "test.catalog_b",
+      "description": "Second check",
+      "parameters": [
+        {
+          "name": "path",
+          "type": "string",
+          "description": "Path to check",
+          "required": true
+        },
+        {
+          "name": "mode",
+          "type": "string",
+          "description": "Mode of the check",
+          "required": false,
+          "default": "fast",
+          "enum": [
+            "fast",
+            "slow"
+          ]
+        }
+      ]
+    }`
+	if !strings.Contains(output, want) {
+		t.Errorf("catalog does not describe the test checks, got:\n%s", output)
+	}
+}
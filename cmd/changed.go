@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// changedPaths returns the paths, relative to the repository root, of the files that
+// changed since the merge base of ref and HEAD, including uncommitted and untracked files
+// of the working tree. The repository is searched from dir upwards.
+func changedPaths(dir, ref string) ([]string, error) {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository: %w", err)
+	}
+
+	refHash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %q: %w", ref, err)
+	}
+	refCommit, err := repo.CommitObject(*refHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit of %q: %w", ref, err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	headCommit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD commit: %w", err)
+	}
+
+	// Compare against the merge base, so changes on ref since branching off are ignored
+	bases, err := refCommit.MergeBase(headCommit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base of %q and HEAD: %w", ref, err)
+	}
+	if len(bases) == 0 {
+		return nil, fmt.Errorf("%q and HEAD have no common ancestor", ref)
+	}
+	baseTree, err := bases[0].Tree()
+	if err != nil {
+		return nil, err
+	}
+	headTree, err := headCommit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	changes, err := baseTree.Diff(headTree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff %q and HEAD: %w", ref, err)
+	}
+
+	changed := make(map[string]bool)
+	for _, change := range changes {
+		// Renamed files count as changed at both their old and new location
+		if change.From.Name != "" {
+			changed[change.From.Name] = true
+		}
+		if change.To.Name != "" {
+			changed[change.To.Name] = true
+		}
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open worktree: %w", err)
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree status: %w", err)
+	}
+	for file, fileStatus := range status {
+		if fileStatus.Staging != git.Unmodified || fileStatus.Worktree != git.Unmodified {
+			changed[file] = true
+		}
+	}
+
+	paths := make([]string, 0, len(changed))
+	for file := range changed {
+		paths = append(paths, file)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// filterChangedChecks returns the checks without paths and the checks with a path
+// pattern matching one of the changed paths
+func filterChangedChecks(checks []types.CheckItem, changed []string) ([]types.CheckItem, error) {
+	filtered := make([]types.CheckItem, 0, len(checks))
+	for _, check := range checks {
+		if len(check.Paths) == 0 {
+			filtered = append(filtered, check)
+			continue
+		}
+		for _, file := range changed {
+			matched, err := matchPath(check.Paths, file)
+			if err != nil {
+				return nil, fmt.Errorf("invalid paths of check %q: %w", check.Name, err)
+			}
+			if matched {
+				filtered = append(filtered, check)
+				break
+			}
+		}
+	}
+	return filtered, nil
+}
+
+// matchPath reports whether the file or one of its parent directories matches any of
+// the glob patterns, so a pattern such as "services/api" matches all files below it
+func matchPath(patterns []string, file string) (bool, error) {
+	for dir := file; dir != "." && dir != "/"; dir = path.Dir(dir) {
+		matched, err := matchAny(patterns, dir)
+		if err != nil || matched {
+			return matched, err
+		}
+	}
+	return false, nil
+}
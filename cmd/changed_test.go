@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// commitFiles writes the files to the worktree and commits them
+func commitFiles(t *testing.T, repo *git.Repository, files map[string]string) plumbing.Hash {
+	t.Helper()
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		path := filepath.Join(worktree.Filesystem.Root(), name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := worktree.Add(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hash, err := worktree.Commit("Update files", &git.CommitOptions{
+		Author: &object.Signature{Name: "Test User", Email: "test@example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash
+}
+
+func TestChangedPaths(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := commitFiles(t, repo, map[string]string{
+		"README.md":            "readme",
+		"services/api/main.go": "package main",
+		"services/web/app.js":  "app",
+	})
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("base"), base)); err != nil {
+		t.Fatal(err)
+	}
+	commitFiles(t, repo, map[string]string{"services/api/main.go": "package main\n\nfunc main() {}"})
+	if err := os.WriteFile(filepath.Join(dir, "NOTES.md"), []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		dir     string
+		ref     string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "committed and untracked changes",
+			dir:  dir,
+			ref:  "base",
+			want: []string{"NOTES.md", "services/api/main.go"},
+		},
+		{
+			name: "from a subdirectory",
+			dir:  filepath.Join(dir, "services", "web"),
+			ref:  "base",
+			want: []string{"NOTES.md", "services/api/main.go"},
+		},
+		{
+			name: "no committed changes",
+			dir:  dir,
+			ref:  "HEAD",
+			want: []string{"NOTES.md"},
+		},
+		{
+			name:    "unknown ref",
+			dir:     dir,
+			ref:     "missing",
+			wantErr: `failed to resolve "missing"`,
+		},
+		{
+			name:    "not a repository",
+			dir:     t.TempDir(),
+			ref:     "base",
+			wantErr: "failed to open git repository",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := changedPaths(tt.dir, tt.ref)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("changedPaths() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("changedPaths() error = %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("changedPaths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterChangedChecks(t *testing.T) {
+	checks := []types.CheckItem{
+		{Name: "always", Type: "command"},
+		{Name: "api", Type: "command", Paths: []string{"services/api"}},
+		{Name: "web", Type: "command", Paths: []string{"services/web/*.js"}},
+		{Name: "docs", Type: "command", Paths: []string{"docs/*", "*.md"}},
+	}
+
+	tests := []struct {
+		name    string
+		changed []string
+		want    []string
+	}{
+		{
+			name: "no changes",
+			want: []string{"always"},
+		},
+		{
+			name:    "file below a directory",
+			changed: []string{"services/api/handlers/user.go"},
+			want:    []string{"always", "api"},
+		},
+		{
+			name:    "glob patterns",
+			changed: []string{"services/web/app.js", "README.md"},
+			want:    []string{"always", "web", "docs"},
+		},
+		{
+			name:    "unrelated changes",
+			changed: []string{"services/web/index.html", "go.mod"},
+			want:    []string{"always"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filterChangedChecks(checks, tt.changed)
+			if err != nil {
+				t.Fatalf("filterChangedChecks() error = %v", err)
+			}
+			var names []string
+			for _, check := range got {
+				names = append(names, check.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("filterChangedChecks() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
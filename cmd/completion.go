@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/internal/config"
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/spf13/cobra"
+)
+
+// newCompletionCommand creates the command generating shell completion scripts
+func newCompletionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion [bash|zsh|fish|powershell|check-types]",
+		Short: "Generate shell completion scripts or list check types",
+		Long: `Generate a completion script for the given shell. Completion includes the
+check names of the configuration file for --only and --skip, and the check types
+for --only-type.
+
+To load completions in the current bash session:
+
+  source <(checkers completion bash)
+
+The check-types argument prints all check types, one per line, for editor
+integrations that complete the type field of configuration files.`,
+		ValidArgs: []string{"bash", "zsh", "fish", "powershell", "check-types"},
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			root := cmd.Root()
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(out, true)
+			case "zsh":
+				return root.GenZshCompletion(out)
+			case "fish":
+				return root.GenFishCompletion(out, true)
+			case "powershell":
+				return root.GenPowerShellCompletionWithDesc(out)
+			default:
+				for _, checkType := range checkTypes() {
+					fmt.Fprintln(out, checkType)
+				}
+				return nil
+			}
+		},
+	}
+}
+
+// checkTypes returns the sorted names of all check types, including command checks
+func checkTypes() []string {
+	names := []string{"command"}
+	for _, check := range checks.List() {
+		names = append(names, check.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// completeCheckNames completes the names of the checks in the configuration file,
+// after items have been expanded
+func completeCheckNames(opts *Options) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		configMgr := config.NewManager(opts.ConfigFile)
+		configMgr.SetFormat(opts.ConfigFormat)
+		configMgr.SetParametersFile(opts.ParametersFile)
+		cfg, err := configMgr.Load()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var names []string
+		for _, check := range cfg.Checks {
+			if strings.HasPrefix(check.Name, toComplete) {
+				names = append(names, check.Name)
+			}
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// registerCompletions registers the completion functions of the root command's flags
+func registerCompletions(cmd *cobra.Command, opts *Options) {
+	cmd.RegisterFlagCompletionFunc("only", completeCheckNames(opts))
+	cmd.RegisterFlagCompletionFunc("skip", completeCheckNames(opts))
+	cmd.RegisterFlagCompletionFunc("only-type", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return checkTypes(), cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.RegisterFlagCompletionFunc("output", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		var formats []string
+		for _, format := range types.SupportedOutputFormats() {
+			formats = append(formats, string(format))
+		}
+		return formats, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions([]string{sortByName, sortByConfig}, cobra.ShellCompDirectiveNoFileComp))
+	cmd.RegisterFlagCompletionFunc("config", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return config.Extensions(), cobra.ShellCompDirectiveFilterFileExt
+	})
+	cmd.RegisterFlagCompletionFunc("config-format", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return config.Formats(), cobra.ShellCompDirectiveNoFileComp
+	})
+}
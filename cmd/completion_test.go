@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+func TestCompletion(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "checks.yaml")
+	configContent := `
+checks:
+  - name: "Check {{ .name }}"
+    type: os.executable_exists
+    items:
+      - name: git
+      - name: docker
+  - name: Disk space
+    type: command
+    command: df -h
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	checks.Register("test.completion", "Check used to test completion", func(item types.CheckItem) (types.CheckResult, error) {
+		return types.CheckResult{}, nil
+	})
+	defer delete(checks.Registry, "test.completion")
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantErr    bool
+		wantOutput []string
+		notOutput  []string
+	}{
+		{
+			name:       "bash script",
+			args:       []string{"completion", "bash"},
+			wantOutput: []string{"bash completion V2 for checkers"},
+		},
+		{
+			name:       "zsh script",
+			args:       []string{"completion", "zsh"},
+			wantOutput: []string{"#compdef checkers"},
+		},
+		{
+			name:       "check types",
+			args:       []string{"completion", "check-types"},
+			wantOutput: []string{"command\n", "test.completion\n"},
+		},
+		{
+			name:    "unknown shell",
+			args:    []string{"completion", "tcsh"},
+			wantErr: true,
+		},
+		{
+			name:       "check names for --only",
+			args:       []string{"__complete", "--config", configPath, "--only", "Check"},
+			wantOutput: []string{"Check git\n", "Check docker\n"},
+			notOutput:  []string{"Disk space"},
+		},
+		{
+			name:       "check names for --skip",
+			args:       []string{"__complete", "--config", configPath, "--skip", ""},
+			wantOutput: []string{"Check git\n", "Check docker\n", "Disk space\n"},
+		},
+		{
+			name:       "check types for --only-type",
+			args:       []string{"__complete", "--only-type", ""},
+			wantOutput: []string{"command\n", "test.completion\n"},
+		},
+		{
+			name:       "output formats",
+			args:       []string{"__complete", "--output", ""},
+			wantOutput: []string{"pretty\n", "json\n", "html\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			cmd := NewRootCommand()
+			cmd.SetOut(stdout)
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("Expected %q in output, got: %s", want, stdout.String())
+				}
+			}
+			for _, notWant := range tt.notOutput {
+				if strings.Contains(stdout.String(), notWant) {
+					t.Errorf("Did not expect %q in output, got: %s", notWant, stdout.String())
+				}
+			}
+		})
+	}
+}
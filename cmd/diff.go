@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/spf13/cobra"
+)
+
+// diffEntry is a check whose status differs between two runs. Before is empty for
+// added checks and After for removed checks.
+type diffEntry struct {
+	ID     string            `json:"id,omitempty"`
+	Name   string            `json:"name"`
+	Before types.CheckStatus `json:"before,omitempty"`
+	After  types.CheckStatus `json:"after,omitempty"`
+}
+
+// resultsDiff holds the checks whose status differs between two runs
+type resultsDiff struct {
+	// Fixed checks succeed now but did not before, Regressed checks the other way round
+	Fixed     []diffEntry `json:"fixed"`
+	Regressed []diffEntry `json:"regressed"`
+	// Changed checks did not succeed in either run, but with a different status
+	Changed []diffEntry `json:"changed"`
+	Added   []diffEntry `json:"added"`
+	Removed []diffEntry `json:"removed"`
+}
+
+// diffResults compares the results of two runs. Checks are matched by their stable ID,
+// and by name for results written before results had IDs.
+func diffResults(before, after []types.CheckResult) resultsDiff {
+	byID := make(map[string]int, len(before))
+	byName := make(map[string]int, len(before))
+	for i, result := range before {
+		if result.ID != "" {
+			byID[result.ID] = i
+		}
+		byName[result.Name] = i
+	}
+
+	// Empty lists rather than null in the JSON output
+	diff := resultsDiff{
+		Fixed:     []diffEntry{},
+		Regressed: []diffEntry{},
+		Changed:   []diffEntry{},
+		Added:     []diffEntry{},
+		Removed:   []diffEntry{},
+	}
+	matched := make([]bool, len(before))
+	for _, result := range after {
+		i, ok := byID[result.ID]
+		if !ok {
+			i, ok = byName[result.Name]
+		}
+		if !ok || matched[i] {
+			diff.Added = append(diff.Added, diffEntry{ID: result.ID, Name: result.Name, After: result.Status})
+			continue
+		}
+		matched[i] = true
+
+		previous := before[i]
+		entry := diffEntry{ID: result.ID, Name: result.Name, Before: previous.Status, After: result.Status}
+		switch {
+		case previous.Status == result.Status:
+		case result.Status == types.Success:
+			diff.Fixed = append(diff.Fixed, entry)
+		case previous.Status == types.Success:
+			diff.Regressed = append(diff.Regressed, entry)
+		default:
+			diff.Changed = append(diff.Changed, entry)
+		}
+	}
+	for i, result := range before {
+		if !matched[i] {
+			diff.Removed = append(diff.Removed, diffEntry{ID: result.ID, Name: result.Name, Before: result.Status})
+		}
+	}
+
+	for _, entries := range [][]diffEntry{diff.Fixed, diff.Regressed, diff.Changed, diff.Added, diff.Removed} {
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Name < entries[j].Name
+		})
+	}
+	return diff
+}
+
+// writeDiff writes the checks whose status differs as a list per kind of change
+func writeDiff(w io.Writer, diff resultsDiff) {
+	sections := []struct {
+		title   string
+		entries []diffEntry
+	}{
+		{"Regressed", diff.Regressed},
+		{"Fixed", diff.Fixed},
+		{"Changed", diff.Changed},
+		{"Added", diff.Added},
+		{"Removed", diff.Removed},
+	}
+	empty := true
+	for _, section := range sections {
+		if len(section.entries) == 0 {
+			continue
+		}
+		if !empty {
+			fmt.Fprintln(w)
+		}
+		empty = false
+		fmt.Fprintf(w, "%s (%d):\n", section.title, len(section.entries))
+		for _, entry := range section.entries {
+			switch {
+			case entry.Before == "":
+				fmt.Fprintf(w, "  %s: %s\n", entry.Name, entry.After)
+			case entry.After == "":
+				fmt.Fprintf(w, "  %s: %s\n", entry.Name, entry.Before)
+			default:
+				fmt.Fprintf(w, "  %s: %s -> %s\n", entry.Name, entry.Before, entry.After)
+			}
+		}
+	}
+	if empty {
+		fmt.Fprintln(w, "No checks changed status")
+	}
+}
+
+// newDiffCommand creates the command comparing the results of two runs
+func newDiffCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff <before.json> <after.json>",
+		Short: "Compare the JSON results of two runs",
+		Long: `Compare the results written by --output json or --output-dir of two runs, e.g. of
+two environments or two points in time, and list the checks that were fixed,
+regressed, changed status otherwise, were added or were removed. Checks are
+matched by their ID, and by name for results without IDs. The comparison is a
+list, or JSON with --output json.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString("output")
+			format := types.OutputFormat(output)
+			if format != types.OutputFormatPretty && format != types.OutputFormatJSON {
+				return fmt.Errorf("diff does not support the %s output format (supported formats: %s, %s)",
+					format, types.OutputFormatPretty, types.OutputFormatJSON)
+			}
+
+			before, err := readResults(args[0])
+			if err != nil {
+				return err
+			}
+			after, err := readResults(args[1])
+			if err != nil {
+				return err
+			}
+			diff := diffResults(before.Results, after.Results)
+
+			if format == types.OutputFormatJSON {
+				data, err := json.MarshalIndent(diff, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to format diff as JSON: %w", err)
+				}
+				_, err = cmd.OutOrStdout().Write(append(data, '\n'))
+				return err
+			}
+			writeDiff(cmd.OutOrStdout(), diff)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+func TestDiffResults(t *testing.T) {
+	before := []types.CheckResult{
+		{ID: "db", Name: "database", Status: types.Failure},
+		{ID: "api", Name: "api", Status: types.Success},
+		{Name: "disk", Status: types.Error},
+		{ID: "old", Name: "legacy", Status: types.Success},
+		{ID: "dns", Name: "dns", Status: types.Success},
+	}
+	after := []types.CheckResult{
+		// Renamed, but matched by its ID
+		{ID: "db", Name: "primary database", Status: types.Success},
+		{ID: "api", Name: "api", Status: types.Warning},
+		// Matched by name, the results before had no IDs
+		{ID: "4f1c2d3e", Name: "disk", Status: types.Failure},
+		{ID: "dns", Name: "dns", Status: types.Success},
+		{ID: "cache", Name: "cache", Status: types.Error},
+	}
+
+	want := resultsDiff{
+		Fixed:     []diffEntry{{ID: "db", Name: "primary database", Before: types.Failure, After: types.Success}},
+		Regressed: []diffEntry{{ID: "api", Name: "api", Before: types.Success, After: types.Warning}},
+		Changed:   []diffEntry{{ID: "4f1c2d3e", Name: "disk", Before: types.Error, After: types.Failure}},
+		Added:     []diffEntry{{ID: "cache", Name: "cache", After: types.Error}},
+		Removed:   []diffEntry{{ID: "old", Name: "legacy", Before: types.Success}},
+	}
+	if got := diffResults(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffResults() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDiffCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	beforePath := filepath.Join(tmpDir, "staging.json")
+	before := `{"results": [
+  {"id": "db", "name": "database", "type": "command", "status": "Failure", "output": ""},
+  {"id": "api", "name": "api", "type": "command", "status": "Success", "output": ""}
+], "metadata": {}}`
+	afterPath := filepath.Join(tmpDir, "production.json")
+	after := `{"results": [
+  {"id": "db", "name": "database", "type": "command", "status": "Success", "output": ""},
+  {"id": "api", "name": "api", "type": "command", "status": "Success", "output": ""},
+  {"id": "cache", "name": "cache", "type": "command", "status": "Error", "output": ""}
+], "metadata": {}}`
+	if err := os.WriteFile(beforePath, []byte(before), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(afterPath, []byte(after), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"diff", beforePath, afterPath})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("cmd.Execute() error = %v", err)
+	}
+	want := "Fixed (1):\n  database: Failure -> Success\n\nAdded (1):\n  cache: Error\n"
+	if outBuf.String() != want {
+		t.Errorf("diff output:\n%s\nwant:\n%s", outBuf.String(), want)
+	}
+
+	cmd = NewRootCommand()
+	outBuf = new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"diff", beforePath, beforePath, "--output", "json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("cmd.Execute() error = %v", err)
+	}
+	var diff map[string][]diffEntry
+	if err := json.Unmarshal(outBuf.Bytes(), &diff); err != nil {
+		t.Fatalf("failed to parse diff: %v\n%s", err, outBuf.String())
+	}
+	for _, key := range []string{"fixed", "regressed", "changed", "added", "removed"} {
+		if entries, ok := diff[key]; !ok || len(entries) != 0 {
+			t.Errorf("diff of identical results has %s = %v, want an empty list", key, entries)
+		}
+	}
+
+	cmd = NewRootCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"diff", beforePath, filepath.Join(tmpDir, "missing.json")})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "failed to read results") {
+		t.Errorf("cmd.Execute() error = %v, want a read error", err)
+	}
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"path"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// filterChecks returns the checks whose name matches one of the only patterns, if any,
+// and none of the skip patterns. Patterns use glob syntax, e.g. "aws*".
+func filterChecks(checks []types.CheckItem, only, skip []string) ([]types.CheckItem, error) {
+	if len(only) == 0 && len(skip) == 0 {
+		return checks, nil
+	}
+
+	filtered := make([]types.CheckItem, 0, len(checks))
+	for _, check := range checks {
+		included := len(only) == 0
+		if !included {
+			matched, err := matchAny(only, check.Name)
+			if err != nil {
+				return nil, err
+			}
+			included = matched
+		}
+		if !included {
+			continue
+		}
+
+		skipped, err := matchAny(skip, check.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !skipped {
+			filtered = append(filtered, check)
+		}
+	}
+	return filtered, nil
+}
+
+// filterChecksByType returns the checks whose type matches one of the patterns, or all
+// checks without patterns. Types are namespaced, so "cloud.*" selects all cloud checks.
+func filterChecksByType(checks []types.CheckItem, patterns []string) ([]types.CheckItem, error) {
+	if len(patterns) == 0 {
+		return checks, nil
+	}
+
+	filtered := make([]types.CheckItem, 0, len(checks))
+	for _, check := range checks {
+		matched, err := matchAny(patterns, check.Type)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			filtered = append(filtered, check)
+		}
+	}
+	return filtered, nil
+}
+
+// failedCheckNames returns the names of the checks of a previous run that did not
+// succeed. Skipped checks did not run and are left out.
+func failedCheckNames(results []types.CheckResult) []string {
+	var names []string
+	for _, result := range results {
+		if result.Status != types.Success && result.Status != types.Skipped {
+			names = append(names, result.Name)
+		}
+	}
+	return names
+}
+
+// filterChecksByName returns the checks with one of the names, and the names that
+// match none of the checks
+func filterChecksByName(checks []types.CheckItem, names []string) ([]types.CheckItem, []string) {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	filtered := make([]types.CheckItem, 0, len(names))
+	for _, check := range checks {
+		if wanted[check.Name] {
+			filtered = append(filtered, check)
+			delete(wanted, check.Name)
+		}
+	}
+
+	var missing []string
+	for _, name := range names {
+		if wanted[name] {
+			missing = append(missing, name)
+			// Report names that occur several times in the results only once
+			delete(wanted, name)
+		}
+	}
+	return filtered, missing
+}
+
+// matchAny reports whether the name matches any of the glob patterns
+func matchAny(patterns []string, name string) (bool, error) {
+	for _, pattern := range patterns {
+		matched, err := path.Match(pattern, name)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/spf13/cobra"
+)
+
+// historyRecord is the compact record of a check result appended to the history file
+// on every run
+type historyRecord struct {
+	Timestamp time.Time         `json:"timestamp"`
+	ID        string            `json:"id,omitempty"`
+	Name      string            `json:"name"`
+	Status    types.CheckStatus `json:"status"`
+	// DurationMs is zero for checks that never started, e.g. when the run timed out
+	DurationMs int64 `json:"duration_ms"`
+}
+
+// historyStats summarizes the records of a check in the history file
+type historyStats struct {
+	ID       string  `json:"id,omitempty"`
+	Name     string  `json:"name"`
+	Runs     int     `json:"runs"`
+	Passed   int     `json:"passed"`
+	PassRate float64 `json:"pass_rate"`
+	// Flips counts how often the status changed between consecutive runs, a check that
+	// alternates between passing and failing is flaky rather than broken
+	Flips      int               `json:"flips"`
+	LastStatus types.CheckStatus `json:"last_status"`
+	LastRun    time.Time         `json:"last_run"`
+}
+
+// appendHistory appends a record of every result to the history file, creating the file
+// and its parent directories. Skipped checks did not run and are left out.
+func appendHistory(path string, timestamp time.Time, results []types.CheckResult) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory for history file: %w", err)
+		}
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	for _, result := range results {
+		if result.Status == types.Skipped {
+			continue
+		}
+		record := historyRecord{
+			Timestamp: timestamp.UTC().Truncate(time.Second),
+			ID:        result.ID,
+			Name:      result.Name,
+			Status:    result.Status,
+		}
+		if result.StartedAt != nil && result.FinishedAt != nil {
+			record.DurationMs = checkDuration(result).Milliseconds()
+		}
+		line, err := json.Marshal(record)
+		if err != nil {
+			file.Close()
+			return fmt.Errorf("failed to encode history of check '%s': %w", result.Name, err)
+		}
+		writer.Write(append(line, '\n'))
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// readHistory reads the records of a history file in the order they were appended
+func readHistory(path string) ([]historyRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	defer file.Close()
+
+	var records []historyRecord
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record historyRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("failed to parse history '%s' at line %d: %w", path, line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	return records, nil
+}
+
+// summarizeHistory computes the pass rate of every check, identified by its ID, or by
+// its name for records written before results had IDs. The least reliable checks come
+// first.
+func summarizeHistory(records []historyRecord) []historyStats {
+	byKey := make(map[string]*historyStats)
+	for _, record := range records {
+		key := record.ID
+		if key == "" {
+			key = record.Name
+		}
+		stats, ok := byKey[key]
+		if !ok {
+			stats = &historyStats{ID: record.ID}
+			byKey[key] = stats
+		} else if record.Status != stats.LastStatus {
+			stats.Flips++
+		}
+		stats.Runs++
+		if record.Status == types.Success {
+			stats.Passed++
+		}
+		// Checks can be renamed while keeping their ID, show the latest name
+		stats.Name = record.Name
+		stats.LastStatus = record.Status
+		stats.LastRun = record.Timestamp
+	}
+
+	summary := make([]historyStats, 0, len(byKey))
+	for _, stats := range byKey {
+		stats.PassRate = float64(stats.Passed) / float64(stats.Runs)
+		summary = append(summary, *stats)
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		if summary[i].PassRate != summary[j].PassRate {
+			return summary[i].PassRate < summary[j].PassRate
+		}
+		if summary[i].Flips != summary[j].Flips {
+			return summary[i].Flips > summary[j].Flips
+		}
+		return summary[i].Name < summary[j].Name
+	})
+	return summary
+}
+
+// writeHistorySummary writes the pass rate of every check as a table
+func writeHistorySummary(w io.Writer, summary []historyStats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tRUNS\tPASSED\tPASS RATE\tFLIPS\tLAST STATUS\tLAST RUN")
+	for _, stats := range summary {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.0f%%\t%d\t%s\t%s\n", stats.Name, stats.Runs, stats.Passed,
+			stats.PassRate*100, stats.Flips, stats.LastStatus, stats.LastRun.Format(time.RFC3339))
+	}
+	return tw.Flush()
+}
+
+// newHistoryCommand creates the command summarizing the history file written by
+// --history-file
+func newHistoryCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "history <history.jsonl>",
+		Short: "Summarize the pass rate of every check over the runs recorded by --history-file",
+		Long: `Summarize the history file written by --history-file: how many times every check
+ran, how often it passed and how often its status changed between consecutive runs.
+The least reliable checks are listed first, so flaky checks stand out. The summary
+is a table, or JSON with --output json.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString("output")
+			format := types.OutputFormat(output)
+			if format != types.OutputFormatPretty && format != types.OutputFormatJSON {
+				return fmt.Errorf("history does not support the %s output format (supported formats: %s, %s)",
+					format, types.OutputFormatPretty, types.OutputFormatJSON)
+			}
+
+			records, err := readHistory(args[0])
+			if err != nil {
+				return err
+			}
+			summary := summarizeHistory(records)
+
+			if format == types.OutputFormatJSON {
+				data, err := json.MarshalIndent(summary, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to format history as JSON: %w", err)
+				}
+				_, err = cmd.OutOrStdout().Write(append(data, '\n'))
+				return err
+			}
+			return writeHistorySummary(cmd.OutOrStdout(), summary)
+		},
+	}
+}
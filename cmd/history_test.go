@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+func TestSummarizeHistory(t *testing.T) {
+	at := func(day int) time.Time { return time.Date(2024, 3, day, 12, 0, 0, 0, time.UTC) }
+	records := []historyRecord{
+		{Timestamp: at(1), ID: "db", Name: "database", Status: types.Success},
+		{Timestamp: at(1), Name: "flaky", Status: types.Failure},
+		{Timestamp: at(1), Name: "broken", Status: types.Error},
+		{Timestamp: at(2), ID: "db", Name: "database", Status: types.Success},
+		{Timestamp: at(2), Name: "flaky", Status: types.Success},
+		{Timestamp: at(2), Name: "broken", Status: types.Error},
+		{Timestamp: at(3), ID: "db", Name: "primary database", Status: types.Failure},
+		{Timestamp: at(3), Name: "flaky", Status: types.Failure},
+		{Timestamp: at(3), Name: "broken", Status: types.Error},
+	}
+
+	want := []historyStats{
+		{Name: "broken", Runs: 3, Passed: 0, PassRate: 0, Flips: 0, LastStatus: types.Error, LastRun: at(3)},
+		{Name: "flaky", Runs: 3, Passed: 1, PassRate: 1.0 / 3, Flips: 2, LastStatus: types.Failure, LastRun: at(3)},
+		{ID: "db", Name: "primary database", Runs: 3, Passed: 2, PassRate: 2.0 / 3, Flips: 1, LastStatus: types.Failure, LastRun: at(3)},
+	}
+	got := summarizeHistory(records)
+	if len(got) != len(want) {
+		t.Fatalf("summarizeHistory() returned %d checks, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("summarizeHistory()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestHistoryFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "history-test.yaml")
+	config := `
+checks:
+  - name: passing-check
+    type: command
+    command: echo ok
+  - name: failing-check
+    id: failing
+    type: command
+    command: exit 1
+  - name: disabled-check
+    type: command
+    enabled: false
+    command: echo ok
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	historyFile := filepath.Join(tmpDir, "history", "checks.jsonl")
+
+	// Every run appends to the history
+	for i := 0; i < 2; i++ {
+		cmd := NewRootCommand()
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs([]string{"--config", configPath, "--history-file", historyFile})
+		if err := cmd.Execute(); err != ErrChecksFailure {
+			t.Fatalf("cmd.Execute() error = %v, want %v", err, ErrChecksFailure)
+		}
+	}
+
+	records, err := readHistory(historyFile)
+	if err != nil {
+		t.Fatalf("readHistory() error = %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("got %d records, want 4 (skipped checks are left out): %+v", len(records), records)
+	}
+	for _, record := range records {
+		if record.Name == "failing-check" && (record.ID != "failing" || record.Status != types.Error) {
+			t.Errorf("unexpected record of the failing check: %+v", record)
+		}
+		if record.Timestamp.IsZero() {
+			t.Errorf("record has no timestamp: %+v", record)
+		}
+	}
+
+	// The history command summarizes the pass rate of every check
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"history", historyFile})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("cmd.Execute() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(outBuf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "failing-check") || !strings.Contains(lines[1], "0%") ||
+		!strings.HasPrefix(lines[2], "passing-check") || !strings.Contains(lines[2], "100%") {
+		t.Errorf("unexpected history summary:\n%s", outBuf.String())
+	}
+
+	cmd = NewRootCommand()
+	outBuf = new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"history", historyFile, "--output", "json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("cmd.Execute() error = %v", err)
+	}
+	var summary []historyStats
+	if err := json.Unmarshal(outBuf.Bytes(), &summary); err != nil {
+		t.Fatalf("failed to parse history summary: %v\n%s", err, outBuf.String())
+	}
+	if len(summary) != 2 || summary[0].ID != "failing" || summary[0].Runs != 2 || summary[1].PassRate != 1 {
+		t.Errorf("unexpected history summary: %+v", summary)
+	}
+
+	cmd = NewRootCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"history", historyFile, "--output", "html"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "does not support the html output format") {
+		t.Errorf("cmd.Execute() error = %v, want an unsupported format error", err)
+	}
+}
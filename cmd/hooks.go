@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seastar-consulting/checkers/internal/executor"
+	"github.com/seastar-consulting/checkers/internal/ui"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// runHooks runs the on_success and on_failure hooks of the checks for their final
+// results, concurrently unless noParallel is set. Failing hooks are reported as warnings
+// without affecting the results.
+func runHooks(ctx context.Context, w io.Writer, executor *executor.Executor, results []types.CheckResult, checksByName map[string]types.CheckItem, noParallel bool) {
+	errs := make([]error, len(results))
+	var wg sync.WaitGroup
+	for i, result := range results {
+		check := checkOf(checksByName, result)
+		if check.Hook(result.Status) == "" {
+			continue
+		}
+		debugLog.Printf("Running hook of check '%s' for status %s", result.Name, result.Status)
+		if noParallel {
+			errs[i] = executor.RunHook(ctx, check, result)
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = executor.RunHook(ctx, check, result)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			// Always show hook failures, even in non-verbose mode
+			fmt.Fprintf(w, "[WARN] Hook of check '%s' failed: %v\n", results[i].Name, err)
+		}
+	}
+}
+
+// runAfterRun runs the after-run hook with the summary of the results in environment
+// variables and the path of a temporary file with the results in the JSON format
+func runAfterRun(ctx context.Context, executor *executor.Executor, formatter *ui.Formatter, command string, results []types.CheckResult, metadata types.OutputMetadata, duration time.Duration) error {
+	output, err := formatter.FormatResultsJSON(results, metadata)
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp("", "checkers-results-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create results file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.WriteString(output); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write results file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write results file: %w", err)
+	}
+
+	summary := types.NewSummary(results, duration.Round(time.Millisecond))
+	var notSucceeded []string
+	for _, result := range results {
+		if result.Status != types.Success && result.Status != types.Skipped {
+			notSucceeded = append(notSucceeded, result.Name)
+		}
+	}
+	debugLog.Printf("Running after-run hook")
+	return executor.RunAfterRun(ctx, command, []string{
+		"CHECKERS_TOTAL=" + strconv.Itoa(summary.Total),
+		"CHECKERS_PASSED=" + strconv.Itoa(summary.Passed),
+		"CHECKERS_FAILED=" + strconv.Itoa(summary.Failed),
+		"CHECKERS_WARNINGS=" + strconv.Itoa(summary.Warnings),
+		"CHECKERS_ERRORS=" + strconv.Itoa(summary.Errors),
+		"CHECKERS_SKIPPED=" + strconv.Itoa(summary.Skipped),
+		"CHECKERS_DURATION=" + summary.Duration,
+		// Check names may contain commas, so they are separated by newlines
+		"CHECKERS_FAILED_CHECKS=" + strings.Join(notSucceeded, "\n"),
+		"CHECKERS_RESULTS_FILE=" + file.Name(),
+	})
+}
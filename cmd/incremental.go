@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// incrementalWriter writes every result to a file as a line of JSON as soon as it is
+// collected, so the results of a run that gets killed are not lost
+type incrementalWriter struct {
+	file *os.File
+	// err is the first error writing a result, later results are not written
+	err error
+}
+
+// newIncrementalWriter creates the file, replacing the results of an earlier run, and
+// its parent directories
+func newIncrementalWriter(path string) (*incrementalWriter, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for incremental file: %w", err)
+		}
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &incrementalWriter{file: file}, nil
+}
+
+// Write appends a result and syncs the file, so the line survives the machine going
+// down. Errors are kept for Close instead of interrupting the run.
+func (w *incrementalWriter) Write(result types.CheckResult) {
+	if w.err != nil {
+		return
+	}
+	line, err := json.Marshal(result)
+	if err != nil {
+		w.err = fmt.Errorf("failed to encode result of check '%s': %w", result.Name, err)
+		return
+	}
+	if _, err := w.file.Write(append(line, '\n')); err != nil {
+		w.err = err
+		return
+	}
+	w.err = w.file.Sync()
+}
+
+// Close closes the file and returns the first error of writing to it
+func (w *incrementalWriter) Close() error {
+	if err := w.file.Close(); err != nil && w.err == nil {
+		w.err = err
+	}
+	return w.err
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+func TestIncrementalFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "incremental-test.yaml")
+	config := `
+checks:
+  - name: passing-check
+    type: command
+    command: echo ok
+  - name: failing-check
+    type: command
+    command: echo 'token=s3cr3t' && exit 1
+    remediation: Rotate the token
+    redact:
+      - "token=\\w+"
+  - name: disabled-check
+    type: command
+    enabled: false
+    command: echo ok
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	incrementalFile := filepath.Join(tmpDir, "partial", "results.ndjson")
+	if err := os.MkdirAll(filepath.Dir(incrementalFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Results of an earlier run are replaced
+	if err := os.WriteFile(incrementalFile, []byte("{\"name\": \"stale\"}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--output", "json", "--no-parallel", "--rerun-failed", "1",
+		"--incremental-file", incrementalFile})
+	if err := cmd.Execute(); err != ErrChecksFailure {
+		t.Fatalf("cmd.Execute() error = %v, want %v", err, ErrChecksFailure)
+	}
+
+	file, err := os.Open(incrementalFile)
+	if err != nil {
+		t.Fatalf("failed to open incremental file: %v", err)
+	}
+	defer file.Close()
+	var results []types.CheckResult
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var result types.CheckResult
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+		}
+		results = append(results, result)
+	}
+
+	// Every attempt is written in the order it completed, followed by the skipped checks
+	want := []struct {
+		name   string
+		status types.CheckStatus
+		reruns int
+	}{
+		{"passing-check", types.Success, 0},
+		{"failing-check", types.Error, 0},
+		{"failing-check", types.Error, 1},
+		{"disabled-check", types.Skipped, 0},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d: %+v", len(results), len(want), results)
+	}
+	for i, w := range want {
+		got := results[i]
+		if got.Name != w.name || got.Status != w.status || got.Reruns != w.reruns {
+			t.Errorf("result %d = %s %s (reruns %d), want %s %s (reruns %d)", i, got.Name, got.Status, got.Reruns, w.name, w.status, w.reruns)
+		}
+		if got.ID == "" {
+			t.Errorf("result %d has no ID", i)
+		}
+	}
+	if failing := results[1]; failing.Output != "***" || failing.Remediation != "Rotate the token" {
+		t.Errorf("failing result was not redacted or is missing its remediation: %+v", failing)
+	}
+
+	// The final output is unaffected
+	var output types.JSONOutput
+	if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, outBuf.String())
+	}
+	if len(output.Results) != 3 {
+		t.Errorf("got %d results in the output, want 3", len(output.Results))
+	}
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/seastar-consulting/checkers/internal/ui"
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/spf13/cobra"
+)
+
+// reportStatuses are the statuses accepted by --filter-status
+var reportStatuses = []types.CheckStatus{types.Success, types.Failure, types.Warning, types.Error, types.Skipped}
+
+// newReportCommand creates the command re-rendering the results of a previous run
+func newReportCommand() *cobra.Command {
+	var filterStatus []string
+	cmd := &cobra.Command{
+		Use:   "report <results.json>",
+		Short: "Render the JSON results of a previous run in another format",
+		Long: `Render the results written by --output json or --output-dir in another format
+without running the checks again, e.g. to turn an archived report into HTML or to
+show only the failures of a run. The format is set with --output or derived from
+the extension of --file like for a regular run.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := reportFormat(cmd)
+			if err != nil {
+				return err
+			}
+			statuses, err := parseStatuses(filterStatus)
+			if err != nil {
+				return err
+			}
+
+			report, err := readResults(args[0])
+			if err != nil {
+				return err
+			}
+			results := filterResultsByStatus(report.Results, statuses)
+			metadata := report.Metadata
+			if title, _ := cmd.Flags().GetString("report-title"); title != "" {
+				metadata.Title = title
+			}
+
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			formatter := ui.NewFormatter(verbose)
+			if fullOutput, _ := cmd.Flags().GetBool("full-output"); fullOutput {
+				formatter.SetMaxOutputLines(0)
+			}
+			formatFuncs := map[types.OutputFormat]ui.FormatFunc{
+				types.OutputFormatJSON:   formatter.FormatResultsJSON,
+				types.OutputFormatHTML:   formatter.FormatResultsHTML,
+				types.OutputFormatPretty: formatter.FormatResultsPretty,
+			}
+			if grouped, _ := cmd.Flags().GetBool("json-grouped"); grouped {
+				formatFuncs[types.OutputFormatJSON] = formatter.FormatResultsJSONGrouped
+			}
+			output, err := formatFuncs[format](results, metadata)
+			if err != nil {
+				return fmt.Errorf("failed to format results as %s: %w", format, err)
+			}
+
+			if file, _ := cmd.Flags().GetString("file"); file != "" {
+				if err := writeOutputFile(file, output); err != nil {
+					return fmt.Errorf("failed to write to output file '%s': %w", file, err)
+				}
+				return nil
+			}
+			_, err = cmd.OutOrStdout().Write([]byte(output))
+			return err
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&filterStatus, "filter-status", nil,
+		"only render results with one of these statuses, e.g. 'failure,error'")
+	cmd.RegisterFlagCompletionFunc("filter-status", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		names := make([]string, 0, len(reportStatuses))
+		for _, status := range reportStatuses {
+			names = append(names, strings.ToLower(string(status)))
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+// reportFormat determines the output format of the report from --output, or from the
+// extension of --file when --output is not set
+func reportFormat(cmd *cobra.Command) (types.OutputFormat, error) {
+	output, _ := cmd.Flags().GetString("output")
+	format := types.OutputFormat(output)
+	if file, _ := cmd.Flags().GetString("file"); file != "" && !cmd.Flags().Changed("output") {
+		var err error
+		if format, err = outputFormatForFile(file); err != nil {
+			return "", err
+		}
+	}
+	if err := validateOutputFormat(&Options{OutputFormat: format}); err != nil {
+		return "", err
+	}
+	return format, nil
+}
+
+// parseStatuses converts the case-insensitive statuses of --filter-status
+func parseStatuses(names []string) ([]types.CheckStatus, error) {
+	statuses := make([]types.CheckStatus, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, status := range reportStatuses {
+			if strings.EqualFold(name, string(status)) {
+				statuses = append(statuses, status)
+				found = true
+				break
+			}
+		}
+		if !found {
+			supported := make([]string, 0, len(reportStatuses))
+			for _, status := range reportStatuses {
+				supported = append(supported, strings.ToLower(string(status)))
+			}
+			return nil, fmt.Errorf("invalid status: %s (supported statuses: %s)", name, strings.Join(supported, ", "))
+		}
+	}
+	return statuses, nil
+}
+
+// readResults reads the JSON results of a previous run
+func readResults(path string) (types.JSONOutput, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return types.JSONOutput{}, fmt.Errorf("failed to read results: %w", err)
+	}
+	var report types.JSONOutput
+	if err := json.Unmarshal(data, &report); err != nil {
+		return types.JSONOutput{}, fmt.Errorf("failed to parse results '%s': %w", path, err)
+	}
+	// A summary or any other JSON document would otherwise be rendered as an empty run,
+	// whose results are null rather than missing
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return types.JSONOutput{}, fmt.Errorf("failed to parse results '%s': %w", path, err)
+	}
+	if _, ok := fields["results"]; !ok {
+		return types.JSONOutput{}, fmt.Errorf("failed to parse results '%s': no results found", path)
+	}
+	return report, nil
+}
+
+// filterResultsByStatus returns the results with one of the statuses, or all results
+// when no statuses are given
+func filterResultsByStatus(results []types.CheckResult, statuses []types.CheckStatus) []types.CheckResult {
+	if len(statuses) == 0 {
+		return results
+	}
+	filtered := make([]types.CheckResult, 0, len(results))
+	for _, result := range results {
+		for _, status := range statuses {
+			if result.Status == status {
+				filtered = append(filtered, result)
+				break
+			}
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+func TestReport(t *testing.T) {
+	dir := t.TempDir()
+	resultsFile := filepath.Join(dir, "results.json")
+	results := `{
+  "results": [
+    {"name": "api", "type": "command", "status": "Success", "output": "healthy"},
+    {"name": "disk", "type": "os.disk_space", "status": "Failure", "output": "only 2GB free"},
+    {"name": "db", "type": "command", "status": "Error", "output": "", "error": "connection refused"}
+  ],
+  "metadata": {"title": "Nightly", "datetime": "2024-03-01T12:00:00Z", "version": "v1.2.3", "os": "linux/amd64"}
+}`
+	if err := os.WriteFile(resultsFile, []byte(results), 0644); err != nil {
+		t.Fatal(err)
+	}
+	summaryFile := filepath.Join(dir, "summary.json")
+	if err := os.WriteFile(summaryFile, []byte(`{"summary": {"total": 1}, "metadata": {}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		args      []string
+		wantNames []string
+		wantTitle string
+		wantErr   string
+	}{
+		{
+			name:      "all results",
+			args:      []string{"report", resultsFile, "-o", "json"},
+			wantNames: []string{"api", "disk", "db"},
+			wantTitle: "Nightly",
+		},
+		{
+			name:      "filter by status ignoring case",
+			args:      []string{"report", resultsFile, "-o", "json", "--filter-status", "failure,ERROR"},
+			wantNames: []string{"disk", "db"},
+			wantTitle: "Nightly",
+		},
+		{
+			name:      "report title overrides the original title",
+			args:      []string{"report", resultsFile, "-o", "json", "--filter-status", "success", "--report-title", "Triage"},
+			wantNames: []string{"api"},
+			wantTitle: "Triage",
+		},
+		{
+			name:    "invalid status",
+			args:    []string{"report", resultsFile, "--filter-status", "broken"},
+			wantErr: "invalid status: broken",
+		},
+		{
+			name:    "invalid output format",
+			args:    []string{"report", resultsFile, "-o", "xml"},
+			wantErr: "invalid output format: xml",
+		},
+		{
+			name:    "not a results file",
+			args:    []string{"report", summaryFile},
+			wantErr: "no results found",
+		},
+		{
+			name:    "missing file",
+			args:    []string{"report", filepath.Join(dir, "missing.json")},
+			wantErr: "failed to read results",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewRootCommand()
+			outBuf := new(bytes.Buffer)
+			cmd.SetOut(outBuf)
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			var got types.JSONOutput
+			if err := json.Unmarshal(outBuf.Bytes(), &got); err != nil {
+				t.Fatalf("failed to parse output: %v\n%s", err, outBuf.String())
+			}
+			var names []string
+			for _, result := range got.Results {
+				names = append(names, result.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantNames, ",") {
+				t.Errorf("results = %v, want %v", names, tt.wantNames)
+			}
+			if got.Metadata.Title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", got.Metadata.Title, tt.wantTitle)
+			}
+			if got.Metadata.Version != "v1.2.3" {
+				t.Errorf("version = %q, want the version of the original run", got.Metadata.Version)
+			}
+		})
+	}
+}
+
+func TestReportToFile(t *testing.T) {
+	dir := t.TempDir()
+	resultsFile := filepath.Join(dir, "results.json")
+	results := `{"results": [{"name": "disk", "type": "os.disk_space", "status": "Failure", "output": "only 2GB free"}], "metadata": {}}`
+	if err := os.WriteFile(resultsFile, []byte(results), 0644); err != nil {
+		t.Fatal(err)
+	}
+	htmlFile := filepath.Join(dir, "reports", "failures.html")
+
+	cmd := NewRootCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetArgs([]string{"report", resultsFile, "--file", htmlFile})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	data, err := os.ReadFile(htmlFile)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	if !strings.Contains(string(data), "<html") || !strings.Contains(string(data), "only 2GB free") {
+		t.Errorf("report is not an HTML report of the results:\n%s", data)
+	}
+}
@@ -2,33 +2,84 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/checks/cloud"
 	"github.com/seastar-consulting/checkers/internal/config"
 	"github.com/seastar-consulting/checkers/internal/executor"
+	"github.com/seastar-consulting/checkers/internal/redact"
 	"github.com/seastar-consulting/checkers/internal/ui"
 	"github.com/seastar-consulting/checkers/internal/version"
 	"github.com/seastar-consulting/checkers/types"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 const defaultTimeout = 30 * time.Second
 
+// Orders in which results can be sorted
+const (
+	sortByName   = "name"
+	sortByConfig = "config"
+)
+
 // Options holds the command line options
 type Options struct {
 	ConfigFile   string
+	ConfigFormat string
 	Verbose      bool
 	Timeout      time.Duration
 	OutputFormat types.OutputFormat
 	OutputFile   string
+	OutputDir    string
+	NoParallel   bool
+	ReportTitle  string
+	DumpConfig   bool
+	ConfigCheck  bool
+	SummaryOnly  bool
+	Only         []string
+	Skip         []string
+	OnlyTypes    []string
+	ChangedSince string
+	NoMetadata   bool
+	AfterRun     string
+	AllowEmpty   bool
+	Sort         string
+	TUI          bool
+	RerunFailed  int
+	MaxFailures  int
+	StrictParams bool
+	Profile      bool
+	AWSRateLimit float64
+	FullOutput   bool
+	JSONGrouped  bool
+	HistoryFile  string
+	RunBudget    time.Duration
+
+	// IncrementalFile receives every result as a line of JSON as soon as it is collected
+	IncrementalFile string
+	// ParametersFile holds parameter values for the checks that the config file leaves out
+	ParametersFile string
+	// OnlyFailedFrom is the JSON results of a previous run whose failed checks are run
+	OnlyFailedFrom string
+	// Baseline is the JSON results of a previous run, used to warn about checks that are
+	// likely to time out
+	Baseline string
+
+	// AfterRunRequired makes a failing after-run hook fail the run
+	AfterRunRequired bool
 }
 
 var (
@@ -43,6 +94,12 @@ var (
 // ErrChecksFailure indicates that one or more checks have failed
 var ErrChecksFailure = fmt.Errorf("one or more checks failed")
 
+// ErrNoChecks indicates that the filters excluded all checks
+var ErrNoChecks = fmt.Errorf("no checks match the given filters (use --allow-empty to allow this)")
+
+// errRunAborted is the cause of cancelling the checks once --max-failures is reached
+var errRunAborted = fmt.Errorf("too many checks failed")
+
 func init() {
 	rootCmd = NewRootCommand()
 }
@@ -62,13 +119,29 @@ func NewRootCommand() *cobra.Command {
 		SilenceUsage:  true, // Don't show usage on errors not related to usage
 		SilenceErrors: true, // We handle error output ourselves
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Validate output format
-			if !opts.OutputFormat.IsValid() {
-				supported := make([]string, 0, len(types.SupportedOutputFormats()))
-				for _, f := range types.SupportedOutputFormats() {
-					supported = append(supported, string(f))
-				}
-				return fmt.Errorf("invalid output format: %s (supported formats: %s)", opts.OutputFormat, strings.Join(supported, ", "))
+			if err := validateOutputFormat(opts); err != nil {
+				return err
+			}
+			if opts.Sort != sortByName && opts.Sort != sortByConfig {
+				return fmt.Errorf("invalid sort order: %s (supported orders: %s, %s)", opts.Sort, sortByName, sortByConfig)
+			}
+			if opts.RerunFailed < 0 {
+				return fmt.Errorf("invalid number of reruns: %d (must not be negative)", opts.RerunFailed)
+			}
+			if opts.MaxFailures < 0 {
+				return fmt.Errorf("invalid number of failures: %d (must not be negative)", opts.MaxFailures)
+			}
+			if opts.AWSRateLimit < 0 {
+				return fmt.Errorf("invalid AWS rate limit: %v (must not be negative)", opts.AWSRateLimit)
+			}
+			if opts.RunBudget < 0 {
+				return fmt.Errorf("invalid run budget: %v (must not be negative)", opts.RunBudget)
+			}
+			if opts.TUI && opts.OutputFile != "" {
+				return fmt.Errorf("--tui cannot be combined with --file")
+			}
+			if opts.TUI && opts.SummaryOnly {
+				return fmt.Errorf("--tui cannot be combined with --summary-only")
 			}
 			return run(cmd, opts)
 		},
@@ -85,23 +158,82 @@ func NewRootCommand() *cobra.Command {
 		supportedFormats = append(supportedFormats, string(f))
 	}
 
-	// Create a map of file extensions to output formats
-	formatExtensions := map[string]types.OutputFormat{
-		".json": types.OutputFormatJSON,
-		".html": types.OutputFormatHTML,
-		".txt":  types.OutputFormatPretty,
-		".log":  types.OutputFormatPretty,
-		".out":  types.OutputFormatPretty,
-	}
-
 	cmd.PersistentFlags().StringVarP(&opts.ConfigFile, "config", "c", "checks.yaml", "config file path")
+	cmd.PersistentFlags().StringVar(&opts.ConfigFormat, "config-format", "",
+		fmt.Sprintf("format of the config file. One of: %s (default: determined by the file extension, yaml otherwise)", strings.Join(config.Formats(), ", ")))
+	cmd.PersistentFlags().StringVar(&opts.ParametersFile, "parameters-file", "",
+		"YAML file of parameter values for all checks or checks by name, used where the config file does not set them")
 	cmd.PersistentFlags().BoolVarP(&opts.Verbose, "verbose", "v", false, "enable verbose logging")
 	cmd.PersistentFlags().DurationVarP(&opts.Timeout, "timeout", "t", defaultTimeout, "timeout for each check")
+	cmd.PersistentFlags().BoolVar(&opts.NoParallel, "no-parallel", false, "run checks one at a time in configuration order")
 
 	cmd.PersistentFlags().StringVarP(&outputFormatStr, "output", "o", string(types.OutputFormatPretty),
 		fmt.Sprintf("output format. One of: %s", strings.Join(supportedFormats, ", ")))
 	cmd.PersistentFlags().StringVarP(&opts.OutputFile, "file", "f", "",
 		"output file path. Format will be determined by file extension (.json for JSON, .html for HTML, any other for pretty)")
+	cmd.PersistentFlags().StringVar(&opts.ReportTitle, "report-title", "", "title of the generated report")
+	cmd.PersistentFlags().StringSliceVar(&opts.Only, "only", nil,
+		"only run checks whose name matches one of these glob patterns")
+	cmd.PersistentFlags().StringSliceVar(&opts.Skip, "skip", nil,
+		"skip checks whose name matches one of these glob patterns")
+	cmd.PersistentFlags().StringSliceVar(&opts.OnlyTypes, "only-type", nil,
+		"only run checks whose type matches one of these glob patterns, e.g. 'cloud.*'")
+	cmd.PersistentFlags().StringVar(&opts.Sort, "sort", sortByName,
+		fmt.Sprintf("order of the results. One of: %s (alphabetical), %s (priority, then configuration order)", sortByName, sortByConfig))
+	cmd.PersistentFlags().StringVar(&opts.OnlyFailedFrom, "only-failed-from", "",
+		"only run the checks that did not succeed in these JSON results of a previous run")
+	cmd.PersistentFlags().StringVar(&opts.ChangedSince, "changed-since", "",
+		"only run checks whose paths match files changed since this git ref, and checks without paths")
+	cmd.PersistentFlags().BoolVar(&opts.AllowEmpty, "allow-empty", false,
+		"succeed when the filters exclude all checks instead of failing")
+	cmd.PersistentFlags().BoolVar(&opts.JSONGrouped, "json-grouped", false,
+		"nest the results of the JSON output by group, the same way they are grouped in the pretty and HTML output")
+	cmd.PersistentFlags().BoolVar(&opts.FullOutput, "full-output", false,
+		"show the complete output of every check in pretty output instead of truncating it")
+	cmd.PersistentFlags().BoolVar(&opts.SummaryOnly, "summary-only", false,
+		"only output the number of passed, failed, warning and errored checks and the total duration")
+	cmd.PersistentFlags().BoolVar(&opts.DumpConfig, "dump-config", false,
+		"print the effective configuration after item expansion and exit (YAML, or JSON with --output json)")
+	cmd.PersistentFlags().BoolVar(&opts.ConfigCheck, "config-check", false,
+		"validate the parameters of every check against its check type and print the configuration with parameter defaults applied, without running any check")
+
+	cmd.PersistentFlags().BoolVar(&opts.StrictParams, "strict-params", false,
+		"reject item parameters of command checks that neither the check's templates nor its command use")
+	cmd.PersistentFlags().BoolVar(&opts.Profile, "profile", false,
+		"record the CPU time and peak memory of command checks, shown in verbose and JSON output, and report the slowest checks")
+	cmd.PersistentFlags().IntVar(&opts.RerunFailed, "rerun-failed", 0,
+		"rerun the checks that failed or errored up to this many times")
+	cmd.PersistentFlags().IntVar(&opts.MaxFailures, "max-failures", 0,
+		"stop running checks once this many checks did not succeed and report the checks that did not run as skipped, 0 for no limit")
+	cmd.PersistentFlags().DurationVar(&opts.RunBudget, "run-budget", 0,
+		"wall-clock time all checks must complete in, including reruns, with --no-parallel split among the remaining checks")
+	cmd.PersistentFlags().Float64Var(&opts.AWSRateLimit, "aws-rate-limit", 0,
+		"maximum number of AWS API requests per second across all checks, including retries, 0 for no limit")
+	cmd.PersistentFlags().StringVar(&opts.AfterRun, "after-run", "",
+		"command to run once after all checks, overriding after_run of the config file")
+	cmd.PersistentFlags().BoolVar(&opts.AfterRunRequired, "after-run-required", false,
+		"fail when the after-run command fails instead of only reporting it")
+	cmd.PersistentFlags().BoolVar(&opts.NoMetadata, "no-metadata", false,
+		"omit the date, version and OS from the JSON and HTML output, e.g. for reports shared externally")
+	cmd.PersistentFlags().BoolVar(&opts.TUI, "tui", false,
+		"browse the results interactively, falls back to pretty output when not running in a terminal")
+	cmd.PersistentFlags().StringVar(&opts.OutputDir, "output-dir", "",
+		"directory to write the results to in every format (results.json, results.html and results.txt), in addition to the regular output")
+	cmd.PersistentFlags().StringVar(&opts.Baseline, "baseline", "",
+		"JSON results of a previous run, used to warn about checks whose timeout is shorter than their previous duration")
+	cmd.PersistentFlags().StringVar(&opts.IncrementalFile, "incremental-file", "",
+		"file to write every result to as a line of JSON as soon as the check completes, keeping partial results if the run is killed")
+	cmd.PersistentFlags().StringVar(&opts.HistoryFile, "history-file", "",
+		"file to append a record of every result to, to track the pass rate of checks across runs with the history command")
+
+	// Complete flag values and provide a completion command that knows about check types
+	registerCompletions(cmd, opts)
+	cmd.CompletionOptions.DisableDefaultCmd = true
+	cmd.AddCommand(newCompletionCommand())
+	cmd.AddCommand(newCatalogCommand())
+	cmd.AddCommand(newReportCommand())
+	cmd.AddCommand(newHistoryCommand())
+	cmd.AddCommand(newDiffCommand())
 
 	// Parse the output format before running the command
 	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
@@ -111,23 +243,11 @@ func NewRootCommand() *cobra.Command {
 		// If output file is specified but --output flag was not explicitly set,
 		// determine format from file extension
 		if opts.OutputFile != "" && !cmd.Flags().Changed("output") {
-			ext := strings.ToLower(filepath.Ext(opts.OutputFile))
-
-			// Check if extension maps to a specific format
-			if format, exists := formatExtensions[ext]; exists {
-				opts.OutputFormat = format
-			} else if ext != "" {
-				// If extension exists but is not in formatExtensions
-				// Build a list of all supported extensions
-				var supportedExts []string
-				for extension := range formatExtensions {
-					supportedExts = append(supportedExts, extension)
-				}
-				return fmt.Errorf("unsupported file extension: %s (supported extensions: %s)", ext, strings.Join(supportedExts, ", "))
-			} else {
-				// No extension, use pretty format
-				opts.OutputFormat = types.OutputFormatPretty
+			format, err := outputFormatForFile(opts.OutputFile)
+			if err != nil {
+				return err
 			}
+			opts.OutputFormat = format
 			// Update outputFormatStr to match the determined format
 			outputFormatStr = string(opts.OutputFormat)
 		}
@@ -141,6 +261,62 @@ func NewRootCommand() *cobra.Command {
 	return cmd
 }
 
+// formatExtensions maps the file extensions of --file to output formats
+var formatExtensions = map[string]types.OutputFormat{
+	".json": types.OutputFormatJSON,
+	".html": types.OutputFormatHTML,
+	".txt":  types.OutputFormatPretty,
+	".log":  types.OutputFormatPretty,
+	".out":  types.OutputFormatPretty,
+}
+
+// outputFormatForFile determines the output format from the extension of a file, using
+// the pretty format for files without an extension
+func outputFormatForFile(path string) (types.OutputFormat, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if format, exists := formatExtensions[ext]; exists {
+		return format, nil
+	}
+	if ext == "" {
+		return types.OutputFormatPretty, nil
+	}
+	var supportedExts []string
+	for extension := range formatExtensions {
+		supportedExts = append(supportedExts, extension)
+	}
+	return "", fmt.Errorf("unsupported file extension: %s (supported extensions: %s)", ext, strings.Join(supportedExts, ", "))
+}
+
+// writeOutputFile writes the formatted results to a file, creating its parent
+// directories if they don't exist
+func writeOutputFile(path, output string) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory for output file: %w", err)
+		}
+	}
+	return os.WriteFile(path, []byte(output), 0644)
+}
+
+// validateOutputFormat checks that the output format is supported and compatible with
+// the other options
+func validateOutputFormat(opts *Options) error {
+	if !opts.OutputFormat.IsValid() {
+		supported := make([]string, 0, len(types.SupportedOutputFormats()))
+		for _, f := range types.SupportedOutputFormats() {
+			supported = append(supported, string(f))
+		}
+		return fmt.Errorf("invalid output format: %s (supported formats: %s)", opts.OutputFormat, strings.Join(supported, ", "))
+	}
+	if opts.SummaryOnly && opts.OutputFormat == types.OutputFormatHTML {
+		return fmt.Errorf("--summary-only is not supported with the %s output format", opts.OutputFormat)
+	}
+	if opts.TUI && opts.OutputFormat != types.OutputFormatPretty {
+		return fmt.Errorf("--tui is not supported with the %s output format", opts.OutputFormat)
+	}
+	return nil
+}
+
 func run(cmd *cobra.Command, opts *Options) error {
 	// Configure loggers based on verbose flag
 	if opts.Verbose {
@@ -156,7 +332,7 @@ func run(cmd *cobra.Command, opts *Options) error {
 	defer func() {
 		totalRuntime := time.Since(startTime)
 		debugLog.Printf("Total runtime: %v", totalRuntime)
-		if opts.Timeout > 0 && totalRuntime > opts.Timeout*3/2 {
+		if !opts.NoParallel && opts.RerunFailed == 0 && opts.Timeout > 0 && totalRuntime > opts.Timeout*3/2 {
 			// Always show performance warnings, even in non-verbose mode
 			fmt.Fprintf(cmd.ErrOrStderr(), "[WARN] Performance warning: Total runtime (%v) exceeded timeout (%v) by more than 50%%\n", totalRuntime, opts.Timeout)
 		}
@@ -164,6 +340,9 @@ func run(cmd *cobra.Command, opts *Options) error {
 
 	// Initialize components
 	configMgr := config.NewManager(opts.ConfigFile)
+	configMgr.SetFormat(opts.ConfigFormat)
+	configMgr.SetStrictParams(opts.StrictParams)
+	configMgr.SetParametersFile(opts.ParametersFile)
 
 	// Load config
 	cfg, err := configMgr.Load()
@@ -173,120 +352,354 @@ func run(cmd *cobra.Command, opts *Options) error {
 		return fmt.Errorf("configuration error: %w", err)
 	}
 
+	// The output format of the config file is the default, after the extension of --file.
+	// The interactive results browser was asked for explicitly, so it takes precedence.
+	if cfg.OutputFormat != "" && !cmd.Flags().Changed("output") && filepath.Ext(opts.OutputFile) == "" && !opts.TUI {
+		debugLog.Printf("Using output format from configuration file: %s", cfg.OutputFormat)
+		opts.OutputFormat = cfg.OutputFormat
+		if err := validateOutputFormat(opts); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] Invalid output_format in configuration file: %v\n", err)
+			return fmt.Errorf("configuration error: %w", err)
+		}
+	}
+
+	// Apply the check filters, starting with the failures of a previous run so that
+	// names missing from the configuration are not confused with filtered checks
+	if opts.OnlyFailedFrom != "" {
+		previous, err := readResults(opts.OnlyFailedFrom)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] Failed to load previous results '%s': %v\n", opts.OnlyFailedFrom, err)
+			return fmt.Errorf("filter error: %w", err)
+		}
+		failed := failedCheckNames(previous.Results)
+		debugLog.Printf("Checks that did not succeed in %s: %v", opts.OnlyFailedFrom, failed)
+		var missing []string
+		cfg.Checks, missing = filterChecksByName(cfg.Checks, failed)
+		for _, name := range missing {
+			// Always show filter warnings, even in non-verbose mode
+			fmt.Fprintf(cmd.ErrOrStderr(), "[WARN] Check '%s' did not succeed in '%s' but is not in the configuration\n", name, opts.OnlyFailedFrom)
+		}
+	}
+	cfg.Checks, err = filterChecks(cfg.Checks, opts.Only, opts.Skip)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] Invalid check filter: %v\n", err)
+		return fmt.Errorf("filter error: %w", err)
+	}
+	cfg.Checks, err = filterChecksByType(cfg.Checks, opts.OnlyTypes)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] Invalid check type filter: %v\n", err)
+		return fmt.Errorf("filter error: %w", err)
+	}
+	if opts.ChangedSince != "" {
+		changed, err := changedPaths(".", opts.ChangedSince)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] Failed to get changed files: %v\n", err)
+			return fmt.Errorf("filter error: %w", err)
+		}
+		debugLog.Printf("Files changed since %s: %v", opts.ChangedSince, changed)
+		cfg.Checks, err = filterChangedChecks(cfg.Checks, changed)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] Invalid check filter: %v\n", err)
+			return fmt.Errorf("filter error: %w", err)
+		}
+	}
+	if len(cfg.Checks) == 0 && !opts.AllowEmpty {
+		// An empty run would otherwise report success, e.g. after a typo in a filter
+		fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] No checks match the given filters\n")
+		return ErrNoChecks
+	}
+
+	// Launch checks with a lower priority first, keeping the configuration order otherwise
+	sort.SliceStable(cfg.Checks, func(i, j int) bool {
+		return cfg.Checks[i].Priority < cfg.Checks[j].Priority
+	})
+
 	// Determine timeout
 	timeout := opts.Timeout
 	if !cmd.Flags().Changed("timeout") && cfg.Timeout != nil {
 		timeout = *cfg.Timeout
 		debugLog.Printf("Using timeout from configuration file: %v", timeout)
+	} else if cfg.Timeout != nil {
+		debugLog.Printf("Using timeout from command line (%v) instead of configuration file (%v)", timeout, *cfg.Timeout)
 	}
 
-	// Create a context with timeout for all checks
-	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
-	defer cancel()
+	if opts.ConfigCheck {
+		resolved, problems := resolveParameters(cmd.ErrOrStderr(), cfg.Checks)
+		if problems > 0 {
+			return fmt.Errorf("configuration error: %d invalid parameters", problems)
+		}
+		cfg.Timeout = &timeout
+		cfg.Checks = maskSecrets(resolved)
+		if err := dumpConfig(cmd.OutOrStdout(), cfg, opts.OutputFormat); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] Failed to dump configuration: %v\n", err)
+			return fmt.Errorf("output error: %w", err)
+		}
+		return nil
+	}
+
+	if opts.DumpConfig {
+		cfg.Timeout = &timeout
+		cfg.Checks = maskSecrets(cfg.Checks)
+		if err := dumpConfig(cmd.OutOrStdout(), cfg, opts.OutputFormat); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] Failed to dump configuration: %v\n", err)
+			return fmt.Errorf("output error: %w", err)
+		}
+		return nil
+	}
 
+	// Disabled checks are reported as skipped without running them
+	var enabledChecks []types.CheckItem
+	var skippedResults []types.CheckResult
+	for _, check := range cfg.Checks {
+		if check.IsEnabled() {
+			enabledChecks = append(enabledChecks, check)
+			continue
+		}
+		debugLog.Printf("Skipping disabled check '%s'", check.Name)
+		skippedResults = append(skippedResults, types.CheckResult{
+			Name:   check.Name,
+			Type:   check.Type,
+			Status: types.Skipped,
+			Output: "disabled",
+		})
+	}
+
+	// Check types may declare a default timeout, which is only used when the user did not set one
 	executor := executor.NewExecutor(timeout)
+	executor.SetTypeTimeouts(!cmd.Flags().Changed("timeout") && cfg.Timeout == nil)
+	executor.SetProfile(opts.Profile)
+	cloud.SetRateLimit(opts.AWSRateLimit)
+	runTimeout := suiteTimeout(executor, timeout, enabledChecks, opts.NoParallel)
+
+	// Warn about checks that can never use their full timeout
+	for _, check := range enabledChecks {
+		if check.Timeout != nil && *check.Timeout > runTimeout {
+			// Always show configuration warnings, even in non-verbose mode
+			fmt.Fprintf(cmd.ErrOrStderr(), "[WARN] Check '%s' has a timeout (%v) exceeding the global timeout (%v) and will be cancelled before it elapses\n",
+				check.Name, *check.Timeout, runTimeout)
+		}
+		if check.Type == "command" && len(check.Parameters) > 0 {
+			debugLog.Printf("Check '%s' passes parameters to its command as environment variables, which is deprecated; use 'env' instead", check.Name)
+		}
+	}
+	if opts.Baseline != "" {
+		baseline, err := readResults(opts.Baseline)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] Failed to load baseline '%s': %v\n", opts.Baseline, err)
+			return fmt.Errorf("baseline error: %w", err)
+		}
+		// Always show configuration warnings, even in non-verbose mode
+		warnShortTimeouts(cmd.ErrOrStderr(), executor, enabledChecks, baseline.Results)
+	}
+
+	// The run budget bounds the wall-clock time of running the checks, including reruns
+	checksCtx := cmd.Context()
+	if opts.RunBudget > 0 {
+		var cancel context.CancelFunc
+		checksCtx, cancel = context.WithTimeout(checksCtx, opts.RunBudget)
+		defer cancel()
+		debugLog.Printf("Running checks within a budget of %v", opts.RunBudget)
+	}
+
 	formatter := ui.NewFormatter(opts.Verbose)
+	if opts.FullOutput {
+		formatter.SetMaxOutputLines(0)
+	}
 
-	// Create channels for results and errors
-	type checkResult struct {
-		result types.CheckResult
-		err    error
-		item   types.CheckItem
+	checksByName := make(map[string]types.CheckItem, len(cfg.Checks))
+	for _, check := range cfg.Checks {
+		checksByName[check.Name] = check
 	}
-	resultChan := make(chan checkResult, len(cfg.Checks))
 
-	debugLog.Printf("Starting execution of %d checks", len(cfg.Checks))
+	// finalize attaches remediation hints and redacts sensitive values before any
+	// formatter or the incremental file sees a result
+	finalize := func(result types.CheckResult) types.CheckResult {
+		check := checkOf(checksByName, result)
+		result.ID = check.StableID()
+		result.Informational = check.Informational
+		if result.Status != types.Success && result.Status != types.Skipped {
+			if result.Remediation == "" {
+				result.Remediation = check.Remediation
+			}
+			if result.DocURL == "" {
+				result.DocURL = docURL(check)
+			}
+		}
+		redacted, err := redactResult(result, check, cfg.Redact)
+		if err != nil {
+			errorLog.Printf("Failed to redact output of check '%s': %v", result.Name, err)
+		}
+		return redacted
+	}
 
-	// Start all checks concurrently
-	for _, checkItem := range cfg.Checks {
-		checkItem := checkItem // Create new variable for goroutine
-		go func() {
-			debugLog.Printf("Executing check: %s", checkItem.Name)
-			result, err := executor.ExecuteCheck(ctx, checkItem)
-			resultChan <- checkResult{result: result, err: err, item: checkItem}
+	// Persist results as they complete, so they survive the run being killed
+	var onResult func(types.CheckResult)
+	if opts.IncrementalFile != "" {
+		incremental, err := newIncrementalWriter(opts.IncrementalFile)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] Failed to create incremental file '%s': %v\n", opts.IncrementalFile, err)
+			return fmt.Errorf("output error: %w", err)
+		}
+		defer func() {
+			if err := incremental.Close(); err != nil {
+				// Always show write failures, the file is incomplete
+				fmt.Fprintf(cmd.ErrOrStderr(), "[WARN] Failed to write incremental file '%s': %v\n", opts.IncrementalFile, err)
+			}
 		}()
+		onResult = func(result types.CheckResult) {
+			incremental.Write(finalize(result))
+		}
 	}
 
-	// Collect results
-	var results []types.CheckResult
-	var timedOutChecks []types.CheckItem
-	var failedChecks []string
-	remainingChecks := len(cfg.Checks)
-
-	for remainingChecks > 0 {
-		select {
-		case <-ctx.Done():
-			debugLog.Printf("Global timeout reached after %v", time.Since(startTime))
-			// Add timeout results for all remaining checks
-			for _, check := range cfg.Checks {
-				found := false
-				for _, res := range results {
-					if res.Name == check.Name {
-						found = true
-						break
-					}
-				}
-				if !found {
-					results = append(results, types.CheckResult{
-						Name:   check.Name,
-						Type:   check.Type,
-						Status: types.Error,
-						Output: "check execution timed out",
-					})
-					timedOutChecks = append(timedOutChecks, check)
-					failedChecks = append(failedChecks, check.Name)
-					debugLog.Printf("Check '%s' timed out", check.Name)
+	// Stop the run once too many checks did not succeed, which likely share a cause
+	runCtx := checksCtx
+	onRunResult := onResult
+	failures := 0
+	if opts.MaxFailures > 0 {
+		var abort context.CancelCauseFunc
+		runCtx, abort = context.WithCancelCause(checksCtx)
+		defer abort(nil)
+		onRunResult = func(result types.CheckResult) {
+			if result.Status != types.Success && result.Status != types.Skipped && !checkOf(checksByName, result).Informational {
+				failures++
+				if failures == opts.MaxFailures {
+					debugLog.Printf("Aborting the run after %d failures", failures)
+					abort(errRunAborted)
 				}
 			}
-			remainingChecks = 0
-		case res := <-resultChan:
-			remainingChecks--
-			if res.err == context.DeadlineExceeded {
-				timedOutChecks = append(timedOutChecks, res.item)
-				results = append(results, types.CheckResult{
-					Name:   res.item.Name,
-					Type:   res.item.Type,
-					Status: types.Error,
-					Output: "check execution timed out",
-				})
-				failedChecks = append(failedChecks, res.item.Name)
-				debugLog.Printf("Check '%s' timed out", res.item.Name)
-			} else if res.err != nil {
-				results = append(results, types.CheckResult{
-					Name:   res.item.Name,
-					Type:   res.item.Type,
-					Status: types.Error,
-					Output: fmt.Sprintf("check failed: %v", res.err),
-				})
-				failedChecks = append(failedChecks, res.item.Name)
-				debugLog.Printf("Check '%s' failed: %v", res.item.Name, res.err)
-			} else if res.result.Status != types.Success {
-				failedChecks = append(failedChecks, res.item.Name)
-				results = append(results, res.result)
-				debugLog.Printf("Check '%s' failed with status: %s", res.item.Name, res.result.Status)
+			if onResult != nil {
+				onResult(result)
+			}
+		}
+	}
+	results, timedOutChecks := executeChecks(runCtx, executor, enabledChecks, timeout, opts.NoParallel, onRunResult)
+	aborted := context.Cause(runCtx) == errRunAborted
+
+	// Rerun the checks that did not succeed, e.g. after a transient outage, unless the
+	// run was aborted or the run budget is exhausted
+	for rerun := 1; rerun <= opts.RerunFailed && !aborted && checksCtx.Err() == nil; rerun++ {
+		// A check producing multiple results is rerun when any of them failed. Warnings
+		// do not fail the run and terminal results would not change, so neither is rerun.
+		notSucceeded := make(map[string]bool, len(results))
+		for _, result := range results {
+			if result.Status == types.Success || result.Status == types.Warning {
+				continue
+			}
+			if result.Retry == types.Terminal {
+				debugLog.Printf("Not rerunning check '%s', its result is terminal", result.Name)
+				continue
+			}
+			notSucceeded[result.CheckName()] = true
+		}
+		var rerunChecks []types.CheckItem
+		for _, check := range enabledChecks {
+			if notSucceeded[check.Name] && !check.Informational {
+				rerunChecks = append(rerunChecks, check)
+			}
+		}
+		if len(rerunChecks) == 0 {
+			break
+		}
+
+		debugLog.Printf("Rerunning %d checks that did not succeed (rerun %d of %d)", len(rerunChecks), rerun, opts.RerunFailed)
+		var onRerunResult func(types.CheckResult)
+		if onResult != nil {
+			onRerunResult = func(result types.CheckResult) {
+				result.Reruns = rerun
+				onResult(result)
+			}
+		}
+		rerunResults, rerunTimedOut := executeChecks(checksCtx, executor, rerunChecks, timeout, opts.NoParallel, onRerunResult)
+		// The rerun replaces all results of a check, which may produce different results
+		// each time
+		rerunNames := make(map[string]bool, len(rerunChecks))
+		for _, check := range rerunChecks {
+			rerunNames[check.Name] = true
+		}
+		// Checks the run budget left no time to start keep the results of their last run
+		var stillTimedOut []timedOutCheck
+		for _, check := range rerunTimedOut {
+			if check.Started {
+				stillTimedOut = append(stillTimedOut, check)
 			} else {
-				results = append(results, res.result)
-				debugLog.Printf("Check '%s' completed successfully", res.item.Name)
+				delete(rerunNames, check.Name)
 			}
 		}
+		results = slices.DeleteFunc(results, func(result types.CheckResult) bool {
+			return rerunNames[result.CheckName()]
+		})
+		for _, result := range rerunResults {
+			if !rerunNames[result.CheckName()] {
+				continue
+			}
+			result.Reruns = rerun
+			results = append(results, result)
+		}
+
+		// Only the last run of a check counts as timed out
+		for _, check := range timedOutChecks {
+			if !rerunNames[check.Name] {
+				stillTimedOut = append(stillTimedOut, check)
+			}
+		}
+		timedOutChecks = stillTimedOut
 	}
 
+	results = append(results, skippedResults...)
+	if onResult != nil {
+		for _, result := range skippedResults {
+			onResult(result)
+		}
+	}
+
+	// Informational and skipped checks are reported without affecting the exit code
+	var failedChecks []string
+	for _, result := range results {
+		if result.Status != types.Success && result.Status != types.Skipped && !checkOf(checksByName, result).Informational {
+			failedChecks = append(failedChecks, result.Name)
+		}
+	}
+	timedOutChecks = slices.DeleteFunc(timedOutChecks, func(check timedOutCheck) bool {
+		return check.Informational
+	})
+
+	for i, result := range results {
+		results[i] = finalize(result)
+	}
+
+	// Run the hooks once the final results are known, e.g. after reruns
+	runHooks(cmd.Context(), cmd.ErrOrStderr(), executor, results, checksByName, opts.NoParallel)
+
 	// Format and write all results
 	var output string
 
-	// Sort results by name for consistent output
+	// Sort results by name, or in the order the checks were launched, for consistent output
 	sortedResults := make([]types.CheckResult, len(results))
 	copy(sortedResults, results)
-	sort.Slice(sortedResults, func(i, j int) bool {
-		return sortedResults[i].Name < sortedResults[j].Name
-	})
+	if opts.Sort == sortByConfig {
+		position := make(map[string]int, len(cfg.Checks))
+		for i, check := range cfg.Checks {
+			position[check.Name] = i
+		}
+		sort.SliceStable(sortedResults, func(i, j int) bool {
+			return position[sortedResults[i].CheckName()] < position[sortedResults[j].CheckName()]
+		})
+	} else {
+		sort.Slice(sortedResults, func(i, j int) bool {
+			return sortedResults[i].Name < sortedResults[j].Name
+		})
+	}
 
-	// Get system information once
-	osInfo := fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH)
 	metadata := types.OutputMetadata{
-		DateTime: time.Now().Format(time.RFC3339),
-		Version:  version.GetVersion(),
-		OS:       osInfo,
+		Title:       opts.ReportTitle,
+		Name:        cfg.Name,
+		Description: cfg.Description,
+	}
+	// Anonymized output only keeps the metadata set by the user
+	if !opts.NoMetadata {
+		metadata.DateTime = time.Now().Format(time.RFC3339)
+		metadata.Version = version.GetVersion()
+		metadata.OS = fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH)
 	}
 
 	// Map output formats to their respective formatting functions
@@ -297,31 +710,65 @@ func run(cmd *cobra.Command, opts *Options) error {
 	}
 
 	// Get the appropriate formatting function and execute it
-	if formatFunc, ok := formatFuncs[opts.OutputFormat]; ok {
-		output = formatFunc(sortedResults, metadata)
+	var formatErr error
+	if opts.SummaryOnly {
+		summary := types.NewSummary(sortedResults, time.Since(startTime).Round(time.Millisecond))
+		if opts.OutputFormat == types.OutputFormatJSON {
+			output, formatErr = formatter.FormatSummaryJSON(summary, metadata)
+		} else {
+			output = formatter.FormatSummaryPretty(summary)
+		}
+	} else if opts.JSONGrouped && opts.OutputFormat == types.OutputFormatJSON {
+		// The results.json of the output directory stays flat, so it can be read back
+		output, formatErr = formatter.FormatResultsJSONGrouped(sortedResults, metadata)
+	} else if formatFunc, ok := formatFuncs[opts.OutputFormat]; ok {
+		output, formatErr = formatFunc(sortedResults, metadata)
 	} else {
 		// Fallback to pretty format if format is not supported
-		output = formatter.FormatResultsPretty(sortedResults, metadata)
+		output, formatErr = formatter.FormatResultsPretty(sortedResults, metadata)
+	}
+	if formatErr != nil {
+		// Never write a broken report, but still show the results in the pretty format
+		fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] Failed to format results as %s: %v\n", opts.OutputFormat, formatErr)
+		pretty, _ := formatter.FormatResultsPretty(sortedResults, metadata)
+		cmd.OutOrStdout().Write([]byte(pretty))
+		return fmt.Errorf("output error: %w", formatErr)
 	}
 
-	// Write output to stdout or file
-	if opts.OutputFile != "" {
-		// Create parent directories if they don't exist
-		dir := filepath.Dir(opts.OutputFile)
-		if dir != "." {
-			if err := os.MkdirAll(dir, 0755); err != nil {
-				fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] Failed to create directory for output file: %v\n", err)
-				return fmt.Errorf("output error: %w", err)
-			}
+	// Write the results in every format to the output directory
+	if opts.OutputDir != "" {
+		if err := writeOutputDir(opts.OutputDir, sortedResults, metadata, formatFuncs); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] Failed to write to output directory '%s': %v\n", opts.OutputDir, err)
+			return fmt.Errorf("output error: %w", err)
+		}
+		debugLog.Printf("Output written to directory: %s", opts.OutputDir)
+	}
+
+	// Record the results of the run, e.g. to find flaky checks with the history command
+	if opts.HistoryFile != "" {
+		if err := appendHistory(opts.HistoryFile, startTime, sortedResults); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] Failed to append to history file '%s': %v\n", opts.HistoryFile, err)
+			return fmt.Errorf("history error: %w", err)
 		}
+		debugLog.Printf("Results appended to history file: %s", opts.HistoryFile)
+	}
 
-		// Write to file
-		if err := os.WriteFile(opts.OutputFile, []byte(output), 0644); err != nil {
+	// Write output to stdout or file
+	if opts.TUI && ui.IsTerminal(cmd.InOrStdin(), cmd.OutOrStdout()) {
+		if err := ui.RunTUI(sortedResults, metadata, cmd.InOrStdin(), cmd.OutOrStdout()); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] Failed to run the interactive results browser: %v\n", err)
+			return fmt.Errorf("output error: %w", err)
+		}
+	} else if opts.OutputFile != "" {
+		if err := writeOutputFile(opts.OutputFile, output); err != nil {
 			fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] Failed to write to output file '%s': %v\n", opts.OutputFile, err)
 			return fmt.Errorf("output error: %w", err)
 		}
 		debugLog.Printf("Output written to file: %s", opts.OutputFile)
 	} else {
+		if opts.TUI {
+			debugLog.Printf("Not running in a terminal, using pretty output instead of the interactive results browser")
+		}
 		// Write output to stdout
 		if _, err := cmd.OutOrStdout().Write([]byte(output)); err != nil {
 			// Always show critical errors, even in non-verbose mode
@@ -330,10 +777,34 @@ func run(cmd *cobra.Command, opts *Options) error {
 		}
 	}
 
+	// Report where the time went, e.g. to find the bottleneck of a long run
+	if opts.Profile || opts.Verbose {
+		writeSlowestChecks(cmd.ErrOrStderr(), results, startTime)
+	}
+
+	// Run the after-run hook once the results have been written, e.g. to upload the report
+	afterRun := cfg.AfterRun
+	if opts.AfterRun != "" {
+		afterRun = opts.AfterRun
+	}
+	var afterRunErr error
+	if afterRun != "" {
+		afterRunErr = runAfterRun(cmd.Context(), executor, formatter, afterRun, sortedResults, metadata, time.Since(startTime))
+		if afterRunErr != nil {
+			// Always show hook failures, even in non-verbose mode
+			fmt.Fprintf(cmd.ErrOrStderr(), "[WARN] After-run hook failed: %v\n", afterRunErr)
+		}
+	}
+
+	if aborted {
+		// Always show why checks did not run, even in non-verbose mode
+		fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] Run aborted after %d failures (--max-failures), the remaining checks are reported as skipped\n", failures)
+	}
+
 	if len(timedOutChecks) > 0 {
 		// Show summary in non-verbose mode
 		if !opts.Verbose {
-			fmt.Fprintf(cmd.ErrOrStderr(), "[ERROR] %d checks timed out\n", len(timedOutChecks))
+			writeTimeoutSummary(cmd.ErrOrStderr(), timedOutChecks)
 		}
 		return context.DeadlineExceeded
 	}
@@ -348,6 +819,361 @@ func run(cmd *cobra.Command, opts *Options) error {
 		return ErrChecksFailure
 	}
 
+	if afterRunErr != nil && (opts.AfterRunRequired || cfg.AfterRunRequired) {
+		return fmt.Errorf("after-run hook error: %w", afterRunErr)
+	}
+
 	debugLog.Printf("All checks completed successfully")
 	return nil
 }
+
+// checkOf returns the configured check a result belongs to. The results of a check
+// producing multiple results belong to children of the check, which inherit its settings.
+func checkOf(checksByName map[string]types.CheckItem, result types.CheckResult) types.CheckItem {
+	if result.Parent != "" {
+		return checksByName[result.Parent].Child(result.Name)
+	}
+	return checksByName[result.Name]
+}
+
+// redactResult masks the sensitive values of a check in its result, using the global rules
+// and the check's own. Logic checks include the results of their sub-checks in their
+// output, so the values the sub-checks consider sensitive are masked as well.
+func redactResult(result types.CheckResult, check types.CheckItem, globalRules []string) (types.CheckResult, error) {
+	rules := append(append([]string{}, globalRules...), check.Redact...)
+	// Secret values are masked as well, but names of unset secrets would be taken
+	// for regular expressions
+	for _, name := range checks.SecretParameters(check) {
+		_, isParam := check.Parameters[name]
+		_, isEnv := check.Env[name]
+		if isParam || isEnv {
+			rules = append(rules, name)
+		}
+	}
+	result, err := redact.Result(result, check, rules)
+	if err != nil {
+		return result, err
+	}
+	for _, sub := range check.Checks {
+		if result, err = redactResult(result, sub, nil); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// docURL returns the documentation URL of a check, or of its type if it does not set one
+func docURL(check types.CheckItem) string {
+	if check.DocURL != "" {
+		return check.DocURL
+	}
+	if registered, err := checks.Get(check.Type); err == nil {
+		return registered.DocURL
+	}
+	return ""
+}
+
+// suiteTimeout returns the timeout for running the checks. Every check gets a window of
+// the global timeout, or of the default timeout of its check type when that applies.
+// When running sequentially, the windows follow one after the other.
+func suiteTimeout(executor *executor.Executor, timeout time.Duration, checks []types.CheckItem, noParallel bool) time.Duration {
+	var total time.Duration
+	longest := timeout
+	for _, check := range checks {
+		window := timeout
+		if check.Timeout == nil {
+			window = executor.CheckTimeout(check)
+		}
+		total += window
+		longest = max(longest, window)
+	}
+	if noParallel {
+		return total
+	}
+	return longest
+}
+
+// budgetShare caps the timeout of a check at its share of the time left until a deadline,
+// split evenly among the checks that have yet to run. Checks finishing early leave more
+// time to the ones after them.
+func budgetShare(executor *executor.Executor, check types.CheckItem, deadline time.Time, remaining int) types.CheckItem {
+	share := time.Until(deadline) / time.Duration(remaining)
+	if share < executor.CheckTimeout(check) {
+		check.Timeout = &share
+	}
+	return check
+}
+
+// timedOutCheck is a check that timed out. Checks that did not start were still waiting
+// for earlier checks to complete when the run timed out.
+type timedOutCheck struct {
+	types.CheckItem
+	Started bool
+}
+
+// writeTimeoutSummary reports how many checks timed out, telling the checks that were
+// interrupted while running from the checks that never started
+func writeTimeoutSummary(w io.Writer, timedOutChecks []timedOutCheck) {
+	var notStarted []string
+	for _, check := range timedOutChecks {
+		if !check.Started {
+			notStarted = append(notStarted, check.Name)
+		}
+	}
+	if len(notStarted) == 0 {
+		fmt.Fprintf(w, "[ERROR] %d checks timed out\n", len(timedOutChecks))
+		return
+	}
+	fmt.Fprintf(w, "[ERROR] %d checks timed out: %d running, %d never started (%s)\n",
+		len(timedOutChecks), len(timedOutChecks)-len(notStarted), len(notStarted), strings.Join(notStarted, ", "))
+}
+
+// executeChecks runs the checks concurrently, or one at a time in order, and returns
+// their results and the checks that timed out. If set, onResult is called with every
+// result as soon as it is collected. Checks run one at a time share the time left until
+// the deadline of the parent context, e.g. of --run-budget. When the parent context is
+// cancelled with errRunAborted, the checks that did not complete are reported as skipped.
+func executeChecks(parent context.Context, executor *executor.Executor, checkItems []types.CheckItem, timeout time.Duration, noParallel bool, onResult func(types.CheckResult)) ([]types.CheckResult, []timedOutCheck) {
+	startTime := time.Now()
+	ctx, cancel := context.WithTimeout(parent, suiteTimeout(executor, timeout, checkItems, noParallel))
+	defer cancel()
+
+	// Create channels for results and errors
+	type checkResult struct {
+		results []types.CheckResult
+		err     error
+		item    types.CheckItem
+	}
+	resultChan := make(chan checkResult, len(checkItems))
+	// Checks are pending until they start running, which tells apart the checks that
+	// never started when the run times out
+	started := make([]atomic.Bool, len(checkItems))
+
+	debugLog.Printf("Starting execution of %d checks", len(checkItems))
+
+	if noParallel {
+		// Run checks one at a time, awaiting each result before starting the next
+		go func() {
+			for i, checkItem := range checkItems {
+				if ctx.Err() != nil {
+					return
+				}
+				if deadline, ok := parent.Deadline(); ok {
+					checkItem = budgetShare(executor, checkItem, deadline, len(checkItems)-i)
+				}
+				debugLog.Printf("Executing check: %s", checkItem.Name)
+				started[i].Store(true)
+				results, err := executor.ExecuteCheckResults(ctx, checkItem)
+				resultChan <- checkResult{results: results, err: err, item: checkItem}
+			}
+		}()
+	} else {
+		// Start all checks concurrently
+		for i, checkItem := range checkItems {
+			i, checkItem := i, checkItem // Create new variables for goroutine
+			go func() {
+				debugLog.Printf("Executing check: %s", checkItem.Name)
+				started[i].Store(true)
+				results, err := executor.ExecuteCheckResults(ctx, checkItem)
+				resultChan <- checkResult{results: results, err: err, item: checkItem}
+			}()
+		}
+	}
+
+	// Collect results
+	var results []types.CheckResult
+	var timedOutChecks []timedOutCheck
+	collect := func(result types.CheckResult) {
+		results = append(results, result)
+		if onResult != nil {
+			onResult(result)
+		}
+	}
+	remainingChecks := len(checkItems)
+
+	for remainingChecks > 0 {
+		select {
+		case <-ctx.Done():
+			aborted := context.Cause(ctx) == errRunAborted
+			if aborted {
+				debugLog.Printf("Run aborted after %v", time.Since(startTime))
+			} else {
+				debugLog.Printf("Global timeout reached after %v", time.Since(startTime))
+			}
+			// Add timeout results for all remaining checks, or skipped results if the run
+			// was aborted
+			for i, check := range checkItems {
+				found := false
+				for _, res := range results {
+					if res.CheckName() == check.Name {
+						found = true
+						break
+					}
+				}
+				if !found && aborted {
+					output := "check was cancelled when the run was aborted"
+					if !started[i].Load() {
+						output = "check did not run, the run was aborted"
+					}
+					collect(types.CheckResult{
+						Name:   check.Name,
+						Type:   check.Type,
+						Status: types.Skipped,
+						Output: output,
+					})
+				} else if !found {
+					timedOut := timedOutCheck{CheckItem: check, Started: started[i].Load()}
+					output := "check execution timed out"
+					if !timedOut.Started {
+						output = "check did not start before the run timed out"
+					}
+					collect(types.CheckResult{
+						Name:   check.Name,
+						Type:   check.Type,
+						Status: types.Error,
+						Output: output,
+						Retry:  types.Retryable,
+					})
+					timedOutChecks = append(timedOutChecks, timedOut)
+					debugLog.Printf("Check '%s' timed out (started: %t)", check.Name, timedOut.Started)
+				}
+			}
+			remainingChecks = 0
+		case res := <-resultChan:
+			remainingChecks--
+			if res.err == context.DeadlineExceeded {
+				timedOutChecks = append(timedOutChecks, timedOutCheck{CheckItem: res.item, Started: true})
+				collect(types.CheckResult{
+					Name:       res.item.Name,
+					Type:       res.item.Type,
+					Status:     types.Error,
+					Output:     "check execution timed out",
+					Retry:      types.Retryable,
+					StartedAt:  res.results[0].StartedAt,
+					FinishedAt: res.results[0].FinishedAt,
+				})
+				debugLog.Printf("Check '%s' timed out", res.item.Name)
+			} else if res.err != nil && context.Cause(ctx) == errRunAborted {
+				collect(types.CheckResult{
+					Name:   res.item.Name,
+					Type:   res.item.Type,
+					Status: types.Skipped,
+					Output: "check was cancelled when the run was aborted",
+				})
+			} else if res.err != nil {
+				collect(types.CheckResult{
+					Name:   res.item.Name,
+					Type:   res.item.Type,
+					Status: types.Error,
+					Output: fmt.Sprintf("check failed: %v", res.err),
+				})
+				debugLog.Printf("Check '%s' failed: %v", res.item.Name, res.err)
+			} else {
+				// Checks producing multiple results add all of them to the results
+				for _, result := range res.results {
+					collect(result)
+					if result.Status != types.Success {
+						debugLog.Printf("Check '%s' failed with status: %s", result.Name, result.Status)
+					} else {
+						debugLog.Printf("Check '%s' completed successfully", result.Name)
+					}
+				}
+			}
+		}
+	}
+
+	return results, timedOutChecks
+}
+
+// outputDirFiles maps the output formats to the files written by --output-dir
+var outputDirFiles = map[types.OutputFormat]string{
+	types.OutputFormatJSON:   "results.json",
+	types.OutputFormatHTML:   "results.html",
+	types.OutputFormatPretty: "results.txt",
+}
+
+// writeOutputDir writes the results in every output format to a directory, creating it if
+// needed. All formats share the same metadata.
+func writeOutputDir(dir string, results []types.CheckResult, metadata types.OutputMetadata, formatFuncs map[types.OutputFormat]ui.FormatFunc) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, format := range types.SupportedOutputFormats() {
+		output, err := formatFuncs[format](results, metadata)
+		if err != nil {
+			return fmt.Errorf("failed to format results as %s: %w", format, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, outputDirFiles[format]), []byte(output), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maskSecrets returns a copy of the checks and their sub-checks with the values of their
+// secret parameters and environment variables masked
+func maskSecrets(items []types.CheckItem) []types.CheckItem {
+	masked := make([]types.CheckItem, len(items))
+	for i, item := range items {
+		masked[i] = redact.Secrets(item, checks.SecretParameters(item))
+		if item.Checks != nil {
+			masked[i].Checks = maskSecrets(item.Checks)
+		}
+	}
+	return masked
+}
+
+// resolveParameters validates the parameters of the checks and their sub-checks against
+// their check types, reporting warnings and errors to w. It returns the checks with their
+// resolved parameters and the number of errors.
+func resolveParameters(w io.Writer, items []types.CheckItem) ([]types.CheckItem, int) {
+	resolved := make([]types.CheckItem, len(items))
+	problems := 0
+	for i, item := range items {
+		resolved[i] = item
+		// Sub-checks with only a name reference another check, which is validated on its own
+		if item.Type != "" {
+			params, warnings, errs := checks.ResolveParameters(item)
+			for _, warning := range warnings {
+				fmt.Fprintf(w, "[WARN] Check '%s': %s\n", item.Name, warning)
+			}
+			for _, err := range errs {
+				fmt.Fprintf(w, "[ERROR] Check '%s': %v\n", item.Name, err)
+			}
+			problems += len(errs)
+			if len(params) > 0 {
+				resolved[i].Parameters = params
+			}
+		}
+		if item.Checks != nil {
+			var subProblems int
+			resolved[i].Checks, subProblems = resolveParameters(w, item.Checks)
+			problems += subProblems
+		}
+	}
+	return resolved, problems
+}
+
+// dumpConfig writes the configuration as YAML, or as JSON when the JSON output format is used
+func dumpConfig(w io.Writer, cfg *types.Config, format types.OutputFormat) error {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	if format == types.OutputFormatJSON {
+		// Convert through YAML so that field names and durations match the configuration file
+		var generic map[string]interface{}
+		if err := yaml.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		data, err = json.MarshalIndent(generic, "", "  ")
+		if err != nil {
+			return err
+		}
+		data = append(data, '\n')
+	}
+
+	_, err = w.Write(data)
+	return err
+}
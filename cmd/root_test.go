@@ -7,12 +7,18 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/seastar-consulting/checkers/checks"
+	_ "github.com/seastar-consulting/checkers/checks/logic" // Register logic checks
+	"github.com/seastar-consulting/checkers/internal/executor"
 	"github.com/seastar-consulting/checkers/types"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 func TestNewRootCommand(t *testing.T) {
@@ -207,6 +213,26 @@ checks:
 `,
 			wantErr: false,
 		},
+		{
+			name: "check timeout exceeding global timeout warns",
+			opts: &Options{
+				ConfigFile: "test-config.yaml",
+				Timeout:    time.Second,
+			},
+			configYAML: `
+checks:
+  - name: long-timeout-check
+    type: command
+    command: "echo hello"
+    timeout: 1m
+`,
+			wantErr: false,
+			checkOutput: func(t *testing.T, output string) {
+				if !strings.Contains(output, "[WARN] Check 'long-timeout-check' has a timeout (1m0s) exceeding the global timeout (1s)") {
+					t.Errorf("expected timeout warning in output, got: %s", output)
+				}
+			},
+		},
 		{
 			name: "command-line timeout overrides config timeout",
 			opts: &Options{
@@ -273,77 +299,1466 @@ checks:
 				}
 			}
 
-			if tt.checkOutput != nil {
-				tt.checkOutput(t, output)
-			}
-		})
+			if tt.checkOutput != nil {
+				tt.checkOutput(t, output)
+			}
+		})
+	}
+}
+
+func TestConcurrentExecution(t *testing.T) {
+	// Create a temporary directory for test files
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "concurrent-test.yaml")
+
+	// Create a config with multiple checks that have measurable execution times
+	config := `
+checks:
+  - name: concurrent-check-1
+    type: command
+    command: "sleep 0.5 && echo '{\"status\":\"success\",\"output\":\"check 1\"}'"
+  - name: concurrent-check-2
+    type: command
+    command: "sleep 0.5 && echo '{\"status\":\"success\",\"output\":\"check 2\"}'"
+  - name: concurrent-check-3
+    type: command
+    command: "sleep 0.5 && echo '{\"status\":\"success\",\"output\":\"check 3\"}'"
+`
+
+	err := os.WriteFile(configPath, []byte(config), 0644)
+	if err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	// Create a command with a buffer for output
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(outBuf)
+
+	// Set command line arguments with a timeout that would fail if checks run sequentially
+	cmd.SetArgs([]string{
+		"--config", configPath,
+		"--verbose",
+		"--timeout", "1s", // This should be enough for concurrent execution but not for sequential
+	})
+
+	// Record start time
+	start := time.Now()
+
+	// Execute the command
+	err = cmd.Execute()
+	if err != nil {
+		t.Errorf("command execution failed: %v", err)
+		return
+	}
+
+	// Check execution time
+	executionTime := time.Since(start)
+	if executionTime >= 1500*time.Millisecond {
+		t.Errorf("checks appear to run sequentially, took %v", executionTime)
+	}
+
+	// Check output for all checks
+	output := outBuf.String()
+	for i := 1; i <= 3; i++ {
+		checkName := fmt.Sprintf("concurrent-check-%d", i)
+		if !strings.Contains(output, checkName) {
+			t.Errorf("output missing check %s", checkName)
+		}
+		if !strings.Contains(output, fmt.Sprintf("check %d", i)) {
+			t.Errorf("output missing result for check %d", i)
+		}
+	}
+}
+
+func TestSequentialExecution(t *testing.T) {
+	// Create a temporary directory for test files
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "sequential-test.yaml")
+	orderFile := filepath.Join(tmpDir, "order.txt")
+
+	// The first check is slower, so it would finish last if checks ran concurrently
+	config := fmt.Sprintf(`
+checks:
+  - name: sequential-check-1
+    type: command
+    command: "sleep 0.3 && echo first >> %[1]s"
+  - name: sequential-check-2
+    type: command
+    command: "echo second >> %[1]s"
+`, orderFile)
+
+	err := os.WriteFile(configPath, []byte(config), 0644)
+	if err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(outBuf)
+	cmd.SetArgs([]string{
+		"--config", configPath,
+		"--no-parallel",
+		"--timeout", "1s",
+	})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command execution failed: %v", err)
+	}
+
+	content, err := os.ReadFile(orderFile)
+	if err != nil {
+		t.Fatalf("failed to read order file: %v", err)
+	}
+	if got, want := string(content), "first\nsecond\n"; got != want {
+		t.Errorf("checks ran in order %q, want %q", got, want)
+	}
+}
+
+func TestInformationalChecks(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr error
+	}{
+		{
+			name: "failing informational check",
+			config: `
+checks:
+  - name: disk usage
+    type: command
+    informational: true
+    command: echo '{"status":"failure","output":"disk usage is 93%"}'
+  - name: passing check
+    type: command
+    command: echo '{"status":"success","output":"ok"}'
+`,
+		},
+		{
+			name: "timed out informational check",
+			config: `
+checks:
+  - name: slow inventory
+    type: command
+    informational: true
+    timeout: 100ms
+    command: sleep 1
+`,
+		},
+		{
+			name: "failing regular check",
+			config: `
+checks:
+  - name: disk usage
+    type: command
+    informational: true
+    command: echo '{"status":"failure","output":"disk usage is 93%"}'
+  - name: failing check
+    type: command
+    command: echo '{"status":"failure","output":"broken"}'
+`,
+			wantErr: ErrChecksFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "checks.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.config), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+
+			cmd := NewRootCommand()
+			outBuf := new(bytes.Buffer)
+			cmd.SetOut(outBuf)
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs([]string{"--config", configPath, "--output", "json"})
+
+			if err := cmd.Execute(); err != tt.wantErr {
+				t.Errorf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+
+			// Informational checks are still reported
+			var output types.JSONOutput
+			if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+				t.Fatalf("failed to parse output: %v\n%s", err, outBuf.String())
+			}
+			if !output.Results[0].Informational || output.Results[0].Status == types.Success {
+				t.Errorf("first result = %+v, want an informational result that did not succeed", output.Results[0])
+			}
+		})
+	}
+}
+
+func TestRerunFailed(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "rerun-test.yaml")
+
+	// Each check counts its runs in a file; the flaky check succeeds on its third run
+	config := fmt.Sprintf(`
+checks:
+  - name: stable
+    type: command
+    command: echo run >> %[1]s/stable && echo '{"status":"success","output":"ok"}'
+  - name: flaky
+    type: command
+    command: |
+      echo run >> %[1]s/flaky
+      if [ "$(wc -l < %[1]s/flaky)" -ge 3 ]; then
+        echo '{"status":"success","output":"recovered"}'
+      else
+        echo '{"status":"failure","output":"outage"}'
+      fi
+  - name: broken
+    type: command
+    command: echo run >> %[1]s/broken && echo '{"status":"failure","output":"broken"}'
+  - name: unavailable
+    type: command
+    command: echo run >> %[1]s/unavailable && exit 75
+    retryable_exit_codes: [75]
+  - name: denied
+    type: command
+    command: echo run >> %[1]s/denied && exit 77
+    retryable_exit_codes: [75]
+  - name: degraded
+    type: command
+    command: echo run >> %[1]s/degraded && echo '{"status":"warning","output":"degraded"}'
+`, tmpDir)
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--rerun-failed", "3", "--output", "json"})
+
+	if err := cmd.Execute(); err != ErrChecksFailure {
+		t.Fatalf("Execute() error = %v, want %v", err, ErrChecksFailure)
+	}
+
+	var output types.JSONOutput
+	if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, outBuf.String())
+	}
+	want := map[string]struct {
+		status types.CheckStatus
+		reruns int
+		runs   int
+	}{
+		"stable": {status: types.Success, reruns: 0, runs: 1},
+		"flaky":  {status: types.Success, reruns: 2, runs: 3},
+		"broken": {status: types.Failure, reruns: 3, runs: 4},
+		// Only retryable exit codes are rerun when a check declares them
+		"unavailable": {status: types.Error, reruns: 3, runs: 4},
+		"denied":      {status: types.Error, reruns: 0, runs: 1},
+		// Warnings are not rerun
+		"degraded": {status: types.Warning, reruns: 0, runs: 1},
+	}
+	for _, result := range output.Results {
+		w := want[result.Name]
+		if result.Status != w.status || result.Reruns != w.reruns {
+			t.Errorf("check %q: status %s after %d reruns, want %s after %d", result.Name, result.Status, result.Reruns, w.status, w.reruns)
+		}
+		content, err := os.ReadFile(filepath.Join(tmpDir, result.Name))
+		if err != nil {
+			t.Fatalf("failed to read run count: %v", err)
+		}
+		if runs := strings.Count(string(content), "run"); runs != w.runs {
+			t.Errorf("check %q ran %d times, want %d", result.Name, runs, w.runs)
+		}
+	}
+
+	// Negative values are rejected
+	cmd = NewRootCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--rerun-failed", "-1"})
+	if err := cmd.Execute(); err == nil || err.Error() != "invalid number of reruns: -1 (must not be negative)" {
+		t.Errorf("Execute() error = %v, want invalid number of reruns", err)
+	}
+}
+
+func TestBudgetShare(t *testing.T) {
+	checkExecutor := executor.NewExecutor(10 * time.Second)
+	deadline := time.Now().Add(9 * time.Second)
+
+	// The remaining budget is split evenly, unless the check's own timeout is shorter
+	check := budgetShare(checkExecutor, types.CheckItem{Name: "slow"}, deadline, 3)
+	if check.Timeout == nil || *check.Timeout > 3*time.Second || *check.Timeout < 2*time.Second {
+		t.Errorf("budgetShare() timeout = %v, want about 3s", check.Timeout)
+	}
+	short := time.Second
+	check = budgetShare(checkExecutor, types.CheckItem{Name: "fast", Timeout: &short}, deadline, 3)
+	if check.Timeout != &short {
+		t.Errorf("budgetShare() timeout = %v, want the check's own timeout of 1s", *check.Timeout)
+	}
+}
+
+func TestRunBudget(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "budget-test.yaml")
+	// With an even split of the budget the second check would time out, but the first
+	// one leaves it most of its share
+	config := `
+checks:
+  - name: fast
+    type: command
+    command: echo ok
+  - name: slow
+    type: command
+    command: sleep 0.5 && echo ok
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cmd := NewRootCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--no-parallel", "--run-budget", "800ms"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	// Checks are cancelled when the budget is exhausted, even within their own timeout
+	for _, args := range [][]string{{"--run-budget", "200ms"}, {"--run-budget", "200ms", "--no-parallel"}} {
+		cmd = NewRootCommand()
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs(append([]string{"--config", configPath, "--timeout", "5s"}, args...))
+		start := time.Now()
+		if err := cmd.Execute(); err != context.DeadlineExceeded {
+			t.Errorf("Execute() with %v error = %v, want %v", args, err, context.DeadlineExceeded)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("Execute() with %v took %v, want the budget of 200ms to be respected", args, elapsed)
+		}
+	}
+
+	cmd = NewRootCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--run-budget", "-1s"})
+	if err := cmd.Execute(); err == nil || err.Error() != "invalid run budget: -1s (must not be negative)" {
+		t.Errorf("Execute() error = %v, want invalid run budget", err)
+	}
+}
+
+func TestRunBudgetRerun(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "budget-rerun-test.yaml")
+	config := `
+checks:
+  - name: fails
+    type: command
+    command: echo "disk full on /var" && exit 3
+  - name: slow
+    type: command
+    command: sleep 5
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	// Checks are not rerun once the budget is exhausted, so they keep their results
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs([]string{"--config", configPath, "--output", "json", "--run-budget", "300ms", "--rerun-failed", "1"})
+	if err := cmd.Execute(); err != context.DeadlineExceeded {
+		t.Fatalf("Execute() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	var output types.JSONOutput
+	if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, outBuf.String())
+	}
+	for _, result := range output.Results {
+		if result.Name == "fails" && (!strings.Contains(result.Output, "disk full on /var") || result.Reruns != 0) {
+			t.Errorf("result of fails = %+v, want the result of its first run", result)
+		}
+	}
+	if strings.Contains(errBuf.String(), "never started") {
+		t.Errorf("stderr reports checks that never started:\n%s", errBuf.String())
+	}
+}
+
+func TestPriority(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "priority-test.yaml")
+	orderFile := filepath.Join(tmpDir, "order.txt")
+
+	config := fmt.Sprintf(`
+checks:
+  - name: check-c
+    type: command
+    command: "echo c >> %[1]s"
+  - name: check-a
+    type: command
+    command: "echo a >> %[1]s"
+    priority: 2
+  - name: check-b
+    type: command
+    command: "echo b >> %[1]s"
+    priority: -1
+`, orderFile)
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--no-parallel", "--sort", "config", "--output", "json"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command execution failed: %v", err)
+	}
+
+	content, err := os.ReadFile(orderFile)
+	if err != nil {
+		t.Fatalf("failed to read order file: %v", err)
+	}
+	if got, want := string(content), "b\nc\na\n"; got != want {
+		t.Errorf("checks ran in order %q, want %q", got, want)
+	}
+
+	var output types.JSONOutput
+	if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	var names []string
+	for _, result := range output.Results {
+		names = append(names, result.Name)
+	}
+	if got, want := strings.Join(names, ","), "check-b,check-c,check-a"; got != want {
+		t.Errorf("results in order %s, want %s", got, want)
+	}
+}
+
+func TestInvalidSortOrder(t *testing.T) {
+	cmd := NewRootCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--sort", "status"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "invalid sort order: status") {
+		t.Errorf("Execute() error = %v, want invalid sort order error", err)
+	}
+}
+
+func TestRedaction(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "redact-test.yaml")
+
+	config := `
+redact:
+  - "token=[a-z0-9]+"
+checks:
+  - name: redact-check
+    type: command
+    command: "echo \"token=abc123 password=$DB_PASSWORD\""
+    items:
+      - DB_PASSWORD: hunter2
+    redact:
+      - DB_PASSWORD
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(outBuf)
+	cmd.SetArgs([]string{
+		"--config", configPath,
+		"--output", "json",
+	})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command execution failed: %v", err)
+	}
+
+	var output types.JSONOutput
+	if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\nOutput: %s", err, outBuf.String())
+	}
+	if len(output.Results) != 1 {
+		t.Fatalf("expected one result, got: %+v", output.Results)
+	}
+	if got, want := output.Results[0].Output, "*** password=***"; got != want {
+		t.Errorf("redacted output = %q, want %q", got, want)
+	}
+}
+
+func TestRemediation(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "remediation-test.yaml")
+
+	config := `
+checks:
+  - name: failing-check
+    type: command
+    command: echo '{"status":"failure","output":"missing"}'
+    remediation: "run ./scripts/setup.sh"
+  - name: passing-check
+    type: command
+    command: echo '{"status":"success","output":"ok"}'
+    remediation: "not needed"
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{
+		"--config", configPath,
+		"--output", "json",
+	})
+
+	if err := cmd.Execute(); err != ErrChecksFailure {
+		t.Fatalf("cmd.Execute() error = %v, want %v", err, ErrChecksFailure)
+	}
+
+	var output types.JSONOutput
+	if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\nOutput: %s", err, outBuf.String())
+	}
+	for _, result := range output.Results {
+		want := ""
+		if result.Name == "failing-check" {
+			want = "run ./scripts/setup.sh"
+		}
+		if result.Remediation != want {
+			t.Errorf("result %q remediation = %q, want %q", result.Name, result.Remediation, want)
+		}
+	}
+}
+
+func TestDocURL(t *testing.T) {
+	checks.Register("test.documented", "Check with documentation", func(item types.CheckItem) (types.CheckResult, error) {
+		return types.CheckResult{Name: item.Name, Type: item.Type, Status: types.CheckStatus(item.Parameters["status"])}, nil
+	},
+		checks.Parameter{Name: "status", Type: checks.ParamString},
+	)
+	checks.SetDocURL("test.documented", "https://docs.example.com/documented")
+	defer delete(checks.Registry, "test.documented")
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "doc-url-test.yaml")
+	config := `
+checks:
+  - name: failing-check
+    type: test.documented
+    parameters:
+      status: Failure
+  - name: overridden-check
+    type: test.documented
+    parameters:
+      status: Error
+    doc_url: https://wiki.example.com/runbook
+  - name: passing-check
+    type: test.documented
+    parameters:
+      status: Success
+  - name: command-check
+    type: command
+    command: echo '{"status":"failure","output":"missing"}'
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--output", "json"})
+	if err := cmd.Execute(); err != ErrChecksFailure {
+		t.Fatalf("cmd.Execute() error = %v, want %v", err, ErrChecksFailure)
+	}
+
+	var output types.JSONOutput
+	if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\nOutput: %s", err, outBuf.String())
+	}
+	want := map[string]string{
+		"failing-check":    "https://docs.example.com/documented",
+		"overridden-check": "https://wiki.example.com/runbook",
+		"passing-check":    "",
+		"command-check":    "",
+	}
+	for _, result := range output.Results {
+		if result.DocURL != want[result.Name] {
+			t.Errorf("result %q doc URL = %q, want %q", result.Name, result.DocURL, want[result.Name])
+		}
+	}
+}
+
+func TestCheckIDs(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "id-test.yaml")
+
+	config := `
+checks:
+  - id: disk-root
+    name: Disk space
+    type: command
+    command: echo ok
+  - name: Home directory
+    type: os.file_exists
+    parameters:
+      path: /tmp
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--output", "json"})
+	cmd.Execute()
+
+	var output types.JSONOutput
+	if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\nOutput: %s", err, outBuf.String())
+	}
+	wantIDs := map[string]string{
+		"Disk space": "disk-root",
+		"Home directory": types.CheckItem{
+			Name:       "Home directory",
+			Type:       "os.file_exists",
+			Parameters: map[string]string{"path": "/tmp"},
+		}.StableID(),
+	}
+	for _, result := range output.Results {
+		if result.ID != wantIDs[result.Name] {
+			t.Errorf("result %q ID = %q, want %q", result.Name, result.ID, wantIDs[result.Name])
+		}
+	}
+	if len(wantIDs["Home directory"]) != 16 {
+		t.Errorf("derived ID %q, want 16 hex characters", wantIDs["Home directory"])
+	}
+
+	// The derived ID only depends on the type, name and parameters
+	renamed := types.CheckItem{Name: "Home", Type: "os.file_exists", Parameters: map[string]string{"path": "/tmp"}}
+	if renamed.StableID() == wantIDs["Home directory"] {
+		t.Errorf("renamed check has the same ID %q", renamed.StableID())
+	}
+}
+
+func TestHooks(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "hooks-test.yaml")
+	hookLog := filepath.Join(tmpDir, "hooks.log")
+
+	config := fmt.Sprintf(`
+checks:
+  - name: failing-check
+    type: command
+    command: echo '{"status":"failure","output":"missing"}'
+    on_failure: echo "failure $CHECKERS_CHECK_NAME" >> %[1]s
+  - name: passing-check
+    type: command
+    command: echo '{"status":"success","output":"ok"}'
+    on_success: echo "success $CHECKERS_CHECK_NAME" >> %[1]s
+    on_failure: echo "unexpected failure" >> %[1]s
+  - name: broken-hook
+    type: command
+    command: echo '{"status":"success","output":"ok"}'
+    on_success: exit 1
+`, hookLog)
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs([]string{"--config", configPath, "--output", "json", "--sort", "config", "--no-parallel"})
+
+	if err := cmd.Execute(); err != ErrChecksFailure {
+		t.Fatalf("cmd.Execute() error = %v, want %v", err, ErrChecksFailure)
+	}
+
+	data, err := os.ReadFile(hookLog)
+	if err != nil {
+		t.Fatalf("failed to read hook log: %v", err)
+	}
+	if want := "failure failing-check\nsuccess passing-check\n"; string(data) != want {
+		t.Errorf("hook log = %q, want %q", data, want)
+	}
+
+	// Failing hooks are reported without changing the result
+	if !strings.Contains(errBuf.String(), "[WARN] Hook of check 'broken-hook' failed: hook failed: exit status 1") {
+		t.Errorf("stderr missing hook failure, got: %s", errBuf.String())
+	}
+	var output types.JSONOutput
+	if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\nOutput: %s", err, outBuf.String())
+	}
+	for _, result := range output.Results {
+		if result.Name == "broken-hook" && result.Status != types.Success {
+			t.Errorf("broken-hook status = %s, want %s", result.Status, types.Success)
+		}
+	}
+}
+
+func TestAfterRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "after-run-test.yaml")
+	hookLog := filepath.Join(tmpDir, "after-run.log")
+
+	config := fmt.Sprintf(`
+after_run: echo "config" > %s
+checks:
+  - name: failing-check
+    type: command
+    command: echo '{"status":"failure","output":"missing"}'
+  - name: passing-check
+    type: command
+    command: echo '{"status":"success","output":"ok"}'
+`, hookLog)
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	summaryHook := fmt.Sprintf(`echo "$CHECKERS_TOTAL $CHECKERS_PASSED $CHECKERS_FAILED $CHECKERS_FAILED_CHECKS" > %[1]s; grep -c '"name"' "$CHECKERS_RESULTS_FILE" >> %[1]s`, hookLog)
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantErr    string
+		wantLog    string
+		wantStderr string
+	}{
+		{
+			name:    "hook from the config file",
+			wantErr: ErrChecksFailure.Error(),
+			wantLog: "config\n",
+		},
+		{
+			name:    "summary and results file",
+			args:    []string{"--after-run", summaryHook},
+			wantErr: ErrChecksFailure.Error(),
+			wantLog: "2 1 1 failing-check\n2\n",
+		},
+		{
+			name:       "failing hook is reported",
+			args:       []string{"--after-run", "exit 2", "--only", "passing-check"},
+			wantStderr: "[WARN] After-run hook failed: hook failed: exit status 2",
+		},
+		{
+			name:       "failing hook fails the run when required",
+			args:       []string{"--after-run", "exit 2", "--only", "passing-check", "--after-run-required"},
+			wantErr:    "after-run hook error: hook failed: exit status 2",
+			wantStderr: "[WARN] After-run hook failed: hook failed: exit status 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Remove(hookLog)
+			cmd := NewRootCommand()
+			errBuf := new(bytes.Buffer)
+			cmd.SetOut(new(bytes.Buffer))
+			cmd.SetErr(errBuf)
+			cmd.SetArgs(append([]string{"--config", configPath, "--output", "json"}, tt.args...))
+
+			err := cmd.Execute()
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("cmd.Execute() error = %v, want %q", err, tt.wantErr)
+			}
+			if tt.wantLog != "" {
+				data, err := os.ReadFile(hookLog)
+				if err != nil {
+					t.Fatalf("failed to read hook log: %v", err)
+				}
+				if string(data) != tt.wantLog {
+					t.Errorf("hook log = %q, want %q", data, tt.wantLog)
+				}
+			}
+			if !strings.Contains(errBuf.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want %q", errBuf.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestNoMetadata(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "no-metadata-test.yaml")
+
+	config := `
+name: Preflight
+checks:
+  - name: test-check
+    type: command
+    command: echo ok
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	outputDir := filepath.Join(tmpDir, "results")
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--output", "json", "--no-metadata", "--output-dir", outputDir})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("cmd.Execute() error = %v", err)
+	}
+
+	var output map[string]json.RawMessage
+	if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\nOutput: %s", err, outBuf.String())
+	}
+	if got, want := string(output["metadata"]), `{
+    "name": "Preflight"
+  }`; got != want {
+		t.Errorf("metadata = %s, want %s", got, want)
+	}
+
+	html, err := os.ReadFile(filepath.Join(outputDir, "results.html"))
+	if err != nil {
+		t.Fatalf("failed to read HTML report: %v", err)
+	}
+	for _, unwanted := range []string{"Version:", "OS:", runtime.GOOS} {
+		if strings.Contains(string(html), unwanted) {
+			t.Errorf("HTML report contains %q", unwanted)
+		}
+	}
+}
+
+func TestDisabledChecks(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "disabled-test.yaml")
+	markerFile := filepath.Join(tmpDir, "ran.txt")
+
+	config := fmt.Sprintf(`
+checks:
+  - name: enabled-check
+    type: command
+    enabled: true
+    command: echo ok
+  - name: disabled-check
+    type: command
+    enabled: false
+    remediation: Fix it
+    on_failure: touch %[1]s
+    command: touch %[1]s && exit 1
+`, markerFile)
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--output", "json", "--rerun-failed", "1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("cmd.Execute() error = %v", err)
+	}
+
+	if _, err := os.Stat(markerFile); err == nil {
+		t.Error("disabled check or its hook was run")
+	}
+
+	var output types.JSONOutput
+	if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, outBuf.String())
+	}
+	if len(output.Results) != 2 {
+		t.Fatalf("got %d results, want 2", len(output.Results))
+	}
+	got := output.Results[0]
+	want := types.CheckResult{
+		ID:     got.ID,
+		Name:   "disabled-check",
+		Type:   "command",
+		Status: types.Skipped,
+		Output: "disabled",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("result = %+v, want %+v", got, want)
+	}
+	if output.Results[1].Status != types.Success {
+		t.Errorf("enabled check status = %s, want %s", output.Results[1].Status, types.Success)
+	}
+}
+
+func TestConfigOutputFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "output-format-test.yaml")
+
+	config := `
+output_format: json
+checks:
+  - name: test-check
+    type: command
+    command: echo ok
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantJSON bool
+	}{
+		{
+			name:     "format of the config file",
+			wantJSON: true,
+		},
+		{
+			name: "flag takes precedence",
+			args: []string{"--output", "pretty"},
+		},
+		{
+			name: "file extension takes precedence",
+			args: []string{"--file", filepath.Join(tmpDir, "results.txt")},
+		},
+		{
+			name:     "file without extension",
+			args:     []string{"--file", filepath.Join(tmpDir, "results")},
+			wantJSON: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewRootCommand()
+			outBuf := new(bytes.Buffer)
+			cmd.SetOut(outBuf)
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs(append([]string{"--config", configPath}, tt.args...))
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("cmd.Execute() error = %v", err)
+			}
+
+			output := outBuf.Bytes()
+			if len(tt.args) > 0 && tt.args[0] == "--file" {
+				var err error
+				if output, err = os.ReadFile(tt.args[1]); err != nil {
+					t.Fatalf("failed to read output file: %v", err)
+				}
+			}
+			if got := json.Valid(output); got != tt.wantJSON {
+				t.Errorf("JSON output = %v, want %v\nOutput: %s", got, tt.wantJSON, output)
+			}
+		})
+	}
+}
+
+func TestDumpConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "dump-test.yaml")
+	markerFile := filepath.Join(tmpDir, "ran.txt")
+
+	config := fmt.Sprintf(`
+checks:
+  - name: "Check {{ .name }}"
+    type: command
+    command: "touch %s"
+    items:
+      - name: git
+`, markerFile)
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		args   []string
+		verify func(t *testing.T, output string)
+	}{
+		{
+			name: "yaml",
+			args: []string{"--dump-config", "--timeout", "5s"},
+			verify: func(t *testing.T, output string) {
+				var cfg types.Config
+				if err := yaml.Unmarshal([]byte(output), &cfg); err != nil {
+					t.Fatalf("failed to parse dumped config: %v\n%s", err, output)
+				}
+				if cfg.Timeout == nil || *cfg.Timeout != 5*time.Second {
+					t.Errorf("dumped timeout = %v, want 5s", cfg.Timeout)
+				}
+				if len(cfg.Checks) != 1 || cfg.Checks[0].Name != "Check git" {
+					t.Errorf("dumped checks = %+v, want expanded check 'Check git'", cfg.Checks)
+				}
+			},
+		},
+		{
+			name: "json",
+			args: []string{"--dump-config", "--output", "json"},
+			verify: func(t *testing.T, output string) {
+				var cfg map[string]interface{}
+				if err := json.Unmarshal([]byte(output), &cfg); err != nil {
+					t.Fatalf("failed to parse dumped config: %v\n%s", err, output)
+				}
+				if cfg["timeout"] != "30s" {
+					t.Errorf("dumped timeout = %v, want 30s", cfg["timeout"])
+				}
+				checks, _ := cfg["checks"].([]interface{})
+				if len(checks) != 1 || checks[0].(map[string]interface{})["name"] != "Check git" {
+					t.Errorf("dumped checks = %v, want expanded check 'Check git'", cfg["checks"])
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewRootCommand()
+			outBuf := new(bytes.Buffer)
+			cmd.SetOut(outBuf)
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs(append([]string{"--config", configPath}, tt.args...))
+
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("command execution failed: %v", err)
+			}
+			tt.verify(t, outBuf.String())
+
+			if _, err := os.Stat(markerFile); err == nil {
+				t.Error("checks were executed while dumping the configuration")
+			}
+		})
+	}
+}
+
+func TestSummaryOnly(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "summary-test.yaml")
+
+	config := `
+checks:
+  - name: passing-check
+    type: command
+    command: echo '{"status":"success","output":"ok"}'
+  - name: failing-check
+    type: command
+    command: echo '{"status":"failure","output":"broken"}'
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		wantErr string
+		verify  func(t *testing.T, output string)
+	}{
+		{
+			name:    "pretty",
+			format:  "pretty",
+			wantErr: ErrChecksFailure.Error(),
+			verify: func(t *testing.T, output string) {
+				if !strings.HasPrefix(output, "2 checks: 1 passed, 1 failed, 0 warnings, 0 errors (") {
+					t.Errorf("unexpected summary line: %q", output)
+				}
+				if strings.Contains(output, "passing-check") {
+					t.Errorf("summary should not contain per-check details: %q", output)
+				}
+			},
+		},
+		{
+			name:    "json",
+			format:  "json",
+			wantErr: ErrChecksFailure.Error(),
+			verify: func(t *testing.T, output string) {
+				var summary types.JSONSummaryOutput
+				if err := json.Unmarshal([]byte(output), &summary); err != nil {
+					t.Fatalf("failed to parse summary: %v\n%s", err, output)
+				}
+				if summary.Summary.Total != 2 || summary.Summary.Passed != 1 || summary.Summary.Failed != 1 {
+					t.Errorf("unexpected summary: %+v", summary.Summary)
+				}
+				if summary.Summary.Duration == "" {
+					t.Error("summary duration is empty")
+				}
+			},
+		},
+		{
+			name:    "html",
+			format:  "html",
+			wantErr: "--summary-only is not supported with the html output format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewRootCommand()
+			outBuf := new(bytes.Buffer)
+			cmd.SetOut(outBuf)
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs([]string{"--config", configPath, "--summary-only", "--output", tt.format})
+
+			err := cmd.Execute()
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Execute() error = %v, want %q", err, tt.wantErr)
+			}
+			if tt.verify != nil {
+				tt.verify(t, outBuf.String())
+			}
+		})
+	}
+}
+
+func TestCheckFilters(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "filter-test.yaml")
+
+	config := `
+checks:
+  - name: aws-s3
+    type: command
+    command: echo ok
+  - name: aws-iam
+    type: command
+    command: echo ok
+  - name: git-repo
+    type: command
+    command: echo ok
+  - name: filter-check
+    type: test.filter
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	checks.Register("test.filter", "Check used to test filters", func(item types.CheckItem) (types.CheckResult, error) {
+		return types.CheckResult{Name: item.Name, Type: item.Type, Status: types.Success}, nil
+	})
+	defer delete(checks.Registry, "test.filter")
+
+	tests := []struct {
+		name      string
+		args      []string
+		wantErr   error
+		wantNames []string
+	}{
+		{
+			name:      "no filters",
+			wantNames: []string{"aws-iam", "aws-s3", "filter-check", "git-repo"},
+		},
+		{
+			name:      "only type glob",
+			args:      []string{"--only-type", "test.*"},
+			wantNames: []string{"filter-check"},
+		},
+		{
+			name:      "only type combined with name filters",
+			args:      []string{"--only-type", "command,test.*", "--skip", "aws-*,filter-check"},
+			wantNames: []string{"git-repo"},
+		},
+		{
+			name:    "no matching types",
+			args:    []string{"--only-type", "cloud.*"},
+			wantErr: ErrNoChecks,
+		},
+		{
+			name:      "only glob",
+			args:      []string{"--only", "aws-*"},
+			wantNames: []string{"aws-iam", "aws-s3"},
+		},
+		{
+			name:      "only and skip",
+			args:      []string{"--only", "aws-*,git-repo", "--skip", "aws-iam"},
+			wantNames: []string{"aws-s3", "git-repo"},
+		},
+		{
+			name:    "no matching checks",
+			args:    []string{"--only", "aws-s4"},
+			wantErr: ErrNoChecks,
+		},
+		{
+			name:      "no matching checks allowed",
+			args:      []string{"--only", "aws-s4", "--allow-empty"},
+			wantNames: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewRootCommand()
+			outBuf := new(bytes.Buffer)
+			cmd.SetOut(outBuf)
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs(append([]string{"--config", configPath, "--output", "json"}, tt.args...))
+
+			err := cmd.Execute()
+			if err != tt.wantErr {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantNames == nil {
+				return
+			}
+
+			var output types.JSONOutput
+			if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+				t.Fatalf("failed to parse output: %v\n%s", err, outBuf.String())
+			}
+			names := []string{}
+			for _, result := range output.Results {
+				names = append(names, result.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantNames, ",") {
+				t.Errorf("ran checks %v, want %v", names, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestOnlyFailedFrom(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "only-failed-test.yaml")
+	config := `
+checks:
+  - name: api
+    type: command
+    command: echo ok
+  - name: db
+    type: command
+    command: echo ok
+  - name: cache
+    type: command
+    command: echo ok
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	previousPath := filepath.Join(tmpDir, "results.json")
+	previous := `{"results": [
+  {"name": "api", "type": "command", "status": "Success", "output": "ok"},
+  {"name": "db", "type": "command", "status": "Failure", "output": "down"},
+  {"name": "cache", "type": "command", "status": "Skipped", "output": "disabled"},
+  {"name": "queue", "type": "command", "status": "Error", "output": "", "error": "timeout"}
+], "metadata": {}}`
+	if err := os.WriteFile(previousPath, []byte(previous), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs([]string{"--config", configPath, "--output", "json", "--only-failed-from", previousPath})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	var output types.JSONOutput
+	if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, outBuf.String())
+	}
+	if len(output.Results) != 1 || output.Results[0].Name != "db" {
+		t.Errorf("ran checks %+v, want only db", output.Results)
+	}
+	if !strings.Contains(errBuf.String(), "[WARN] Check 'queue' did not succeed in '"+previousPath+"' but is not in the configuration") {
+		t.Errorf("missing warning about the unknown check, got:\n%s", errBuf.String())
+	}
+
+	cmd = NewRootCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--only-failed-from", filepath.Join(tmpDir, "missing.json")})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "filter error") {
+		t.Errorf("Execute() error = %v, want a filter error", err)
+	}
+}
+
+func TestJSONGrouped(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "json-grouped-test.yaml")
+	config := `
+checks:
+  - name: build
+    type: command
+    command: echo ok
+  - name: native-check
+    type: test.grouped
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	checks.Register("test.grouped", "Check used to test grouped output", func(item types.CheckItem) (types.CheckResult, error) {
+		return types.CheckResult{Name: item.Name, Type: item.Type, Status: types.Success}, nil
+	})
+	defer delete(checks.Registry, "test.grouped")
+	outputDir := filepath.Join(tmpDir, "artifacts")
+
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--output", "json", "--json-grouped", "--output-dir", outputDir})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	var output types.JSONGroupedOutput
+	if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, outBuf.String())
+	}
+	if len(output.Results) != 2 || len(output.Results["command"]) != 1 || len(output.Results["test"]) != 1 {
+		t.Errorf("got groups %+v, want command and test with one result each", output.Results)
+	}
+
+	// The results.json of the output directory stays flat
+	if _, err := readResults(filepath.Join(outputDir, "results.json")); err != nil {
+		t.Errorf("failed to read results.json of the output directory: %v", err)
 	}
 }
 
-func TestConcurrentExecution(t *testing.T) {
-	// Create a temporary directory for test files
+func TestSecretParams(t *testing.T) {
 	tmpDir := t.TempDir()
-	configPath := filepath.Join(tmpDir, "concurrent-test.yaml")
-
-	// Create a config with multiple checks that have measurable execution times
+	configPath := filepath.Join(tmpDir, "secret-test.yaml")
 	config := `
 checks:
-  - name: concurrent-check-1
-    type: command
-    command: "sleep 0.5 && echo '{\"status\":\"success\",\"output\":\"check 1\"}'"
-  - name: concurrent-check-2
+  - name: deploy
     type: command
-    command: "sleep 0.5 && echo '{\"status\":\"success\",\"output\":\"check 2\"}'"
-  - name: concurrent-check-3
-    type: command
-    command: "sleep 0.5 && echo '{\"status\":\"success\",\"output\":\"check 3\"}'"
+    command: echo "token is $API_TOKEN in $REGION"
+    env:
+      API_TOKEN: s3cr3t
+      REGION: eu-west-1
+    secret_params: [API_TOKEN]
+  - name: database
+    type: test.secret
+    parameters:
+      user: app
+      password: hunter2
 `
-
-	err := os.WriteFile(configPath, []byte(config), 0644)
-	if err != nil {
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
 		t.Fatalf("failed to write test config: %v", err)
 	}
+	checks.Register("test.secret", "Check used to test secret parameters", func(item types.CheckItem) (types.CheckResult, error) {
+		return types.CheckResult{Name: item.Name, Type: item.Type, Status: types.Failure,
+			Output: "login as " + item.Parameters["user"] + " with " + item.Parameters["password"] + " failed"}, nil
+	}, checks.Parameter{Name: "user", Type: checks.ParamString}, checks.Parameter{Name: "password", Type: checks.ParamString, Secret: true})
+	defer delete(checks.Registry, "test.secret")
 
-	// Create a command with a buffer for output
+	// The dumped configuration masks the values of secrets
 	cmd := NewRootCommand()
 	outBuf := new(bytes.Buffer)
 	cmd.SetOut(outBuf)
-	cmd.SetErr(outBuf)
-
-	// Set command line arguments with a timeout that would fail if checks run sequentially
-	cmd.SetArgs([]string{
-		"--config", configPath,
-		"--verbose",
-		"--timeout", "1s", // This should be enough for concurrent execution but not for sequential
-	})
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--dump-config"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, secret := range []string{"s3cr3t", "hunter2"} {
+		if strings.Contains(outBuf.String(), secret) {
+			t.Errorf("dumped configuration contains secret %q:\n%s", secret, outBuf.String())
+		}
+	}
+	for _, value := range []string{"eu-west-1", "user: app"} {
+		if !strings.Contains(outBuf.String(), value) {
+			t.Errorf("dumped configuration is missing %q:\n%s", value, outBuf.String())
+		}
+	}
 
-	// Record start time
-	start := time.Now()
+	// The results mask them too
+	cmd = NewRootCommand()
+	outBuf = new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--output", "json"})
+	if err := cmd.Execute(); err != ErrChecksFailure {
+		t.Fatalf("Execute() error = %v, want %v", err, ErrChecksFailure)
+	}
+	var output types.JSONOutput
+	if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, outBuf.String())
+	}
+	want := map[string]string{
+		"deploy":   "token is *** in eu-west-1",
+		"database": "login as app with *** failed",
+	}
+	for _, result := range output.Results {
+		if strings.TrimSpace(result.Output) != want[result.Name] {
+			t.Errorf("output of %s = %q, want %q", result.Name, result.Output, want[result.Name])
+		}
+	}
+}
 
-	// Execute the command
-	err = cmd.Execute()
-	if err != nil {
-		t.Errorf("command execution failed: %v", err)
-		return
+func TestSubCheckSecrets(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "sub-check-secrets.yaml")
+	config := `
+checks:
+  - name: referenced
+    type: command
+    command: 'echo "{\"status\":\"failure\",\"output\":\"key $KEY\"}"'
+    env:
+      KEY: abc123
+    redact: [KEY]
+  - name: group
+    type: logic.all_of
+    checks:
+      - name: inline
+        type: command
+        command: 'echo "{\"status\":\"failure\",\"output\":\"pw $PW\"}"'
+        env:
+          PW: hunter2
+        redact: [PW]
+      - name: secret
+        type: command
+        command: 'echo "{\"status\":\"failure\",\"output\":\"token $TOKEN\"}"'
+        env:
+          TOKEN: s3cr3t
+        secret_params: [TOKEN]
+      - name: referenced
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
 	}
 
-	// Check execution time
-	executionTime := time.Since(start)
-	if executionTime >= 1500*time.Millisecond {
-		t.Errorf("checks appear to run sequentially, took %v", executionTime)
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--output", "json"})
+	if err := cmd.Execute(); err != ErrChecksFailure {
+		t.Fatalf("Execute() error = %v, want %v", err, ErrChecksFailure)
+	}
+	var output types.JSONOutput
+	if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, outBuf.String())
 	}
 
-	// Check output for all checks
-	output := outBuf.String()
-	for i := 1; i <= 3; i++ {
-		checkName := fmt.Sprintf("concurrent-check-%d", i)
-		if !strings.Contains(output, checkName) {
-			t.Errorf("output missing check %s", checkName)
+	// The output of the logic check includes the outputs of its sub-checks, with the
+	// values they consider sensitive masked
+	for _, result := range output.Results {
+		if result.Name != "group" {
+			continue
 		}
-		if !strings.Contains(output, fmt.Sprintf("check %d", i)) {
-			t.Errorf("output missing result for check %d", i)
+		for _, want := range []string{"- inline: Failure (pw ***)", "- secret: Failure (token ***)", "- referenced: Failure (key ***)"} {
+			if !strings.Contains(result.Output, want) {
+				t.Errorf("output of group = %q, want it to contain %q", result.Output, want)
+			}
 		}
+		return
 	}
+	t.Fatalf("no result for group in %s", outBuf.String())
 }
 
 func TestCommandExecution(t *testing.T) {
@@ -481,6 +1896,9 @@ checks:
 					if len(output.Results) != 1 || output.Results[0].Name != "test-check" {
 						t.Errorf("Expected one result with name 'test-check', got: %+v", output.Results)
 					}
+					if len(output.Results) == 1 && (output.Results[0].StartedAt == nil || output.Results[0].FinishedAt == nil) {
+						t.Errorf("Expected start and finish timestamps in result, got: %+v", output.Results[0])
+					}
 
 					// Verify metadata
 					if output.Metadata.Version == "" {
@@ -702,3 +2120,493 @@ checks:
 		})
 	}
 }
+
+func TestOutputDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "checks.yaml")
+	outputDir := filepath.Join(tmpDir, "artifacts", "checks")
+
+	configContent := `
+checks:
+  - name: test-check
+    type: command
+    command: echo "test output"
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	stdout := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(stdout)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--output-dir", outputDir, "--report-title", "Nightly"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("cmd.Execute() error = %v", err)
+	}
+
+	// The regular output is still written to stdout
+	if !strings.Contains(stdout.String(), "test-check") {
+		t.Errorf("Expected pretty output in stdout, got: %s", stdout.String())
+	}
+
+	readFile := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(outputDir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		return string(content)
+	}
+
+	var output types.JSONOutput
+	if err := json.Unmarshal([]byte(readFile("results.json")), &output); err != nil {
+		t.Fatalf("Failed to parse results.json: %v", err)
+	}
+	if len(output.Results) != 1 || output.Results[0].Name != "test-check" {
+		t.Errorf("Expected one result with name 'test-check', got: %+v", output.Results)
+	}
+	if output.Metadata.Title != "Nightly" {
+		t.Errorf("Expected title 'Nightly' in metadata, got: %q", output.Metadata.Title)
+	}
+
+	html := readFile("results.html")
+	if !strings.Contains(html, "<!DOCTYPE html>") || !strings.Contains(html, "test-check") {
+		t.Errorf("Expected HTML report with check name, got: %s", html)
+	}
+	if !strings.Contains(html, "Nightly") || !strings.Contains(html, output.Metadata.DateTime) {
+		t.Errorf("Expected HTML report with the same metadata as the JSON report, got: %s", html)
+	}
+
+	if pretty := readFile("results.txt"); !strings.Contains(pretty, "test-check") {
+		t.Errorf("Expected pretty output in results.txt, got: %s", pretty)
+	}
+}
+
+func TestTUI(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "checks.yaml")
+	configContent := `
+checks:
+  - name: test-check
+    type: command
+    command: echo "test output"
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantErr    string
+		wantStdout string
+	}{
+		{
+			name:       "falls back to pretty output without a terminal",
+			args:       []string{"--tui"},
+			wantStdout: "test-check",
+		},
+		{
+			name:    "cannot be combined with json output",
+			args:    []string{"--tui", "--output", "json"},
+			wantErr: "--tui is not supported with the json output format",
+		},
+		{
+			name:    "cannot be combined with summary only",
+			args:    []string{"--tui", "--summary-only"},
+			wantErr: "--tui cannot be combined with --summary-only",
+		},
+		{
+			name:    "cannot be combined with an output file",
+			args:    []string{"--tui", "--file", filepath.Join(tmpDir, "results.txt")},
+			wantErr: "--tui cannot be combined with --file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			cmd := NewRootCommand()
+			cmd.SetOut(stdout)
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetIn(strings.NewReader(""))
+			cmd.SetArgs(append([]string{"--config", configPath}, tt.args...))
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Execute() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if !strings.Contains(stdout.String(), tt.wantStdout) {
+				t.Errorf("Expected %q in stdout, got: %s", tt.wantStdout, stdout.String())
+			}
+		})
+	}
+}
+
+func TestMultiResultChecks(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "multi-test.yaml")
+	config := `
+checks:
+  - name: deployments
+    id: deploys
+    type: test.multi
+    remediation: Scale up the deployment
+  - name: other
+    type: command
+    command: echo ok
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	runs := 0
+	checks.RegisterMulti("test.multi", "Check used to test multiple results", func(item types.CheckItem) ([]types.CheckResult, error) {
+		runs++
+		return []types.CheckResult{
+			{Name: "web", Status: types.Success},
+			{Name: "worker", Status: types.Failure, Output: "0/2 replicas ready"},
+		}, nil
+	})
+	defer delete(checks.Registry, "test.multi")
+
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--output", "json", "--rerun-failed", "1", "--sort", "config"})
+	if err := cmd.Execute(); err != ErrChecksFailure {
+		t.Fatalf("Execute() error = %v, want %v", err, ErrChecksFailure)
+	}
+	if runs != 2 {
+		t.Errorf("check ran %d times, want 2 (the failing result reruns the check)", runs)
+	}
+
+	var output types.JSONOutput
+	if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, outBuf.String())
+	}
+	// The results replace those of the first run, and inherit the settings of their check
+	want := []types.CheckResult{
+		{ID: "deploys-web", Name: "deployments: web", Type: "test.multi", Status: types.Success, Reruns: 1, Parent: "deployments"},
+		{ID: "deploys-worker", Name: "deployments: worker", Type: "test.multi", Status: types.Failure, Output: "0/2 replicas ready",
+			Remediation: "Scale up the deployment", Reruns: 1, Parent: "deployments"},
+	}
+	if len(output.Results) != 3 {
+		t.Fatalf("got %d results, want 3: %+v", len(output.Results), output.Results)
+	}
+	for i, w := range want {
+		got := output.Results[i]
+		got.StartedAt, got.FinishedAt = nil, nil
+		if !reflect.DeepEqual(got, w) {
+			t.Errorf("result %d = %+v, want %+v", i, got, w)
+		}
+	}
+	if output.Results[2].Name != "other" {
+		t.Errorf("last result = %q, want other", output.Results[2].Name)
+	}
+}
+
+func TestConfigCheck(t *testing.T) {
+	checks.Register("test.typed", "Check used to test parameter validation", func(item types.CheckItem) (types.CheckResult, error) {
+		t.Errorf("check %q ran, but --config-check must not run checks", item.Name)
+		return types.CheckResult{Name: item.Name, Type: item.Type, Status: types.Success}, nil
+	},
+		checks.Parameter{Name: "host", Type: checks.ParamString, Required: true, Pattern: `[a-z0-9.-]+`},
+		checks.Parameter{Name: "port", Type: checks.ParamInt, Default: "443"},
+		checks.Parameter{Name: "tls", Type: checks.ParamBool, Default: "false"},
+		checks.Parameter{Name: "interval", Type: checks.ParamDuration},
+		checks.Parameter{Name: "mode", Type: checks.ParamString, Enum: []string{"fast", "thorough"}},
+		checks.Parameter{Name: "token", Type: checks.ParamString, Secret: true},
+	)
+	defer delete(checks.Registry, "test.typed")
+
+	tests := []struct {
+		name       string
+		config     string
+		wantErr    bool
+		wantOutput []string
+		wantStderr []string
+	}{
+		{
+			name: "valid parameters are coerced and defaults applied",
+			config: `
+checks:
+  - name: api
+    type: test.typed
+    parameters:
+      host: api.example.com
+      tls: "1"
+      interval: 90s
+      mode: fast
+      token: s3cr3t
+      hots: typo
+  - name: shell
+    type: command
+    command: echo $ANYTHING
+    env:
+      ANYTHING: goes
+`,
+			wantOutput: []string{"host: api.example.com", "port: \"443\"", "tls: \"true\"", "interval: 1m30s", "token: '***'"},
+			wantStderr: []string{`[WARN] Check 'api': parameter "hots" is not a parameter of test.typed and is ignored`},
+		},
+		{
+			name: "invalid parameters of checks and sub-checks",
+			config: `
+checks:
+  - name: api
+    type: test.typed
+    parameters:
+      host: API.example.com
+      port: https
+      mode: slow
+  - name: either
+    type: logic.any_of
+    checks:
+      - name: missing-host
+        type: test.typed
+      - name: unknown
+        type: test.unknown
+`,
+			wantErr: true,
+			wantStderr: []string{
+				"[ERROR] Check 'api': Invalid value for 'host' parameter: API.example.com does not match [a-z0-9.-]+",
+				"[ERROR] Check 'api': Invalid value for 'port' parameter: https is not an integer",
+				"[ERROR] Check 'api': Invalid value for 'mode' parameter: slow (allowed values: fast, thorough)",
+				"[ERROR] Check 'missing-host': host parameter is required",
+				`[ERROR] Check 'unknown': unknown check type "test.unknown"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config-check.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.config), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+
+			cmd := NewRootCommand()
+			outBuf := new(bytes.Buffer)
+			errBuf := new(bytes.Buffer)
+			cmd.SetOut(outBuf)
+			cmd.SetErr(errBuf)
+			cmd.SetArgs([]string{"--config", configPath, "--config-check"})
+			err := cmd.Execute()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v\nstderr: %s", err, tt.wantErr, errBuf.String())
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(outBuf.String(), want) {
+					t.Errorf("output does not contain %q:\n%s", want, outBuf.String())
+				}
+			}
+			if tt.wantErr && outBuf.Len() > 0 {
+				t.Errorf("invalid configuration was printed:\n%s", outBuf.String())
+			}
+			for _, want := range tt.wantStderr {
+				if !strings.Contains(errBuf.String(), want) {
+					t.Errorf("stderr does not contain %q:\n%s", want, errBuf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestTimeoutSummary(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "timeout-summary.yaml")
+	// The checks' own timeouts exceed the run's, so the run times out while the first
+	// check is running and before the second one starts
+	config := `
+checks:
+  - name: first
+    type: command
+    command: sleep 5
+    timeout: 10s
+  - name: second
+    type: command
+    command: sleep 5
+    timeout: 10s
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs([]string{"--config", configPath, "--no-parallel", "--timeout", "200ms", "--output", "json"})
+	if err := cmd.Execute(); err != context.DeadlineExceeded {
+		t.Fatalf("Execute() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if want := "[ERROR] 2 checks timed out: 1 running, 1 never started (second)"; !strings.Contains(errBuf.String(), want) {
+		t.Errorf("stderr does not contain %q:\n%s", want, errBuf.String())
+	}
+	var output types.JSONOutput
+	if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, outBuf.String())
+	}
+	want := map[string]string{
+		"first":  "check execution timed out",
+		"second": "check did not start before the run timed out",
+	}
+	for _, result := range output.Results {
+		if result.Output != want[result.Name] {
+			t.Errorf("check %q: output %q, want %q", result.Name, result.Output, want[result.Name])
+		}
+	}
+}
+
+func TestParametersFile(t *testing.T) {
+	checks.Register("test.region", "Check reporting its region", func(item types.CheckItem) (types.CheckResult, error) {
+		return types.CheckResult{Name: item.Name, Type: item.Type, Status: types.Success, Output: "region " + item.Parameters["region"]}, nil
+	},
+		checks.Parameter{Name: "region", Type: checks.ParamString, Required: true},
+	)
+	defer delete(checks.Registry, "test.region")
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "checks.yaml")
+	configYAML := `
+checks:
+  - name: default
+    type: test.region
+  - name: pinned
+    type: test.region
+    parameters:
+      region: ap-south-1
+`
+	if err := os.WriteFile(configPath, []byte(configYAML), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	paramsPath := filepath.Join(tmpDir, "prod.yaml")
+	if err := os.WriteFile(paramsPath, []byte("parameters:\n  region: eu-west-1\n"), 0644); err != nil {
+		t.Fatalf("failed to write parameters file: %v", err)
+	}
+
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs([]string{"--config", configPath, "--parameters-file", paramsPath, "--output", "json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v\nstderr: %s", err, errBuf.String())
+	}
+	for _, want := range []string{`"output": "region eu-west-1"`, `"output": "region ap-south-1"`} {
+		if !strings.Contains(outBuf.String(), want) {
+			t.Errorf("output does not contain %s:\n%s", want, outBuf.String())
+		}
+	}
+
+	// A missing parameters file is a configuration error
+	cmd = NewRootCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--parameters-file", filepath.Join(tmpDir, "missing.yaml")})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "configuration error") {
+		t.Errorf("Execute() error = %v, want a configuration error", err)
+	}
+}
+
+func TestMaxFailures(t *testing.T) {
+	checks.Register("test.max_failures", "Check with a configurable status and delay", func(item types.CheckItem) (types.CheckResult, error) {
+		delay, _ := time.ParseDuration(item.Parameters["delay"])
+		time.Sleep(delay)
+		return types.CheckResult{Name: item.Name, Type: item.Type, Status: types.CheckStatus(item.Parameters["status"])}, nil
+	},
+		checks.Parameter{Name: "status", Type: checks.ParamString},
+		checks.Parameter{Name: "delay", Type: checks.ParamDuration},
+	)
+	defer delete(checks.Registry, "test.max_failures")
+
+	configPath := filepath.Join(t.TempDir(), "max-failures.yaml")
+	config := `
+checks:
+  - name: fail-a
+    type: test.max_failures
+    parameters:
+      status: Failure
+  - name: fail-b
+    type: test.max_failures
+    parameters:
+      status: Error
+      delay: 200ms
+  - name: informational
+    type: test.max_failures
+    parameters:
+      status: Failure
+    informational: true
+  - name: slow-c
+    type: test.max_failures
+    parameters:
+      status: Success
+      delay: 5s
+  - name: slow-d
+    type: test.max_failures
+    parameters:
+      status: Failure
+      delay: 5s
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cmd := NewRootCommand()
+	outBuf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs([]string{"--config", configPath, "--max-failures", "2", "--output", "json"})
+	start := time.Now()
+	if err := cmd.Execute(); err != ErrChecksFailure {
+		t.Fatalf("Execute() error = %v, want %v", err, ErrChecksFailure)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("run took %v, want it to be aborted before the slow checks complete", elapsed)
+	}
+
+	var output types.JSONOutput
+	if err := json.Unmarshal(outBuf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\nOutput: %s", err, outBuf.String())
+	}
+	// The informational check completes first, but is not counted, so the slower
+	// fail-b is still collected
+	want := map[string]types.CheckStatus{
+		"fail-a":        types.Failure,
+		"fail-b":        types.Error,
+		"informational": types.Failure,
+		"slow-c":        types.Skipped,
+		"slow-d":        types.Skipped,
+	}
+	for _, result := range output.Results {
+		if result.Status != want[result.Name] {
+			t.Errorf("result %q status = %s, want %s", result.Name, result.Status, want[result.Name])
+		}
+		if result.Status == types.Skipped && !strings.Contains(result.Output, "the run was aborted") {
+			t.Errorf("result %q output = %q, want the run to be reported as aborted", result.Name, result.Output)
+		}
+	}
+	if !strings.Contains(errBuf.String(), "[ERROR] Run aborted after 2 failures (--max-failures)") {
+		t.Errorf("stderr does not report the aborted run:\n%s", errBuf.String())
+	}
+
+	// A negative limit is rejected
+	cmd = NewRootCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--config", configPath, "--max-failures", "-1"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid number of failures: -1") {
+		t.Errorf("Execute() error = %v, want an invalid number of failures", err)
+	}
+}
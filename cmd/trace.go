@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// writeSlowestChecks writes the checks sorted by duration, slowest first, with the time
+// they started relative to the start of the run. Checks without timestamps, such as
+// those that never started before the run timed out, are left out.
+func writeSlowestChecks(w io.Writer, results []types.CheckResult, runStart time.Time) {
+	timed := make([]types.CheckResult, 0, len(results))
+	for _, result := range results {
+		if result.StartedAt != nil && result.FinishedAt != nil {
+			timed = append(timed, result)
+		}
+	}
+	if len(timed) == 0 {
+		return
+	}
+	sort.SliceStable(timed, func(i, j int) bool {
+		return checkDuration(timed[i]) > checkDuration(timed[j])
+	})
+
+	fmt.Fprintln(w, "Slowest checks:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, result := range timed {
+		fmt.Fprintf(tw, "  %s\tstarted +%v\ttook %v\n", result.Name,
+			result.StartedAt.Sub(runStart).Round(time.Millisecond), checkDuration(result).Round(time.Millisecond))
+	}
+	tw.Flush()
+}
+
+// checkDuration returns how long a check ran, based on its timestamps
+func checkDuration(result types.CheckResult) time.Duration {
+	return result.FinishedAt.Sub(*result.StartedAt)
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+func TestWriteSlowestChecks(t *testing.T) {
+	runStart := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(offset time.Duration) *time.Time {
+		ts := runStart.Add(offset)
+		return &ts
+	}
+	results := []types.CheckResult{
+		{Name: "fast", StartedAt: at(0), FinishedAt: at(250 * time.Millisecond)},
+		{Name: "never-started", Status: types.Error},
+		{Name: "slow-database", StartedAt: at(10 * time.Millisecond), FinishedAt: at(2 * time.Minute)},
+		{Name: "medium", StartedAt: at(2 * time.Minute), FinishedAt: at(2*time.Minute + 3*time.Second)},
+	}
+
+	var buf bytes.Buffer
+	writeSlowestChecks(&buf, results, runStart)
+
+	want := "Slowest checks:\n" +
+		"  slow-database  started +10ms  took 1m59.99s\n" +
+		"  medium         started +2m0s  took 3s\n" +
+		"  fast           started +0s    took 250ms\n"
+	if buf.String() != want {
+		t.Errorf("writeSlowestChecks() = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	writeSlowestChecks(&buf, []types.CheckResult{{Name: "never-started"}}, runStart)
+	if buf.Len() != 0 {
+		t.Errorf("writeSlowestChecks() = %q, want no output", buf.String())
+	}
+}
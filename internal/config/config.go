@@ -3,19 +3,51 @@ package config
 import (
 	"bytes"
 	"fmt"
+	"maps"
+	"net/url"
 	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 	"text/template"
+	"text/template/parse"
+	"time"
 
+	"github.com/seastar-consulting/checkers/checks"
 	"github.com/seastar-consulting/checkers/types"
 
 	"github.com/seastar-consulting/checkers/internal/errors"
-	"gopkg.in/yaml.v3"
+	"github.com/seastar-consulting/checkers/internal/executor"
+	"github.com/seastar-consulting/checkers/internal/processor"
+	"github.com/seastar-consulting/checkers/internal/redact"
 )
 
+// templateFuncs are the helper functions available in check name templates
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+	"default": func(def, value string) string {
+		if value == "" {
+			return def
+		}
+		return value
+	},
+}
+
+// itemTimeoutKey is the reserved item key setting the timeout of the check generated for
+// the item. It is available to templates but not passed as a parameter. It is not named
+// timeout, which is a parameter of some check types and commands.
+const itemTimeoutKey = "check_timeout"
+
 // Manager handles configuration loading and validation
 type Manager struct {
-	configPath string
+	configPath     string
+	format         string
+	strictParams   bool
+	parametersFile string
 }
 
 // NewManager creates a new configuration manager
@@ -25,6 +57,24 @@ func NewManager(configPath string) *Manager {
 	}
 }
 
+// SetFormat selects the format of the configuration file, which is otherwise determined
+// by its extension
+func (m *Manager) SetFormat(format string) {
+	m.format = format
+}
+
+// SetStrictParams enables rejecting item parameters of command checks that none of the
+// check's templates use, which are most likely typos
+func (m *Manager) SetStrictParams(strict bool) {
+	m.strictParams = strict
+}
+
+// SetParametersFile sets a YAML file of parameter values that are filled into the checks
+// which do not set them, e.g. secrets or the values of an environment
+func (m *Manager) SetParametersFile(path string) {
+	m.parametersFile = path
+}
+
 // Load loads and validates the configuration
 func (m *Manager) Load() (*types.Config, error) {
 	data, err := os.ReadFile(m.configPath)
@@ -32,46 +82,120 @@ func (m *Manager) Load() (*types.Config, error) {
 		return nil, errors.NewConfigError("file", err)
 	}
 
+	parser, err := parserFor(m.format, m.configPath)
+	if err != nil {
+		return nil, errors.NewConfigError("format", err)
+	}
+
 	var config types.Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
+	if err := parser.Parse(data, &config); err != nil {
 		return nil, errors.NewConfigError("parse", err)
 	}
 
+	// Matrices are expanded into items first, so every combination is validated like an item
+	isMatrix := make([]bool, len(config.Checks))
+	for i := range config.Checks {
+		isMatrix[i] = len(config.Checks[i].Matrix) > 0
+		if err := expandMatrix(&config.Checks[i]); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := m.validate(&config); err != nil {
 		return nil, err
 	}
 
 	// Expand checks with multiple items
 	var expandedChecks []types.CheckItem
-	for _, check := range config.Checks {
+	var combinations []int
+	for checkIndex, check := range config.Checks {
 		if len(check.Items) > 0 {
 			// For each item in the list, create a new check
 			for i, item := range check.Items {
 				// Create a copy of the check
-				newCheck := types.CheckItem{
-					Type:        check.Type,
-					Description: check.Description,
-					Command:     check.Command,
-					Parameters:  item,
-				}
+				newCheck := check
+				newCheck.Items = nil
 
 				// If the name contains a template, render it with the item parameters
 				if isTemplate(check.Name) {
-					tmpl, err := template.New("check-name").Option("missingkey=error").Parse(check.Name)
+					name, err := renderTemplate("check-name", check.Name, item)
 					if err != nil {
-						return nil, errors.NewConfigError("check.name", fmt.Errorf("invalid template in check name: %v", err))
-					}
-
-					var buf bytes.Buffer
-					if err := tmpl.Execute(&buf, item); err != nil {
 						return nil, errors.NewConfigError("check.name", fmt.Errorf("failed to render check name template: %v", err))
 					}
-					newCheck.Name = buf.String()
+					newCheck.Name = name
 				} else {
 					// Use the default index-based naming
 					newCheck.Name = fmt.Sprintf("%s: %d", check.Name, i+1)
 				}
 
+				// Render the ID like the name, so every item keeps a unique ID
+				if isTemplate(check.ID) {
+					id, err := renderTemplate("check-id", check.ID, item)
+					if err != nil {
+						return nil, errors.NewConfigError("check.id", fmt.Errorf("failed to render ID template for check %q: %v", newCheck.Name, err))
+					}
+					newCheck.ID = id
+				} else if check.ID != "" {
+					newCheck.ID = fmt.Sprintf("%s-%d", check.ID, i+1)
+				}
+
+				// Render the command with the item parameters
+				command, err := renderTemplate("check-command", check.Command, item)
+				if err != nil {
+					return nil, errors.NewConfigError("check.command",
+						fmt.Errorf("failed to render command template for check %q: %v", newCheck.Name, err))
+				}
+				newCheck.Command = command
+
+				// Render the output file with the item parameters
+				outputFile, err := renderTemplate("check-output-file", check.OutputFile, item)
+				if err != nil {
+					return nil, errors.NewConfigError("check.output_file",
+						fmt.Errorf("failed to render output file template for check %q: %v", newCheck.Name, err))
+				}
+				newCheck.OutputFile = outputFile
+
+				// Render shared parameters with the item parameters, item values take precedence
+				params := make(map[string]string, len(check.Parameters)+len(item))
+				for key, value := range check.Parameters {
+					rendered, err := renderTemplate("check-parameter", value, item)
+					if err != nil {
+						return nil, errors.NewConfigError("check.parameters",
+							fmt.Errorf("failed to render parameter %q template for check %q: %v", key, newCheck.Name, err))
+					}
+					params[key] = rendered
+				}
+				for key, value := range item {
+					if key == itemTimeoutKey {
+						continue
+					}
+					params[key] = value
+				}
+				newCheck.Parameters = params
+
+				// Items may override the timeout of the check, which was validated before
+				if value, ok := item[itemTimeoutKey]; ok {
+					timeout, _ := time.ParseDuration(value)
+					newCheck.Timeout = &timeout
+				}
+
+				// Render environment variables with the item parameters
+				if check.Env != nil {
+					env := make(map[string]string, len(check.Env))
+					for key, value := range check.Env {
+						rendered, err := renderTemplate("check-env", value, item)
+						if err != nil {
+							return nil, errors.NewConfigError("check.env",
+								fmt.Errorf("failed to render env %q template for check %q: %v", key, newCheck.Name, err))
+						}
+						env[key] = rendered
+					}
+					newCheck.Env = env
+				}
+
+				if isMatrix[checkIndex] {
+					combinations = append(combinations, len(expandedChecks))
+				}
 				expandedChecks = append(expandedChecks, newCheck)
 			}
 		} else {
@@ -79,76 +203,644 @@ func (m *Manager) Load() (*types.Config, error) {
 		}
 	}
 
+	// Replace parameter values referencing files with their contents, relative paths
+	// are resolved against the directory of the configuration file
+	for i := range expandedChecks {
+		if err := resolveFileParams(&expandedChecks[i], filepath.Dir(m.configPath)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Values of the parameters file only fill in what the configuration leaves out
+	if m.parametersFile != "" {
+		values, err := loadParameterValues(m.parametersFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := applyParameterValues(expandedChecks, values); err != nil {
+			return nil, err
+		}
+	}
+
+	// Nobody wrote the combinations of a matrix out, so each of them has to be valid for
+	// its check type rather than failing when run. Unknown types fail when run.
+	for _, i := range combinations {
+		check := expandedChecks[i]
+		if _, err := checks.Get(check.Type); err != nil {
+			continue
+		}
+		if _, _, errs := checks.ResolveParameters(check); len(errs) > 0 {
+			return nil, errors.NewConfigError("check.matrix",
+				fmt.Errorf("matrix combination %q of type %s is invalid: %v", check.Name, check.Type, errs[0]))
+		}
+	}
+
+	// IDs identify checks across runs and must therefore be unique
+	ids := make(map[string]string, len(expandedChecks))
+	for _, check := range expandedChecks {
+		if check.ID == "" {
+			continue
+		}
+		if other, ok := ids[check.ID]; ok {
+			return nil, errors.NewConfigError("check.id", fmt.Errorf("checks %q and %q have the same ID %q", other, check.Name, check.ID))
+		}
+		ids[check.ID] = check.Name
+	}
+
+	// Resolve sub-checks referencing other checks by name
+	checksByName := make(map[string]types.CheckItem, len(expandedChecks))
+	for _, check := range expandedChecks {
+		checksByName[check.Name] = check
+	}
+	for i, check := range expandedChecks {
+		resolved, err := resolveReferences(check, checksByName, map[string]bool{})
+		if err != nil {
+			return nil, err
+		}
+		expandedChecks[i] = resolved
+	}
+
+	// Apply the default shell options to commands that do not set their own
+	if config.ShellOptions != "" {
+		for i := range expandedChecks {
+			applyShellOptions(&expandedChecks[i], config.ShellOptions)
+		}
+	}
+
 	config.Checks = expandedChecks
 	return &config, nil
 }
 
+// expandMatrix replaces the matrix of a check with an item for every combination of its
+// values. Combinations are ordered by the sorted parameter names, the values of the last
+// parameter varying fastest, and values in the order they are listed, so the generated
+// items and their names are stable.
+func expandMatrix(check *types.CheckItem) error {
+	if len(check.Matrix) == 0 {
+		return nil
+	}
+	if len(check.Items) > 0 {
+		return errors.NewConfigError("check.matrix",
+			fmt.Errorf("check %q cannot have both 'items' and 'matrix' fields", check.Name))
+	}
+
+	keys := make([]string, 0, len(check.Matrix))
+	for key, values := range check.Matrix {
+		if len(values) == 0 {
+			return errors.NewConfigError("check.matrix",
+				fmt.Errorf("matrix parameter %q of check %q has no values", key, check.Name))
+		}
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	items := []map[string]string{{}}
+	for _, key := range keys {
+		combined := make([]map[string]string, 0, len(items)*len(check.Matrix[key]))
+		for _, item := range items {
+			for _, value := range check.Matrix[key] {
+				next := make(map[string]string, len(item)+1)
+				for k, v := range item {
+					next[k] = v
+				}
+				next[key] = value
+				combined = append(combined, next)
+			}
+		}
+		items = combined
+	}
+
+	check.Items = items
+	check.Matrix = nil
+	return nil
+}
+
+// fileParamPrefixes start the parameter values that reference a file. Other values
+// starting with @, e.g. the cron schedule @hourly, are literal values.
+var fileParamPrefixes = []string{"@/", "@./", "@../"}
+
+// isFileParam reports whether a parameter value references a file, like @/path or
+// @./path, or is such a reference escaped as @@/path
+func isFileParam(value string) bool {
+	for _, prefix := range fileParamPrefixes {
+		if strings.HasPrefix(value, prefix) || strings.HasPrefix(value, "@"+prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveFileParams replaces the parameters of a check and its sub-checks whose value
+// references a file, like @/path or @./path, with the contents of the file, without
+// trailing newlines. A reference escaped as @@/path is kept as the literal value @/path.
+func resolveFileParams(check *types.CheckItem, baseDir string) error {
+	var params map[string]string
+	for key, value := range check.Parameters {
+		if !isFileParam(value) {
+			continue
+		}
+		if params == nil {
+			// Copy the parameters, which may be shared with other checks
+			params = maps.Clone(check.Parameters)
+		}
+		resolved, err := readFileParam(value, baseDir)
+		if err != nil {
+			return errors.NewConfigError("check.parameters",
+				fmt.Errorf("failed to read parameter %q of check %q from file: %v", key, check.Name, err))
+		}
+		params[key] = resolved
+	}
+	if params != nil {
+		check.Parameters = params
+	}
+
+	if len(check.Checks) > 0 {
+		check.Checks = slices.Clone(check.Checks)
+		for i := range check.Checks {
+			if err := resolveFileParams(&check.Checks[i], baseDir); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readFileParam returns the contents of the file referenced by a parameter value, without
+// trailing newlines, or the literal value of an escaped reference starting with @@
+func readFileParam(value, baseDir string) (string, error) {
+	if strings.HasPrefix(value, "@@") {
+		return value[1:], nil
+	}
+	file := value[1:]
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(baseDir, file)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// applyShellOptions sets the shell options of a command check and its sub-checks that do
+// not set their own
+func applyShellOptions(check *types.CheckItem, options string) {
+	if check.Type == "command" && check.ShellOptions == "" {
+		check.ShellOptions = options
+	}
+	if len(check.Checks) > 0 {
+		// Copy the sub-checks, which may be shared with other checks after resolving references
+		check.Checks = slices.Clone(check.Checks)
+		for i := range check.Checks {
+			applyShellOptions(&check.Checks[i], options)
+		}
+	}
+}
+
 // validate validates the configuration
 func (m *Manager) validate(config *types.Config) error {
 	if len(config.Checks) == 0 {
 		return errors.NewConfigError("checks", fmt.Errorf("no checks defined"))
 	}
 
+	if err := redact.Validate(config.Redact); err != nil {
+		return errors.NewConfigError("redact", err)
+	}
+
+	if config.ShellOptions != "" {
+		if err := executor.ValidateShellOptions(config.ShellOptions); err != nil {
+			return errors.NewConfigError("shell_options", err)
+		}
+	}
+
+	if config.OutputFormat != "" && !config.OutputFormat.IsValid() {
+		return errors.NewConfigError("output_format", fmt.Errorf("unsupported output format %q", config.OutputFormat))
+	}
+
 	for _, check := range config.Checks {
-		// Validate required fields
-		if check.Name == "" {
-			return errors.NewConfigError("check.name", fmt.Errorf("check name is required"))
+		if err := validateCheck(check); err != nil {
+			return err
 		}
-		if check.Type == "" {
-			return errors.NewConfigError("check.type", fmt.Errorf("check type is required for check %q", check.Name))
+		if m.strictParams {
+			if err := validateCommandParams(check); err != nil {
+				return err
+			}
 		}
+	}
 
-		// If the name looks like a template, validate it first
-		if strings.Contains(check.Name, "{{") {
-			// Try to parse the template
-			if _, err := template.New("check-name").Option("missingkey=error").Parse(check.Name); err != nil {
-				return errors.NewConfigError("check.name", fmt.Errorf("invalid template in check name: %v", err))
+	return nil
+}
+
+// envVarPattern matches references to environment variables like $host or ${host}
+var envVarPattern = regexp.MustCompile(`\$\{?([A-Za-z_][A-Za-z0-9_]*)`)
+
+// validateCommandParams checks that every item parameter of a command check is used by
+// one of the templates of its ID, name, command, output file or env, or as an environment
+// variable by its command, including the item values its command template interpolates
+func validateCommandParams(check types.CheckItem) error {
+	if check.Type != "command" || len(check.Items) == 0 {
+		return nil
+	}
+
+	// Item parameters are also passed to the command as environment variables, so the
+	// command may use them as $host or ${host} instead of a template. Values of env are
+	// passed to the command as they are, without shell expansion.
+	used := make(map[string]bool)
+	addEnvVarRefs(check.Command, used)
+
+	commandFields := make(map[string]bool)
+	addTemplateFields(check.Command, commandFields)
+	texts := []string{check.ID, check.Name, check.OutputFile, check.Command}
+	for _, value := range check.Env {
+		texts = append(texts, value)
+	}
+	for _, text := range texts {
+		addTemplateFields(text, used)
+	}
+
+	for i, item := range check.Items {
+		// Values the command template interpolates become part of the command, whose
+		// shell expands the environment variables they reference
+		interpolated := make(map[string]bool)
+		for field := range commandFields {
+			addEnvVarRefs(item[field], interpolated)
+		}
+		keys := make([]string, 0, len(item))
+		for key := range item {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if !used[key] && !interpolated[key] && key != itemTimeoutKey {
+				return errors.NewConfigError("check.items",
+					fmt.Errorf("item %d of check %q sets parameter %q that the check does not use", i, check.Name, key))
 			}
 		}
+	}
+	return nil
+}
+
+// addEnvVarRefs adds the names of the environment variables referenced by text, e.g. host
+// for $host or ${host}, to names
+func addEnvVarRefs(text string, names map[string]bool) {
+	for _, match := range envVarPattern.FindAllStringSubmatch(text, -1) {
+		names[match[1]] = true
+	}
+}
+
+// addTemplateFields adds the names of the fields referenced by text, if it is a template,
+// to fields
+func addTemplateFields(text string, fields map[string]bool) {
+	if !isTemplate(text) {
+		return
+	}
+	tmpl, err := parseTemplate("check-params", text)
+	if err != nil {
+		// Invalid templates are reported by validateCheck
+		return
+	}
+	collectFields(tmpl.Root, fields)
+}
 
-		// Count how many of the mutually exclusive fields are set
-		fieldsSet := 0
-		if check.Command != "" {
-			fieldsSet++
+// collectFields adds the names of the fields referenced by a template node, e.g. host
+// for {{ .host }}, to fields
+func collectFields(node parse.Node, fields map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
 		}
-		if len(check.Parameters) > 0 {
-			fieldsSet++
+		for _, child := range n.Nodes {
+			collectFields(child, fields)
 		}
-		if len(check.Items) > 0 {
-			fieldsSet++
+	case *parse.ActionNode:
+		collectFields(n.Pipe, fields)
+	case *parse.PipeNode:
+		if n == nil {
+			return
 		}
+		for _, cmd := range n.Cmds {
+			collectFields(cmd, fields)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectFields(arg, fields)
+		}
+	case *parse.ChainNode:
+		collectFields(n.Node, fields)
+	case *parse.FieldNode:
+		fields[n.Ident[0]] = true
+	case *parse.IfNode:
+		collectFields(n.Pipe, fields)
+		collectFields(n.List, fields)
+		collectFields(n.ElseList, fields)
+	case *parse.RangeNode:
+		collectFields(n.Pipe, fields)
+		collectFields(n.List, fields)
+		collectFields(n.ElseList, fields)
+	case *parse.WithNode:
+		collectFields(n.Pipe, fields)
+		collectFields(n.List, fields)
+		collectFields(n.ElseList, fields)
+	case *parse.TemplateNode:
+		collectFields(n.Pipe, fields)
+	}
+}
 
-		// // Enforce exactly one field must be set
-		if fieldsSet > 1 {
-			return errors.NewConfigError("check.fields",
-				fmt.Errorf("check %q cannot have multiple of 'command', 'parameters', and 'items' fields", check.Name))
+// hasParameter reports whether a check sets the named parameter or environment variable,
+// directly or through its items
+func hasParameter(check types.CheckItem, name string) bool {
+	if _, ok := check.Parameters[name]; ok {
+		return true
+	}
+	if _, ok := check.Env[name]; ok {
+		return true
+	}
+	for _, item := range check.Items {
+		if _, ok := item[name]; ok {
+			return true
 		}
+	}
+	return false
+}
 
-		// If Items is used, ensure each item has parameters and validate template rendering
-		if len(check.Items) > 0 {
-			for i, item := range check.Items {
-				if len(item) == 0 {
+// validateCheck validates a single check and its sub-checks
+func validateCheck(check types.CheckItem) error {
+	// Validate required fields
+	if check.Name == "" {
+		return errors.NewConfigError("check.name", fmt.Errorf("check name is required"))
+	}
+	if check.Type == "" {
+		return errors.NewConfigError("check.type", fmt.Errorf("check type is required for check %q", check.Name))
+	}
+
+	if strings.Contains(check.ID, "{{") {
+		if _, err := parseTemplate("check-id", check.ID); err != nil {
+			return errors.NewConfigError("check.id", fmt.Errorf("invalid template in ID of check %q: %v", check.Name, err))
+		}
+	}
+
+	// If the name looks like a template, validate it first
+	if strings.Contains(check.Name, "{{") {
+		// Try to parse the template
+		if _, err := parseTemplate("check-name", check.Name); err != nil {
+			return errors.NewConfigError("check.name", fmt.Errorf("invalid template in check name: %v", err))
+		}
+	}
+
+	if check.Timeout != nil && *check.Timeout <= 0 {
+		return errors.NewConfigError("check.timeout", fmt.Errorf("timeout of check %q must be positive", check.Name))
+	}
+
+	if check.OutputFormat != "" && !slices.Contains(processor.SupportedOutputFormats(), check.OutputFormat) {
+		return errors.NewConfigError("check.output_format",
+			fmt.Errorf("invalid output format %q for check %q (supported formats: %s)",
+				check.OutputFormat, check.Name, strings.Join(processor.SupportedOutputFormats(), ", ")))
+	}
+
+	if err := redact.Validate(check.Redact); err != nil {
+		return errors.NewConfigError("check.redact", fmt.Errorf("check %q: %v", check.Name, err))
+	}
+
+	for _, name := range check.SecretParams {
+		if !hasParameter(check, name) {
+			return errors.NewConfigError("check.secret_params",
+				fmt.Errorf("secret_params of check %q names %q, which is neither a parameter nor an environment variable of the check", check.Name, name))
+		}
+	}
+
+	if check.DocURL != "" {
+		u, err := url.Parse(check.DocURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.NewConfigError("check.doc_url",
+				fmt.Errorf("doc_url of check %q must be an http or https URL, got %q", check.Name, check.DocURL))
+		}
+	}
+
+	for _, pattern := range check.Paths {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.NewConfigError("check.paths", fmt.Errorf("invalid path pattern %q of check %q: %v", pattern, check.Name, err))
+		}
+	}
+
+	// 'command' and 'parameters' are mutually exclusive
+	if check.Command != "" && len(check.Parameters) > 0 {
+		return errors.NewConfigError("check.fields",
+			fmt.Errorf("check %q cannot have both 'command' and 'parameters' fields", check.Name))
+	}
+
+	// Environment variables are only passed to commands
+	if len(check.Env) > 0 && check.Type != "command" {
+		return errors.NewConfigError("check.env",
+			fmt.Errorf("check %q can only use 'env' with the command type", check.Name))
+	}
+
+	// Output files are only read after running commands
+	if (check.OutputFile != "" || check.KeepOutputFile) && check.Type != "command" {
+		return errors.NewConfigError("check.output_file",
+			fmt.Errorf("check %q can only use 'output_file' with the command type", check.Name))
+	}
+	if check.KeepOutputFile && check.OutputFile == "" {
+		return errors.NewConfigError("check.keep_output_file",
+			fmt.Errorf("check %q sets 'keep_output_file' without 'output_file'", check.Name))
+	}
+
+	// Only commands have an exit code
+	if check.ExitAsFailure && check.Type != "command" {
+		return errors.NewConfigError("check.exit_as_failure",
+			fmt.Errorf("check %q can only use 'exit_as_failure' with the command type", check.Name))
+	}
+	if check.IgnoreStderr && check.Type != "command" {
+		return errors.NewConfigError("check.ignore_stderr",
+			fmt.Errorf("check %q can only use 'ignore_stderr' with the command type", check.Name))
+	}
+	if len(check.RetryableExitCodes) > 0 && check.Type != "command" {
+		return errors.NewConfigError("check.retryable_exit_codes",
+			fmt.Errorf("check %q can only use 'retryable_exit_codes' with the command type", check.Name))
+	}
+	for _, code := range check.RetryableExitCodes {
+		if code < 1 || code > 255 {
+			return errors.NewConfigError("check.retryable_exit_codes",
+				fmt.Errorf("retryable exit code %d of check %q must be between 1 and 255", code, check.Name))
+		}
+	}
+
+	// JSONPath expectations replace the output format of command checks
+	if check.JSONPath != "" || check.Expected != "" {
+		if check.Type != "command" {
+			return errors.NewConfigError("check.jsonpath",
+				fmt.Errorf("check %q can only use 'jsonpath' with the command type", check.Name))
+		}
+		if check.JSONPath == "" || check.Expected == "" {
+			return errors.NewConfigError("check.jsonpath",
+				fmt.Errorf("check %q must set both 'jsonpath' and 'expected'", check.Name))
+		}
+		if check.OutputFormat != "" {
+			return errors.NewConfigError("check.jsonpath",
+				fmt.Errorf("check %q cannot have both 'jsonpath' and 'output_format' fields", check.Name))
+		}
+		if _, err := executor.ParseJSONPath(check.JSONPath); err != nil {
+			return errors.NewConfigError("check.jsonpath", fmt.Errorf("invalid jsonpath of check %q: %v", check.Name, err))
+		}
+	}
+
+	// Shell options are only used when running commands
+	if check.ShellOptions != "" {
+		if check.Type != "command" {
+			return errors.NewConfigError("check.shell_options",
+				fmt.Errorf("check %q can only use 'shell_options' with the command type", check.Name))
+		}
+		if err := executor.ValidateShellOptions(check.ShellOptions); err != nil {
+			return errors.NewConfigError("check.shell_options", fmt.Errorf("check %q: %v", check.Name, err))
+		}
+	}
+
+	// If Items is used, ensure each item has parameters and validate template rendering
+	if len(check.Items) > 0 {
+		for i, item := range check.Items {
+			if len(item) == 0 {
+				return errors.NewConfigError("check.items",
+					fmt.Errorf("item %d in check %q must have parameters", i, check.Name))
+			}
+			if value, ok := item[itemTimeoutKey]; ok {
+				timeout, err := time.ParseDuration(value)
+				if err != nil {
 					return errors.NewConfigError("check.items",
-						fmt.Errorf("item %d in check %q must have parameters", i, check.Name))
+						fmt.Errorf("invalid %s %q in item %d of check %q: %v", itemTimeoutKey, value, i, check.Name, err))
+				}
+				if timeout <= 0 {
+					return errors.NewConfigError("check.items",
+						fmt.Errorf("%s of item %d of check %q must be positive", itemTimeoutKey, i, check.Name))
 				}
 			}
+		}
 
-			// If the name contains a template, validate it can be rendered
-			if isTemplate(check.Name) {
-				tmpl, _ := template.New("check-name").Option("missingkey=error").Parse(check.Name)
-				// Try to render the template with the first item to validate field access
-				var buf bytes.Buffer
-				if err := tmpl.Execute(&buf, check.Items[0]); err != nil {
-					return errors.NewConfigError("check.name", fmt.Errorf("failed to render check name template: %v", err))
+		// Templates in the command, output file, parameters and env are only rendered when items are used
+		if strings.Contains(check.Command, "{{") {
+			if _, err := parseTemplate("check-command", check.Command); err != nil {
+				return errors.NewConfigError("check.command", fmt.Errorf("invalid template in command of check %q: %v", check.Name, err))
+			}
+		}
+		if strings.Contains(check.OutputFile, "{{") {
+			if _, err := parseTemplate("check-output-file", check.OutputFile); err != nil {
+				return errors.NewConfigError("check.output_file",
+					fmt.Errorf("invalid template in output file of check %q: %v", check.Name, err))
+			}
+		}
+		for key, value := range check.Parameters {
+			if strings.Contains(value, "{{") {
+				if _, err := parseTemplate("check-parameter", value); err != nil {
+					return errors.NewConfigError("check.parameters",
+						fmt.Errorf("invalid template in parameter %q of check %q: %v", key, check.Name, err))
 				}
 			}
 		}
+		for key, value := range check.Env {
+			if strings.Contains(value, "{{") {
+				if _, err := parseTemplate("check-env", value); err != nil {
+					return errors.NewConfigError("check.env",
+						fmt.Errorf("invalid template in env %q of check %q: %v", key, check.Name, err))
+				}
+			}
+		}
+
+		// If the name contains a template, validate it can be rendered
+		if isTemplate(check.Name) {
+			// Try to render the template with the first item to validate field access
+			if _, err := renderTemplate("check-name", check.Name, check.Items[0]); err != nil {
+				return errors.NewConfigError("check.name", fmt.Errorf("failed to render check name template: %v", err))
+			}
+		}
+	}
+
+	// Sub-checks that only have a name reference another check, which is validated on its own
+	for _, sub := range check.Checks {
+		if sub.Type == "" && sub.Name != "" {
+			continue
+		}
+		if len(sub.Items) > 0 {
+			return errors.NewConfigError("check.checks",
+				fmt.Errorf("sub-check %q of check %q cannot use items", sub.Name, check.Name))
+		}
+		if len(sub.Matrix) > 0 {
+			return errors.NewConfigError("check.checks",
+				fmt.Errorf("sub-check %q of check %q cannot use matrix", sub.Name, check.Name))
+		}
+		// Hooks only run for the results of top-level checks
+		if sub.OnSuccess != "" || sub.OnFailure != "" {
+			return errors.NewConfigError("check.checks",
+				fmt.Errorf("sub-check %q of check %q cannot use on_success or on_failure", sub.Name, check.Name))
+		}
+		// Only top-level checks are skipped, a logic check always evaluates all its sub-checks
+		if sub.Enabled != nil {
+			return errors.NewConfigError("check.checks",
+				fmt.Errorf("sub-check %q of check %q cannot use enabled", sub.Name, check.Name))
+		}
+		if err := validateCheck(sub); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// resolveReferences replaces sub-checks that only have a name with the check of that
+// name, recursively. Checks that (indirectly) reference themselves are rejected.
+func resolveReferences(check types.CheckItem, checksByName map[string]types.CheckItem, visiting map[string]bool) (types.CheckItem, error) {
+	if len(check.Checks) == 0 {
+		return check, nil
+	}
+	if visiting[check.Name] {
+		return check, errors.NewConfigError("check.checks", fmt.Errorf("check %q references itself", check.Name))
+	}
+	visiting[check.Name] = true
+	defer delete(visiting, check.Name)
+
+	subChecks := make([]types.CheckItem, 0, len(check.Checks))
+	for _, sub := range check.Checks {
+		if sub.Type == "" {
+			ref, ok := checksByName[sub.Name]
+			if !ok {
+				return check, errors.NewConfigError("check.checks",
+					fmt.Errorf("check %q references unknown check %q", check.Name, sub.Name))
+			}
+			sub = ref
+		}
+		resolved, err := resolveReferences(sub, checksByName, visiting)
+		if err != nil {
+			return check, err
+		}
+		subChecks = append(subChecks, resolved)
+	}
+	check.Checks = subChecks
+	return check, nil
+}
+
 // isTemplate returns true if the string contains Go template syntax
 func isTemplate(s string) bool {
 	return strings.Contains(s, "{{") && strings.Contains(s, "}}")
 }
+
+// parseTemplate parses a template with the helper functions and strict missing key handling
+func parseTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+}
+
+// renderTemplate renders text against the given item parameters. Text that does not
+// contain template syntax is returned unchanged.
+func renderTemplate(name, text string, data map[string]string) (string, error) {
+	if !isTemplate(text) {
+		return text, nil
+	}
+
+	tmpl, err := parseTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
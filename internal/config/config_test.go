@@ -1,10 +1,16 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/types"
 )
 
 func TestManager_Load(t *testing.T) {
@@ -69,10 +75,10 @@ checks:
       key: value
 `,
 			wantErr:     true,
-			errContains: "cannot have multiple of 'command', 'parameters', and 'items' fields",
+			errContains: "cannot have both 'command' and 'parameters' fields",
 		},
 		{
-			name: "invalid_command_and_items",
+			name: "valid_command_and_items",
 			configYAML: `
 checks:
   - name: test-check
@@ -81,11 +87,12 @@ checks:
     items:
       - key: value
 `,
-			wantErr:     true,
-			errContains: "cannot have multiple of 'command', 'parameters', and 'items' fields",
+			wantErr:    false,
+			wantChecks: 1,
+			checkNames: []string{"test-check: 1"},
 		},
 		{
-			name: "invalid_parameters_and_items",
+			name: "valid_parameters_and_items",
 			configYAML: `
 checks:
   - name: test-check
@@ -95,8 +102,9 @@ checks:
     items:
       - key: value
 `,
-			wantErr:     true,
-			errContains: "cannot have multiple of 'command', 'parameters', and 'items' fields",
+			wantErr:    false,
+			wantChecks: 1,
+			checkNames: []string{"test-check: 1"},
 		},
 		{
 			name: "invalid_all_three_fields",
@@ -111,7 +119,7 @@ checks:
       - key: value
 `,
 			wantErr:     true,
-			errContains: "cannot have multiple of 'command', 'parameters', and 'items' fields",
+			errContains: "cannot have both 'command' and 'parameters' fields",
 		},
 		{
 			name: "empty checks",
@@ -141,6 +149,19 @@ checks:
 			wantErr:     true,
 			errContains: "check type is required",
 		},
+		{
+			name: "invalid path pattern",
+			configYAML: `
+checks:
+  - name: test-check
+    type: command
+    command: echo "test"
+    paths:
+      - "services/[api"
+`,
+			wantErr:     true,
+			errContains: "invalid path pattern",
+		},
 		{
 			name: "invalid empty item parameters",
 			configYAML: `
@@ -169,98 +190,1367 @@ checks:
 			checkNames: []string{"Check binary: git", "Check binary: docker"},
 		},
 		{
-			name: "invalid template syntax",
+			name: "valid config with template functions",
 			configYAML: `
 checks:
-  - name: "Check binary: {{ .name"
+  - name: "Check {{ .name | upper }} in {{ .region | default \"us-east-1\" | lower }}"
     type: test
     items:
       - name: git
+        region: EU-WEST-1
+      - name: docker
+        region: ""
 `,
-			wantErr:     true,
-			errContains: "invalid template in check name",
+			wantErr:    false,
+			wantChecks: 2,
+			checkNames: []string{"Check GIT in eu-west-1", "Check DOCKER in us-east-1"},
 		},
 		{
-			name: "missing template field",
+			name: "template trim function",
 			configYAML: `
 checks:
-  - name: "Check binary: {{ .missing }}"
+  - name: "Check {{ trim .name }}"
     type: test
     items:
-      - name: git
+      - name: "  git  "
+`,
+			wantErr:    false,
+			wantChecks: 1,
+			checkNames: []string{"Check git"},
+		},
+		{
+			name: "valid config with redaction",
+			configYAML: `
+redact:
+  - "token=\\w+"
+checks:
+  - name: test-check
+    type: command
+    command: echo "test"
+    redact:
+      - PASSWORD
+`,
+			wantErr:    false,
+			wantChecks: 1,
+			checkNames: []string{"test-check"},
+		},
+		{
+			name: "invalid redaction pattern",
+			configYAML: `
+checks:
+  - name: test-check
+    type: command
+    command: echo "test"
+    redact:
+      - "[unclosed"
 `,
 			wantErr:     true,
-			errContains: "failed to render check name template",
+			errContains: "invalid redaction pattern",
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Create a temporary config file
-			configPath := filepath.Join(tmpDir, tt.name+".yaml")
-			err := os.WriteFile(configPath, []byte(tt.configYAML), 0644)
-			if err != nil {
-				t.Fatalf("failed to write test config: %v", err)
-			}
-
-			m := NewManager(configPath)
-			config, err := m.Load()
-
-			if tt.wantErr {
-				if err == nil {
-					t.Error("Load() error = nil, wantErr = true")
-					return
-				}
-				if tt.errContains != "" && !strings.Contains(err.Error(), tt.errContains) {
-					t.Errorf("Load() error = %v, want error containing %v", err, tt.errContains)
-				}
-				return
-			}
-
-			if err != nil {
-				t.Errorf("Load() unexpected error = %v", err)
-				return
-			}
-
-			if len(config.Checks) != tt.wantChecks {
-				t.Errorf("Load() got %v checks, want %v", len(config.Checks), tt.wantChecks)
-			}
-
-			if tt.checkNames != nil {
-				for i, want := range tt.checkNames {
-					if i >= len(config.Checks) {
-						t.Errorf("Load() missing check at index %d, want name %s", i, want)
-						continue
-					}
-					if got := config.Checks[i].Name; got != want {
-						t.Errorf("Load() check[%d].Name = %v, want %v", i, got, want)
-					}
-				}
-			}
-		})
-	}
-}
-
-func TestManager_LoadNonExistentFile(t *testing.T) {
-	m := NewManager("non-existent-file.yaml")
-	_, err := m.Load()
-	if err == nil {
-		t.Error("Load() error = nil, want error for non-existent file")
-	}
-}
-
-func TestManager_LoadInvalidYAML(t *testing.T) {
-	tmpDir := t.TempDir()
-	configPath := filepath.Join(tmpDir, "invalid.yaml")
-
-	err := os.WriteFile(configPath, []byte("invalid: yaml: content"), 0644)
-	if err != nil {
-		t.Fatalf("failed to write test config: %v", err)
-	}
-
-	m := NewManager(configPath)
-	_, err = m.Load()
-	if err == nil {
-		t.Error("Load() error = nil, want error for invalid YAML")
+		{
+			name: "valid config with check timeout",
+			configYAML: `
+checks:
+  - name: test-check
+    type: command
+    command: echo "test"
+    timeout: 10s
+`,
+			wantErr:    false,
+			wantChecks: 1,
+			checkNames: []string{"test-check"},
+		},
+		{
+			name: "invalid negative check timeout",
+			configYAML: `
+checks:
+  - name: test-check
+    type: command
+    command: echo "test"
+    timeout: -1s
+`,
+			wantErr:     true,
+			errContains: "must be positive",
+		},
+		{
+			name: "invalid output format",
+			configYAML: `
+checks:
+  - name: test-check
+    type: command
+    command: echo "test"
+    output_format: xml
+`,
+			wantErr:     true,
+			errContains: "invalid output format \"xml\"",
+		},
+		{
+			name: "valid config with sub-checks",
+			configYAML: `
+checks:
+  - name: port-open
+    type: command
+    command: echo "test"
+  - name: service-ready
+    type: logic.all_of
+    checks:
+      - name: port-open
+      - name: config-exists
+        type: os.file_exists
+        parameters:
+          path: /etc/service.conf
+`,
+			wantErr:    false,
+			wantChecks: 2,
+			checkNames: []string{"port-open", "service-ready"},
+		},
+		{
+			name: "unknown sub-check reference",
+			configYAML: `
+checks:
+  - name: service-ready
+    type: logic.all_of
+    checks:
+      - name: missing
+`,
+			wantErr:     true,
+			errContains: "references unknown check \"missing\"",
+		},
+		{
+			name: "self-referencing sub-check",
+			configYAML: `
+checks:
+  - name: a
+    type: logic.all_of
+    checks:
+      - name: b
+  - name: b
+    type: logic.all_of
+    checks:
+      - name: a
+`,
+			wantErr:     true,
+			errContains: "references itself",
+		},
+		{
+			name: "invalid inline sub-check",
+			configYAML: `
+checks:
+  - name: service-ready
+    type: logic.all_of
+    checks:
+      - type: os.file_exists
+`,
+			wantErr:     true,
+			errContains: "check name is required",
+		},
+		{
+			name: "valid config with env",
+			configYAML: `
+checks:
+  - name: test-check
+    type: command
+    command: echo "$GREETING"
+    env:
+      GREETING: hello
+`,
+			wantErr:    false,
+			wantChecks: 1,
+			checkNames: []string{"test-check"},
+		},
+		{
+			name: "env on native check",
+			configYAML: `
+checks:
+  - name: test-check
+    type: os.file_exists
+    env:
+      GREETING: hello
+`,
+			wantErr:     true,
+			errContains: "can only use 'env' with the command type",
+		},
+		{
+			name: "output file on native check",
+			configYAML: `
+checks:
+  - name: test-check
+    type: os.file_exists
+    output_file: /tmp/result.json
+`,
+			wantErr:     true,
+			errContains: "can only use 'output_file' with the command type",
+		},
+		{
+			name: "secret params",
+			configYAML: `
+checks:
+  - name: "deploy {{ .env }}"
+    type: command
+    command: ./deploy.sh
+    env:
+      API_TOKEN: s3cr3t
+    secret_params: [API_TOKEN, password]
+    items:
+      - env: prod
+        password: hunter2
+`,
+			wantErr:    false,
+			wantChecks: 1,
+			checkNames: []string{"deploy prod"},
+		},
+		{
+			name: "unknown secret param",
+			configYAML: `
+checks:
+  - name: test-check
+    type: command
+    command: echo "$API_TOKEN"
+    env:
+      API_TOKEN: s3cr3t
+    secret_params: [API_TOKN]
+`,
+			wantErr:     true,
+			errContains: `secret_params of check "test-check" names "API_TOKN"`,
+		},
+		{
+			name: "keep output file without output file",
+			configYAML: `
+checks:
+  - name: test-check
+    type: command
+    command: echo "test"
+    keep_output_file: true
+`,
+			wantErr:     true,
+			errContains: "sets 'keep_output_file' without 'output_file'",
+		},
+		{
+			name: "exit as failure with native check",
+			configYAML: `
+checks:
+  - name: test-check
+    type: os.file_exists
+    parameters:
+      path: /etc/hosts
+    exit_as_failure: true
+`,
+			wantErr:     true,
+			errContains: "can only use 'exit_as_failure' with the command type",
+		},
+		{
+			name: "relative doc url",
+			configYAML: `
+checks:
+  - name: test-check
+    type: os.file_exists
+    parameters:
+      path: /etc/hosts
+    doc_url: docs/hosts.md
+`,
+			wantErr:     true,
+			errContains: `doc_url of check "test-check" must be an http or https URL, got "docs/hosts.md"`,
+		},
+		{
+			name: "ignore stderr with native check",
+			configYAML: `
+checks:
+  - name: test-check
+    type: os.file_exists
+    parameters:
+      path: /etc/hosts
+    ignore_stderr: true
+`,
+			wantErr:     true,
+			errContains: "can only use 'ignore_stderr' with the command type",
+		},
+		{
+			name: "retryable exit codes with native check",
+			configYAML: `
+checks:
+  - name: test-check
+    type: os.file_exists
+    parameters:
+      path: /etc/hosts
+    retryable_exit_codes: [75]
+`,
+			wantErr:     true,
+			errContains: "can only use 'retryable_exit_codes' with the command type",
+		},
+		{
+			name: "retryable exit code out of range",
+			configYAML: `
+checks:
+  - name: test-check
+    type: command
+    command: curl -sf https://example.com
+    retryable_exit_codes: [7, 256]
+`,
+			wantErr:     true,
+			errContains: "retryable exit code 256 of check \"test-check\" must be between 1 and 255",
+		},
+		{
+			name: "valid shell options",
+			configYAML: `
+shell_options: -euo pipefail
+checks:
+  - name: test-check
+    type: command
+    command: echo "test"
+    shell_options: -e +o pipefail
+`,
+			wantErr:    false,
+			wantChecks: 1,
+			checkNames: []string{"test-check"},
+		},
+		{
+			name: "invalid shell options",
+			configYAML: `
+checks:
+  - name: test-check
+    type: command
+    command: echo "test"
+    shell_options: -e; curl example.com
+`,
+			wantErr:     true,
+			errContains: `invalid shell option "-e;"`,
+		},
+		{
+			name: "invalid default shell options",
+			configYAML: `
+shell_options: -o pipefial
+checks:
+  - name: test-check
+    type: command
+    command: echo "test"
+`,
+			wantErr:     true,
+			errContains: `unknown shell option name "pipefial"`,
+		},
+		{
+			name: "invalid output format",
+			configYAML: `
+output_format: xml
+checks:
+  - name: test-check
+    type: command
+    command: echo "test"
+`,
+			wantErr:     true,
+			errContains: `unsupported output format "xml"`,
+		},
+		{
+			name: "jsonpath without expected",
+			configYAML: `
+checks:
+  - name: test-check
+    type: command
+    command: kubectl get deployment api -o json
+    jsonpath: .status.readyReplicas
+`,
+			wantErr:     true,
+			errContains: "must set both 'jsonpath' and 'expected'",
+		},
+		{
+			name: "invalid jsonpath",
+			configYAML: `
+checks:
+  - name: test-check
+    type: command
+    command: kubectl get deployment api -o json
+    jsonpath: "{.status"
+    expected: "3"
+`,
+			wantErr:     true,
+			errContains: "invalid jsonpath of check",
+		},
+		{
+			name: "jsonpath on native check",
+			configYAML: `
+checks:
+  - name: test-check
+    type: os.file_exists
+    jsonpath: .status
+    expected: ok
+`,
+			wantErr:     true,
+			errContains: "can only use 'jsonpath' with the command type",
+		},
+		{
+			name: "disabled sub-check",
+			configYAML: `
+checks:
+  - name: test-check
+    type: logic.all_of
+    checks:
+      - name: sub-check
+        type: command
+        command: echo "test"
+        enabled: false
+`,
+			wantErr:     true,
+			errContains: `sub-check "sub-check" of check "test-check" cannot use enabled`,
+		},
+		{
+			name: "shell options on native check",
+			configYAML: `
+checks:
+  - name: test-check
+    type: os.file_exists
+    shell_options: -eu
+`,
+			wantErr:     true,
+			errContains: "can only use 'shell_options' with the command type",
+		},
+		{
+			name: "invalid template syntax",
+			configYAML: `
+checks:
+  - name: "Check binary: {{ .name"
+    type: test
+    items:
+      - name: git
+`,
+			wantErr:     true,
+			errContains: "invalid template in check name",
+		},
+		{
+			name: "missing template field",
+			configYAML: `
+checks:
+  - name: "Check binary: {{ .missing }}"
+    type: test
+    items:
+      - name: git
+`,
+			wantErr:     true,
+			errContains: "failed to render check name template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a temporary config file
+			configPath := filepath.Join(tmpDir, tt.name+".yaml")
+			err := os.WriteFile(configPath, []byte(tt.configYAML), 0644)
+			if err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+
+			m := NewManager(configPath)
+			config, err := m.Load()
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Load() error = nil, wantErr = true")
+					return
+				}
+				if tt.errContains != "" && !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("Load() error = %v, want error containing %v", err, tt.errContains)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Load() unexpected error = %v", err)
+				return
+			}
+
+			if len(config.Checks) != tt.wantChecks {
+				t.Errorf("Load() got %v checks, want %v", len(config.Checks), tt.wantChecks)
+			}
+
+			if tt.checkNames != nil {
+				for i, want := range tt.checkNames {
+					if i >= len(config.Checks) {
+						t.Errorf("Load() missing check at index %d, want name %s", i, want)
+						continue
+					}
+					if got := config.Checks[i].Name; got != want {
+						t.Errorf("Load() check[%d].Name = %v, want %v", i, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestManager_LoadDefaultShellOptions(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "checks.yaml")
+	configYAML := `
+shell_options: -euo pipefail
+checks:
+  - name: default-options
+    type: command
+    command: echo "test"
+  - name: own-options
+    type: command
+    command: echo "test"
+    shell_options: -e
+  - name: native-check
+    type: os.file_exists
+  - name: combined
+    type: logic.all_of
+    checks:
+      - name: default-options
+      - name: inline
+        type: command
+        command: echo "test"
+`
+	if err := os.WriteFile(configPath, []byte(configYAML), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	config, err := NewManager(configPath).Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+
+	tests := []struct {
+		check types.CheckItem
+		want  string
+	}{
+		{check: config.Checks[0], want: "-euo pipefail"},
+		{check: config.Checks[1], want: "-e"},
+		{check: config.Checks[2], want: ""},
+		{check: config.Checks[3].Checks[0], want: "-euo pipefail"},
+		{check: config.Checks[3].Checks[1], want: "-euo pipefail"},
+	}
+	for _, tt := range tests {
+		if tt.check.ShellOptions != tt.want {
+			t.Errorf("check %q ShellOptions = %q, want %q", tt.check.Name, tt.check.ShellOptions, tt.want)
+		}
+	}
+}
+
+func TestManager_LoadStrictParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name: "all parameters used",
+			config: `
+checks:
+  - name: "Ping {{ .host }}"
+    type: command
+    command: ping -c 1 {{ if .count }}-c {{ .count }}{{ end }} $TARGET
+    env:
+      TARGET: "{{ .host | upper }}"
+    items:
+      - host: example.com
+        count: "3"
+`,
+		},
+		{
+			name: "parameters used as environment variables",
+			config: `
+checks:
+  - name: "Resolve {{ .region }}"
+    type: command
+    command: 'test -n "$host" && nslookup "${host}"'
+    items:
+      - region: eu
+        host: example.com
+`,
+		},
+		{
+			name: "parameters referenced by interpolated values",
+			config: `
+checks:
+  - name: "Run {{ .script }}"
+    type: command
+    command: "{{ .script }}"
+    items:
+      - script: 'nslookup "$host"'
+        host: example.com
+`,
+		},
+		{
+			name: "env values are not expanded",
+			config: `
+checks:
+  - name: Resolve
+    type: command
+    command: nslookup "$TARGET"
+    env:
+      TARGET: $host
+    items:
+      - host: example.com
+`,
+			wantErr: `item 0 of check "Resolve" sets parameter "host" that the check does not use`,
+		},
+		{
+			name: "misspelled parameter",
+			config: `
+checks:
+  - name: Ping
+    type: command
+    command: ping -c 1 {{ .host }}
+    items:
+      - host: example.com
+      - host: example.org
+        hots: example.net
+`,
+			wantErr: `item 1 of check "Ping" sets parameter "hots" that the check does not use`,
+		},
+		{
+			name: "native checks are not affected",
+			config: `
+checks:
+  - name: File
+    type: os.file_exists
+    items:
+      - path: /etc/hosts
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "checks.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.config), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+
+			manager := NewManager(configPath)
+			manager.SetStrictParams(true)
+			_, err := manager.Load()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Load() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %v, want error containing %q", err, tt.wantErr)
+			}
+
+			// Without strict parameters the configuration is accepted
+			if _, err := NewManager(configPath).Load(); err != nil {
+				t.Errorf("Load() without strict params unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestManager_LoadItemTimeouts(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "checks.yaml")
+	configYAML := `
+checks:
+  - name: "Repo {{ .repo }}"
+    type: command
+    command: "git -C {{ .repo }} fetch"
+    timeout: 10s
+    items:
+      - repo: small
+      - repo: monorepo
+        check_timeout: 5m
+  - name: "Clock {{ .server }}"
+    type: os.time_sync
+    timeout: 10s
+    items:
+      - server: pool.ntp.org:123
+        timeout: 2s
+`
+	if err := os.WriteFile(configPath, []byte(configYAML), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	m := NewManager(configPath)
+	m.SetStrictParams(true)
+	config, err := m.Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+
+	tests := []struct {
+		check       types.CheckItem
+		wantTimeout time.Duration
+		wantParams  map[string]string
+	}{
+		{check: config.Checks[0], wantTimeout: 10 * time.Second, wantParams: map[string]string{"repo": "small"}},
+		{check: config.Checks[1], wantTimeout: 5 * time.Minute, wantParams: map[string]string{"repo": "monorepo"}},
+		// An item's timeout is a parameter like any other key
+		{check: config.Checks[2], wantTimeout: 10 * time.Second, wantParams: map[string]string{"server": "pool.ntp.org:123", "timeout": "2s"}},
+	}
+	for _, tt := range tests {
+		if tt.check.Timeout == nil || *tt.check.Timeout != tt.wantTimeout {
+			t.Errorf("check %q Timeout = %v, want %v", tt.check.Name, tt.check.Timeout, tt.wantTimeout)
+		}
+		if !reflect.DeepEqual(tt.check.Parameters, tt.wantParams) {
+			t.Errorf("check %q Parameters = %v, want %v", tt.check.Name, tt.check.Parameters, tt.wantParams)
+		}
+	}
+
+	for _, timeout := range []string{"soon", "-1s"} {
+		configYAML = `
+checks:
+  - name: "Repo {{ .repo }}"
+    type: command
+    command: "git -C {{ .repo }} fetch"
+    items:
+      - repo: monorepo
+        check_timeout: "` + timeout + `"
+`
+		if err := os.WriteFile(configPath, []byte(configYAML), 0644); err != nil {
+			t.Fatalf("failed to write test config: %v", err)
+		}
+		if _, err := NewManager(configPath).Load(); err == nil || !strings.Contains(err.Error(), "item 0 of check") {
+			t.Errorf("Load() with item timeout %q error = %v, want an invalid timeout error", timeout, err)
+		}
+	}
+}
+
+func TestManager_LoadFileParams(t *testing.T) {
+	tmpDir := t.TempDir()
+	secretsDir := filepath.Join(tmpDir, "secrets")
+	if err := os.MkdirAll(secretsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(secretsDir, "identity"), []byte("arn:aws:iam::123456789012:user/ci\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tokenPath := filepath.Join(secretsDir, "token")
+	if err := os.WriteFile(tokenPath, []byte("s3cr3t"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	configPath := filepath.Join(tmpDir, "checks.yaml")
+	configYAML := fmt.Sprintf(`
+checks:
+  - name: relative-path
+    type: cloud.aws_authentication
+    parameters:
+      identity: "@./secrets/identity"
+  - name: "absolute path {{ .env }}"
+    type: net.tcp_banner
+    parameters:
+      expected_banner: "@%s"
+      handle: "@@/ops"
+    items:
+      - env: prod
+  - name: literal values
+    type: os.cron_freshness
+    parameters:
+      cron: "@hourly"
+      owner: "@ops"
+  - name: combined
+    type: logic.all_of
+    checks:
+      - name: inline
+        type: os.file_exists
+        parameters:
+          path: "@./secrets/token"
+`, tokenPath)
+	if err := os.WriteFile(configPath, []byte(configYAML), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	config, err := NewManager(configPath).Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+
+	tests := []struct {
+		check types.CheckItem
+		param string
+		want  string
+	}{
+		{check: config.Checks[0], param: "identity", want: "arn:aws:iam::123456789012:user/ci"},
+		{check: config.Checks[1], param: "expected_banner", want: "s3cr3t"},
+		{check: config.Checks[1], param: "handle", want: "@/ops"},
+		// Values starting with @ that do not look like a path are literal values
+		{check: config.Checks[2], param: "cron", want: "@hourly"},
+		{check: config.Checks[2], param: "owner", want: "@ops"},
+		{check: config.Checks[3].Checks[0], param: "path", want: "s3cr3t"},
+	}
+	for _, tt := range tests {
+		if got := tt.check.Parameters[tt.param]; got != tt.want {
+			t.Errorf("check %q parameter %q = %q, want %q", tt.check.Name, tt.param, got, tt.want)
+		}
+	}
+
+	// A missing file is reported with the parameter and check
+	configYAML = `
+checks:
+  - name: missing
+    type: cloud.aws_authentication
+    parameters:
+      identity: "@./secrets/missing"
+`
+	if err := os.WriteFile(configPath, []byte(configYAML), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	_, err = NewManager(configPath).Load()
+	if err == nil || !strings.Contains(err.Error(), `failed to read parameter "identity" of check "missing" from file`) {
+		t.Errorf("Load() error = %v, want an error about the missing file", err)
+	}
+}
+
+func TestManager_LoadParametersFile(t *testing.T) {
+	checks.Register("test.parameters_file", "Test check", func(item types.CheckItem) (types.CheckResult, error) {
+		return types.CheckResult{}, nil
+	},
+		checks.Parameter{Name: "region", Type: checks.ParamString},
+		checks.Parameter{Name: "password", Type: checks.ParamString},
+	)
+	defer delete(checks.Registry, "test.parameters_file")
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "password"), []byte("s3cr3t\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	paramsPath := filepath.Join(tmpDir, "prod.yaml")
+	paramsYAML := `
+parameters:
+  region: eu-west-1
+  password: shared
+checks:
+  database orders:
+    password: "@./password"
+  custom:
+    region: us-east-1
+    bucket: artifacts
+`
+	if err := os.WriteFile(paramsPath, []byte(paramsYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	configPath := filepath.Join(tmpDir, "checks.yaml")
+	configYAML := `
+checks:
+  - name: "database {{ .db }}"
+    type: test.parameters_file
+    items:
+      - db: orders
+  - name: configured
+    type: test.parameters_file
+    parameters:
+      region: ap-south-1
+  - name: custom
+    type: os.file_exists
+    parameters:
+      path: /etc/hosts
+  - name: combined
+    type: logic.all_of
+    checks:
+      - name: inline
+        type: test.parameters_file
+  - name: shell
+    type: command
+    command: echo ok
+`
+	if err := os.WriteFile(configPath, []byte(configYAML), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	m := NewManager(configPath)
+	m.SetParametersFile(paramsPath)
+	config, err := m.Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+
+	tests := []struct {
+		check types.CheckItem
+		want  map[string]string
+	}{
+		// Values of the check by name take precedence over shared values and match the
+		// name rendered from the item
+		{check: config.Checks[0], want: map[string]string{"db": "orders", "region": "eu-west-1", "password": "s3cr3t"}},
+		// Values of the config file take precedence over the parameters file
+		{check: config.Checks[1], want: map[string]string{"region": "ap-south-1", "password": "shared"}},
+		// Shared values only apply to declared parameters, values by name apply to any
+		{check: config.Checks[2], want: map[string]string{"path": "/etc/hosts", "region": "us-east-1", "bucket": "artifacts"}},
+		{check: config.Checks[3].Checks[0], want: map[string]string{"region": "eu-west-1", "password": "shared"}},
+		{check: config.Checks[4], want: nil},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.check.Parameters, tt.want) {
+			t.Errorf("check %q parameters = %v, want %v", tt.check.Name, tt.check.Parameters, tt.want)
+		}
+	}
+
+	errorTests := []struct {
+		name       string
+		paramsYAML string
+		wantErr    string
+	}{
+		{
+			name:       "unknown check",
+			paramsYAML: "checks:\n  databse:\n    region: eu-west-1\n",
+			wantErr:    `parameters file sets parameters of check "databse", which does not exist`,
+		},
+		{
+			name:       "command check",
+			paramsYAML: "checks:\n  shell:\n    region: eu-west-1\n",
+			wantErr:    `parameters file sets parameters of command check "shell", which does not take parameters`,
+		},
+		{
+			name:       "unknown field",
+			paramsYAML: "parameter:\n  region: eu-west-1\n",
+			wantErr:    "field parameter not found",
+		},
+		{
+			name:       "missing file",
+			paramsYAML: "parameters:\n  password: \"@./missing\"\n",
+			wantErr:    `failed to read parameter "password" from file`,
+		},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(paramsPath, []byte(tt.paramsYAML), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := m.Load()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestManager_LoadNonExistentFile(t *testing.T) {
+	m := NewManager("non-existent-file.yaml")
+	_, err := m.Load()
+	if err == nil {
+		t.Error("Load() error = nil, want error for non-existent file")
+	}
+}
+
+func TestManager_LoadInvalidYAML(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "invalid.yaml")
+
+	err := os.WriteFile(configPath, []byte("invalid: yaml: content"), 0644)
+	if err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	m := NewManager(configPath)
+	_, err = m.Load()
+	if err == nil {
+		t.Error("Load() error = nil, want error for invalid YAML")
+	}
+}
+
+func TestManager_LoadMatrix(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "checks.yaml")
+	configYAML := `
+checks:
+  - name: "Bucket {{ .env }} in {{ .region }}"
+    id: "bucket-{{ .env }}-{{ .region }}"
+    type: cloud.aws_s3_access
+    parameters:
+      bucket: "artifacts-{{ .env }}-{{ .region }}"
+    matrix:
+      region: [us-east-1, eu-west-1]
+      env: [prod, dev]
+`
+	if err := os.WriteFile(configPath, []byte(configYAML), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	config, err := NewManager(configPath).Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+
+	// Combinations are ordered by parameter name, the last one varying fastest, and
+	// values in the order they are listed
+	want := []struct {
+		name, id, bucket, region string
+	}{
+		{"Bucket prod in us-east-1", "bucket-prod-us-east-1", "artifacts-prod-us-east-1", "us-east-1"},
+		{"Bucket prod in eu-west-1", "bucket-prod-eu-west-1", "artifacts-prod-eu-west-1", "eu-west-1"},
+		{"Bucket dev in us-east-1", "bucket-dev-us-east-1", "artifacts-dev-us-east-1", "us-east-1"},
+		{"Bucket dev in eu-west-1", "bucket-dev-eu-west-1", "artifacts-dev-eu-west-1", "eu-west-1"},
+	}
+	if len(config.Checks) != len(want) {
+		t.Fatalf("Load() returned %d checks, want %d", len(config.Checks), len(want))
+	}
+	for i, w := range want {
+		check := config.Checks[i]
+		if check.Name != w.name || check.ID != w.id || check.Parameters["bucket"] != w.bucket {
+			t.Errorf("check %d = %q (ID %q, bucket %q), want %q (ID %q, bucket %q)",
+				i, check.Name, check.ID, check.Parameters["bucket"], w.name, w.id, w.bucket)
+		}
+		if check.Parameters["region"] != w.region {
+			t.Errorf("check %q region = %q, want %q", check.Name, check.Parameters["region"], w.region)
+		}
+		if check.Matrix != nil || check.Items != nil {
+			t.Errorf("check %q was not expanded: %+v", check.Name, check)
+		}
+	}
+
+	checks.Register("test.matrix", "Test check", func(item types.CheckItem) (types.CheckResult, error) {
+		return types.CheckResult{}, nil
+	}, checks.Parameter{Name: "path", Type: checks.ParamString, Required: true})
+	defer delete(checks.Registry, "test.matrix")
+
+	invalid := []struct {
+		name        string
+		configYAML  string
+		errContains string
+	}{
+		{
+			name: "items and matrix",
+			configYAML: `
+checks:
+  - name: "Host {{ .host }}"
+    type: command
+    command: ping -c 1 {{ .host }}
+    items:
+      - host: a
+    matrix:
+      host: [b, c]
+`,
+			errContains: `check "Host {{ .host }}" cannot have both 'items' and 'matrix' fields`,
+		},
+		{
+			name: "parameter without values",
+			configYAML: `
+checks:
+  - name: "Host {{ .host }}"
+    type: command
+    command: ping -c 1 {{ .host }}
+    matrix:
+      host: []
+`,
+			errContains: `matrix parameter "host" of check "Host {{ .host }}" has no values`,
+		},
+		{
+			name: "combination validated like an item",
+			configYAML: `
+checks:
+  - name: "Repo {{ .repo }}"
+    type: command
+    command: git -C {{ .repo }} fetch
+    matrix:
+      repo: [small]
+      check_timeout: [1m, soon]
+`,
+			errContains: `invalid check_timeout "soon" in item 1 of check`,
+		},
+		{
+			name: "combination invalid for the check type",
+			configYAML: `
+checks:
+  - name: "File {{ .bogus }}"
+    type: test.matrix
+    matrix:
+      bogus: ["1", "2"]
+`,
+			errContains: `matrix combination "File 1" of type test.matrix is invalid: path parameter is required`,
+		},
+		{
+			name: "sub-check with matrix",
+			configYAML: `
+checks:
+  - name: all
+    type: logic.all_of
+    checks:
+      - name: "Host {{ .host }}"
+        type: command
+        command: ping -c 1 {{ .host }}
+        matrix:
+          host: [a, b]
+`,
+			errContains: `sub-check "Host {{ .host }}" of check "all" cannot use matrix`,
+		},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(configPath, []byte(tt.configYAML), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+			if _, err := NewManager(configPath).Load(); err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("Load() error = %v, want an error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestManager_LoadItemTemplates(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	tests := []struct {
+		name        string
+		configYAML  string
+		wantErr     bool
+		errContains string
+		wantChecks  []types.CheckItem
+	}{
+		{
+			name: "command template",
+			configYAML: `
+checks:
+  - name: "Health {{ .host }}"
+    type: command
+    command: "curl -sf http://{{ .host }}:{{ .port | default \"80\" }}/health"
+    items:
+      - host: api.internal
+        port: "8080"
+      - host: web.internal
+        port: ""
+`,
+			wantChecks: []types.CheckItem{
+				{
+					Name:       "Health api.internal",
+					Type:       "command",
+					Command:    "curl -sf http://api.internal:8080/health",
+					Parameters: map[string]string{"host": "api.internal", "port": "8080"},
+				},
+				{
+					Name:       "Health web.internal",
+					Type:       "command",
+					Command:    "curl -sf http://web.internal:80/health",
+					Parameters: map[string]string{"host": "web.internal", "port": ""},
+				},
+			},
+		},
+		{
+			name: "parameter templates",
+			configYAML: `
+checks:
+  - name: "Bucket {{ .env }}"
+    type: cloud.aws_s3_access
+    parameters:
+      bucket: "artifacts-{{ .env }}"
+      aws_profile: shared
+    items:
+      - env: prod
+      - env: dev
+        aws_profile: dev
+`,
+			wantChecks: []types.CheckItem{
+				{
+					Name:       "Bucket prod",
+					Type:       "cloud.aws_s3_access",
+					Parameters: map[string]string{"bucket": "artifacts-prod", "aws_profile": "shared", "env": "prod"},
+				},
+				{
+					Name:       "Bucket dev",
+					Type:       "cloud.aws_s3_access",
+					Parameters: map[string]string{"bucket": "artifacts-dev", "aws_profile": "dev", "env": "dev"},
+				},
+			},
+		},
+		{
+			name: "sub-check references",
+			configYAML: `
+checks:
+  - name: "Port {{ .port }}"
+    type: command
+    command: "nc -z localhost {{ .port }}"
+    items:
+      - port: "80"
+  - name: web-ready
+    type: logic.all_of
+    checks:
+      - name: Port 80
+      - name: inline
+        type: command
+        command: echo ok
+`,
+			wantChecks: []types.CheckItem{
+				{
+					Name:       "Port 80",
+					Type:       "command",
+					Command:    "nc -z localhost 80",
+					Parameters: map[string]string{"port": "80"},
+				},
+				{
+					Name: "web-ready",
+					Type: "logic.all_of",
+					Checks: []types.CheckItem{
+						{
+							Name:       "Port 80",
+							Type:       "command",
+							Command:    "nc -z localhost 80",
+							Parameters: map[string]string{"port": "80"},
+						},
+						{
+							Name:    "inline",
+							Type:    "command",
+							Command: "echo ok",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ID templates",
+			configYAML: `
+checks:
+  - id: "health-{{ .host }}"
+    name: "Health {{ .host }}"
+    type: command
+    command: "curl -sf http://{{ .host }}/health"
+    items:
+      - host: api
+  - id: ping
+    name: Ping
+    type: command
+    command: "ping -c 1 {{ .host }}"
+    items:
+      - host: api
+      - host: web
+`,
+			wantChecks: []types.CheckItem{
+				{
+					ID:         "health-api",
+					Name:       "Health api",
+					Type:       "command",
+					Command:    "curl -sf http://api/health",
+					Parameters: map[string]string{"host": "api"},
+				},
+				{
+					ID:         "ping-1",
+					Name:       "Ping: 1",
+					Type:       "command",
+					Command:    "ping -c 1 api",
+					Parameters: map[string]string{"host": "api"},
+				},
+				{
+					ID:         "ping-2",
+					Name:       "Ping: 2",
+					Type:       "command",
+					Command:    "ping -c 1 web",
+					Parameters: map[string]string{"host": "web"},
+				},
+			},
+		},
+		{
+			name: "duplicate IDs",
+			configYAML: `
+checks:
+  - id: disk
+    name: Disk root
+    type: command
+    command: df /
+  - id: disk
+    name: Disk home
+    type: command
+    command: df /home
+`,
+			wantErr:     true,
+			errContains: `checks "Disk root" and "Disk home" have the same ID "disk"`,
+		},
+		{
+			name: "output file template",
+			configYAML: `
+checks:
+  - name: "Scan {{ .image }}"
+    type: command
+    command: "scanner --report /tmp/scan-{{ .image }}.json {{ .image }}"
+    output_file: "/tmp/scan-{{ .image }}.json"
+    items:
+      - image: api
+`,
+			wantChecks: []types.CheckItem{
+				{
+					Name:       "Scan api",
+					Type:       "command",
+					Command:    "scanner --report /tmp/scan-api.json api",
+					OutputFile: "/tmp/scan-api.json",
+					Parameters: map[string]string{"image": "api"},
+				},
+			},
+		},
+		{
+			name: "env templates",
+			configYAML: `
+checks:
+  - name: "Ping {{ .host }}"
+    type: command
+    command: ping -c 1 "$HOST"
+    env:
+      HOST: "{{ .host }}.internal"
+      COUNT: "1"
+    items:
+      - host: api
+`,
+			wantChecks: []types.CheckItem{
+				{
+					Name:       "Ping api",
+					Type:       "command",
+					Command:    `ping -c 1 "$HOST"`,
+					Parameters: map[string]string{"host": "api"},
+					Env:        map[string]string{"HOST": "api.internal", "COUNT": "1"},
+				},
+			},
+		},
+		{
+			name: "invalid command template",
+			configYAML: `
+checks:
+  - name: test-check
+    type: command
+    command: "echo {{ .host"
+    items:
+      - host: a
+`,
+			wantErr:     true,
+			errContains: "invalid template in command",
+		},
+		{
+			name: "missing key in command template",
+			configYAML: `
+checks:
+  - name: test-check
+    type: command
+    command: "echo {{ .host }}"
+    items:
+      - host: a
+      - port: "80"
+`,
+			wantErr:     true,
+			errContains: "failed to render command template",
+		},
+		{
+			name: "missing key in parameter template",
+			configYAML: `
+checks:
+  - name: test-check
+    type: os.file_exists
+    parameters:
+      path: "/etc/{{ .file }}"
+    items:
+      - name: a
+`,
+			wantErr:     true,
+			errContains: "failed to render parameter \"path\" template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(tmpDir, tt.name+".yaml")
+			if err := os.WriteFile(configPath, []byte(tt.configYAML), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+
+			config, err := NewManager(configPath).Load()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Load() error = nil, wantErr = true")
+				}
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("Load() error = %v, want error containing %v", err, tt.errContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() unexpected error = %v", err)
+			}
+
+			if !reflect.DeepEqual(config.Checks, tt.wantChecks) {
+				t.Errorf("Load() checks = %+v, want %+v", config.Checks, tt.wantChecks)
+			}
+		})
 	}
 }
@@ -0,0 +1,151 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/seastar-consulting/checkers/checks"
+	"github.com/seastar-consulting/checkers/internal/errors"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// parameterValues are parameter values supplied separately from the configuration, e.g.
+// secrets or the values of an environment
+type parameterValues struct {
+	// Parameters apply to every check whose type declares the parameter
+	Parameters map[string]string `yaml:"parameters"`
+	// Checks apply to the check of the same name, after items have been expanded
+	Checks map[string]map[string]string `yaml:"checks"`
+}
+
+// loadParameterValues reads a parameters file. Values referencing a file, like @./path,
+// are replaced with its contents like in the configuration, relative to the parameters file.
+func loadParameterValues(path string) (*parameterValues, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.NewConfigError("parameters_file", err)
+	}
+
+	var values parameterValues
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&values); err != nil && err != io.EOF {
+		return nil, errors.NewConfigError("parameters_file", fmt.Errorf("failed to parse %s: %v", path, err))
+	}
+
+	baseDir := filepath.Dir(path)
+	if err := readFileValues(values.Parameters, baseDir); err != nil {
+		return nil, err
+	}
+	for _, params := range values.Checks {
+		if err := readFileValues(params, baseDir); err != nil {
+			return nil, err
+		}
+	}
+	return &values, nil
+}
+
+// readFileValues replaces the parameter values referencing a file with the contents of
+// the file
+func readFileValues(params map[string]string, baseDir string) error {
+	for key, value := range params {
+		if !isFileParam(value) {
+			continue
+		}
+		resolved, err := readFileParam(value, baseDir)
+		if err != nil {
+			return errors.NewConfigError("parameters_file",
+				fmt.Errorf("failed to read parameter %q from file: %v", key, err))
+		}
+		params[key] = resolved
+	}
+	return nil
+}
+
+// applyParameterValues fills in the parameters of the checks that the configuration does
+// not set from the parameter values. Values for a check by name take precedence over the
+// shared values. Every check named by the values must exist and take parameters.
+func applyParameterValues(checkItems []types.CheckItem, values *parameterValues) error {
+	applied := make(map[string]bool, len(values.Checks))
+	for i := range checkItems {
+		if err := applyCheckValues(&checkItems[i], values, applied); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(values.Checks)) {
+		if !applied[name] {
+			return errors.NewConfigError("parameters_file",
+				fmt.Errorf("parameters file sets parameters of check %q, which does not exist", name))
+		}
+	}
+	return nil
+}
+
+// applyCheckValues applies the parameter values to a check and its sub-checks, recording
+// the names of the checks that had values of their own in applied
+func applyCheckValues(check *types.CheckItem, values *parameterValues, applied map[string]bool) error {
+	own, ok := values.Checks[check.Name]
+	if ok {
+		applied[check.Name] = true
+		if check.Type == "command" {
+			return errors.NewConfigError("parameters_file",
+				fmt.Errorf("parameters file sets parameters of command check %q, which does not take parameters", check.Name))
+		}
+	}
+
+	var declared map[string]bool
+	if check.Type != "command" && len(values.Parameters) > 0 {
+		if registered, err := checks.Get(check.Type); err == nil {
+			declared = make(map[string]bool, len(registered.Parameters))
+			for _, param := range registered.Parameters {
+				declared[param.Name] = true
+			}
+		}
+	}
+
+	var params map[string]string
+	set := func(key, value string) {
+		if _, ok := check.Parameters[key]; ok {
+			return
+		}
+		if params == nil {
+			// Copy the parameters, which may be shared with other checks
+			params = maps.Clone(check.Parameters)
+			if params == nil {
+				params = make(map[string]string)
+			}
+		}
+		if _, ok := params[key]; !ok {
+			params[key] = value
+		}
+	}
+	for key, value := range own {
+		set(key, value)
+	}
+	for key, value := range values.Parameters {
+		if declared[key] {
+			set(key, value)
+		}
+	}
+	if params != nil {
+		check.Parameters = params
+	}
+
+	if len(check.Checks) > 0 {
+		check.Checks = slices.Clone(check.Checks)
+		for i := range check.Checks {
+			if err := applyCheckValues(&check.Checks[i], values, applied); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
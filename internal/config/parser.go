@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"github.com/seastar-consulting/checkers/types"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFormat is the format of configuration files whose extension is not registered
+const DefaultFormat = "yaml"
+
+// Parser decodes the contents of a configuration file
+type Parser interface {
+	Parse(data []byte, config *types.Config) error
+}
+
+// ParserFunc is a function that implements Parser
+type ParserFunc func(data []byte, config *types.Config) error
+
+// Parse implements Parser
+func (f ParserFunc) Parse(data []byte, config *types.Config) error {
+	return f(data, config)
+}
+
+var (
+	parsers    = make(map[string]Parser)
+	extensions = make(map[string]string)
+	parsersMu  sync.RWMutex
+)
+
+func init() {
+	RegisterParser("yaml", ParserFunc(func(data []byte, config *types.Config) error {
+		return yaml.Unmarshal(data, config)
+	}), ".yaml", ".yml")
+	RegisterParser("toml", ParserFunc(func(data []byte, config *types.Config) error {
+		_, err := toml.Decode(string(data), config)
+		return err
+	}), ".toml")
+}
+
+// RegisterParser adds a parser for a configuration format, which is used for files with
+// one of the given extensions or when the format is selected explicitly
+func RegisterParser(format string, parser Parser, exts ...string) {
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+	parsers[format] = parser
+	for _, ext := range exts {
+		extensions[strings.ToLower(ext)] = format
+	}
+}
+
+// Formats returns the sorted names of all registered configuration formats
+func Formats() []string {
+	parsersMu.RLock()
+	defer parsersMu.RUnlock()
+	return sortedKeys(parsers)
+}
+
+// Extensions returns the sorted file extensions of all registered configuration formats,
+// without the leading dot
+func Extensions() []string {
+	parsersMu.RLock()
+	defer parsersMu.RUnlock()
+	exts := make([]string, 0, len(extensions))
+	for ext := range extensions {
+		exts = append(exts, strings.TrimPrefix(ext, "."))
+	}
+	sort.Strings(exts)
+	return exts
+}
+
+// parserFor returns the parser of the format, or of the path's extension if no format
+// is given, falling back to the default format
+func parserFor(format, path string) (Parser, error) {
+	parsersMu.RLock()
+	defer parsersMu.RUnlock()
+	if format == "" {
+		var ok bool
+		if format, ok = extensions[strings.ToLower(filepath.Ext(path))]; !ok {
+			format = DefaultFormat
+		}
+	}
+	parser, ok := parsers[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported configuration format %q (supported formats: %s)",
+			format, strings.Join(sortedKeys(parsers), ", "))
+	}
+	return parser, nil
+}
+
+// sortedKeys returns the sorted keys of the parsers
+func sortedKeys(m map[string]Parser) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
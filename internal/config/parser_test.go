@@ -0,0 +1,131 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+func TestManager_LoadFormats(t *testing.T) {
+	yamlConfig := `
+timeout: 1m
+checks:
+  - name: Check {{ .host }}
+    type: command
+    command: ping -c 1 {{ .host }}
+    timeout: 5s
+    items:
+      - host: example.com
+  - name: Hosts file
+    type: os.file_exists
+    parameters:
+      path: /etc/hosts
+`
+	tomlConfig := `
+timeout = "1m"
+
+[[checks]]
+name = "Check {{ .host }}"
+type = "command"
+command = "ping -c 1 {{ .host }}"
+timeout = "5s"
+items = [{ host = "example.com" }]
+
+[[checks]]
+name = "Hosts file"
+type = "os.file_exists"
+parameters = { path = "/etc/hosts" }
+`
+	timeout := time.Minute
+	checkTimeout := 5 * time.Second
+	want := &types.Config{
+		Timeout: &timeout,
+		Checks: []types.CheckItem{
+			{
+				Name:       "Check example.com",
+				Type:       "command",
+				Command:    "ping -c 1 example.com",
+				Timeout:    &checkTimeout,
+				Parameters: map[string]string{"host": "example.com"},
+			},
+			{
+				Name:       "Hosts file",
+				Type:       "os.file_exists",
+				Parameters: map[string]string{"path": "/etc/hosts"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		fileName string
+		content  string
+		format   string
+		wantErr  string
+	}{
+		{name: "yaml by extension", fileName: "checks.yaml", content: yamlConfig},
+		{name: "yml by extension", fileName: "checks.yml", content: yamlConfig},
+		{name: "toml by extension", fileName: "checks.toml", content: tomlConfig},
+		{name: "yaml by default", fileName: "checks.conf", content: yamlConfig},
+		{name: "explicit format", fileName: "checks.conf", content: tomlConfig, format: "toml"},
+		{name: "explicit format overrides extension", fileName: "checks.yaml", content: tomlConfig, format: "toml"},
+		{name: "unknown format", fileName: "checks.yaml", content: yamlConfig, format: "ini", wantErr: `unsupported configuration format "ini"`},
+		{name: "invalid toml", fileName: "checks.toml", content: "checks = [", wantErr: "parse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), tt.fileName)
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+
+			manager := NewManager(configPath)
+			manager.SetFormat(tt.format)
+			got, err := manager.Load()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Load() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Load() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestRegisterParser(t *testing.T) {
+	RegisterParser("test", ParserFunc(func(data []byte, config *types.Config) error {
+		config.Checks = []types.CheckItem{{Name: string(data), Type: "command", Command: "true"}}
+		return nil
+	}), ".test")
+	defer func() {
+		delete(parsers, "test")
+		delete(extensions, ".test")
+	}()
+
+	configPath := filepath.Join(t.TempDir(), "checks.TEST")
+	if err := os.WriteFile(configPath, []byte("from test parser"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	config, err := NewManager(configPath).Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+	if config.Checks[0].Name != "from test parser" {
+		t.Errorf("Load() used the wrong parser, got check %q", config.Checks[0].Name)
+	}
+	if got := Formats(); !reflect.DeepEqual(got, []string{"test", "toml", "yaml"}) {
+		t.Errorf("Formats() = %v", got)
+	}
+}
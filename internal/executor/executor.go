@@ -3,9 +3,11 @@ package executor
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,8 +18,20 @@ import (
 
 // Executor handles the execution of checks
 type Executor struct {
-	timeout   time.Duration
-	processor *processor.Processor
+	timeout      time.Duration
+	typeTimeouts bool
+	profile      bool
+	processor    *processor.Processor
+}
+
+// executorKey is the context key of the executor running a check
+type executorKey struct{}
+
+// FromContext returns the executor running the check of the context, so checks running
+// other checks can run them the same way, or nil if the context is not of a check
+func FromContext(ctx context.Context) *Executor {
+	e, _ := ctx.Value(executorKey{}).(*Executor)
+	return e
 }
 
 // NewExecutor creates a new Executor instance
@@ -28,59 +42,209 @@ func NewExecutor(timeout time.Duration) *Executor {
 	}
 }
 
-// ExecuteCheck executes a single check and returns the result
+// SetTypeTimeouts makes checks without their own timeout use the default timeout of
+// their check type, if it declares one, instead of the executor's timeout. It is
+// enabled when the executor's timeout is a default rather than set by the user.
+func (e *Executor) SetTypeTimeouts(enabled bool) {
+	e.typeTimeouts = enabled
+}
+
+// SetProfile enables recording the CPU time and peak memory of command checks
+func (e *Executor) SetProfile(enabled bool) {
+	e.profile = enabled
+}
+
+// CheckTimeout returns the timeout of a check: its own timeout if set, otherwise the
+// default timeout of its check type if type timeouts are enabled, otherwise the
+// executor's timeout
+func (e *Executor) CheckTimeout(check types.CheckItem) time.Duration {
+	if check.Timeout != nil {
+		return *check.Timeout
+	}
+	if e.typeTimeouts {
+		if registered, err := checks.Get(check.Type); err == nil && registered.DefaultTimeout > 0 {
+			return registered.DefaultTimeout
+		}
+	}
+	return e.timeout
+}
+
+// ExecuteCheck executes a single check and returns the result, recording when the check
+// started and finished unless it was cancelled. The results of checks producing multiple
+// results are summarized in one result.
 func (e *Executor) ExecuteCheck(ctx context.Context, check types.CheckItem) (types.CheckResult, error) {
-	// Create a new context with timeout
-	ctxWithTimeout, cancel := context.WithTimeout(ctx, e.timeout)
+	results, err := e.ExecuteCheckResults(ctx, check)
+	if len(results) != 1 {
+		return summarizeResults(check, results), err
+	}
+	return results[0], err
+}
+
+// ExecuteCheckResults executes a single check and returns its results, recording when the
+// check started and finished unless it was cancelled. Checks registered with a
+// MultiCheckFunc return a result per object they inspected, all other checks one result.
+func (e *Executor) ExecuteCheckResults(ctx context.Context, check types.CheckItem) ([]types.CheckResult, error) {
+	startedAt := time.Now()
+	results, err := e.executeCheck(ctx, check)
+	if err != nil && err != context.DeadlineExceeded {
+		return results, err
+	}
+	finishedAt := time.Now()
+	for i := range results {
+		results[i].StartedAt = &startedAt
+		results[i].FinishedAt = &finishedAt
+	}
+	return results, err
+}
+
+// executeCheck executes a single check and returns its results
+func (e *Executor) executeCheck(ctx context.Context, check types.CheckItem) ([]types.CheckResult, error) {
+	// Create a new context with timeout, preferring the check's own timeout if set
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, e.CheckTimeout(check))
 	defer cancel()
 
 	// Check if this is a native check
-	if checkFunc, ok := checks.Registry[check.Type]; ok {
-		// Run internal check with timeout
-		resultChan := make(chan types.CheckResult, 1)
-		errChan := make(chan error, 1)
-
-		go func() {
-			result, err := checkFunc.Func(check)
-			resultChan <- result
-			errChan <- err
-		}()
-
-		// Wait for either completion or timeout
-		select {
-		case <-ctxWithTimeout.Done():
-			if ctxWithTimeout.Err() == context.DeadlineExceeded {
-				return types.CheckResult{
-					Name:   check.Name,
-					Type:   check.Type,
-					Status: types.Error,
-					Output: "command execution timed out",
-				}, context.DeadlineExceeded
-			}
-			return types.CheckResult{}, ctxWithTimeout.Err()
-		case err := <-errChan:
-			result := <-resultChan
-			if err != nil {
-				return types.CheckResult{
-					Name:   check.Name,
-					Type:   check.Type,
-					Status: types.Error,
-					Error:  fmt.Sprintf("failed to execute check: %v", err),
-				}, nil
-			}
+	if registered, ok := checks.Registry[check.Type]; ok {
+		return executeNative(context.WithValue(ctxWithTimeout, executorKey{}, e), check, registered)
+	}
+	result, err := e.executeCommand(ctxWithTimeout, check)
+	return []types.CheckResult{result}, err
+}
+
+// executeNative runs a registered check until it completes or the context is done
+func executeNative(ctx context.Context, check types.CheckItem, registered checks.Check) ([]types.CheckResult, error) {
+	// Run internal check with timeout
+	resultsChan := make(chan []types.CheckResult, 1)
+	errChan := make(chan error, 1)
 
-			// Add name and type if not set
-			if result.Name == "" {
-				result.Name = check.Name
+	go func() {
+		var results []types.CheckResult
+		var err error
+		if registered.MultiFunc != nil {
+			results, err = registered.MultiFunc(check)
+		} else if registered.ContextFunc != nil {
+			var result types.CheckResult
+			result, err = registered.ContextFunc(ctx, check)
+			results = []types.CheckResult{result}
+		} else {
+			var result types.CheckResult
+			result, err = registered.Func(check)
+			results = []types.CheckResult{result}
+		}
+		resultsChan <- results
+		errChan <- err
+	}()
+
+	// Wait for either completion or timeout
+	select {
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			return []types.CheckResult{{
+				Name:   check.Name,
+				Type:   check.Type,
+				Status: types.Error,
+				Output: "command execution timed out",
+				Retry:  types.Retryable,
+			}}, context.DeadlineExceeded
+		}
+		return []types.CheckResult{{}}, ctx.Err()
+	case err := <-errChan:
+		results := <-resultsChan
+		if err != nil {
+			result := types.CheckResult{
+				Name:   check.Name,
+				Type:   check.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("failed to execute check: %v", err),
+			}
+			if isParameterError(err.Error()) {
+				result.Retry = types.Terminal
 			}
-			if result.Type == "" {
-				result.Type = check.Type
+			return []types.CheckResult{result}, nil
+		}
+		for i := range results {
+			if results[i].Status == types.Error && results[i].Retry == "" && isParameterError(results[i].Error) {
+				results[i].Retry = types.Terminal
 			}
+		}
+		if registered.MultiFunc != nil {
+			return childResults(check, results), nil
+		}
 
-			return result, nil
+		// Add name and type if not set
+		result := results[0]
+		if result.Name == "" {
+			result.Name = check.Name
 		}
+		if result.Type == "" {
+			result.Type = check.Type
+		}
+
+		return []types.CheckResult{result}, nil
 	}
+}
+
+// isParameterError reports whether an error message tells that a parameter of a check is
+// missing or invalid, which running the check again does not fix
+func isParameterError(message string) bool {
+	return strings.Contains(message, "parameter is required") || strings.HasPrefix(message, "Invalid value for '")
+}
 
+// childResults names the results of a check producing multiple results after the check,
+// so they are distinct from the results of other checks and from each other. Results
+// without a name, or repeating one, are numbered instead.
+func childResults(check types.CheckItem, results []types.CheckResult) []types.CheckResult {
+	if len(results) == 0 {
+		return []types.CheckResult{{
+			Name:   check.Name,
+			Type:   check.Type,
+			Status: types.Success,
+			Output: "check produced no results",
+		}}
+	}
+	seen := make(map[string]bool, len(results))
+	for i := range results {
+		name := results[i].Name
+		if name == "" || seen[name] {
+			name = strconv.Itoa(i + 1)
+		}
+		seen[name] = true
+		results[i].Name = check.Name + ": " + name
+		results[i].Type = check.Type
+		results[i].Parent = check.Name
+	}
+	return results
+}
+
+// summarizeResults combines the results of a check producing multiple results into one
+// result with the most severe status among them
+func summarizeResults(check types.CheckItem, results []types.CheckResult) types.CheckResult {
+	severity := map[types.CheckStatus]int{types.Warning: 1, types.Failure: 2, types.Error: 3}
+	summary := types.CheckResult{Name: check.Name, Type: check.Type, Status: types.Success}
+	var notSucceeded []string
+	for _, result := range results {
+		if severity[result.Status] == 0 {
+			continue
+		}
+		notSucceeded = append(notSucceeded, result.Name)
+		if severity[result.Status] > severity[summary.Status] {
+			summary.Status = result.Status
+		}
+	}
+	if len(results) > 0 {
+		summary.StartedAt = results[0].StartedAt
+		summary.FinishedAt = results[0].FinishedAt
+	}
+	if len(notSucceeded) == 0 {
+		summary.Output = fmt.Sprintf("All %d results succeeded", len(results))
+	} else {
+		summary.Output = fmt.Sprintf("%d of %d results did not succeed: %s", len(notSucceeded), len(results), strings.Join(notSucceeded, ", "))
+	}
+	return summary
+}
+
+// executeCommand runs a command check until it completes or the context is done
+func (e *Executor) executeCommand(ctx context.Context, check types.CheckItem) (types.CheckResult, error) {
 	// Handle command-based check
 	if check.Type != "command" {
 		return types.CheckResult{
@@ -101,17 +265,40 @@ func (e *Executor) ExecuteCheck(ctx context.Context, check types.CheckItem) (typ
 	}
 
 	// Prepare command
-	cmd := exec.CommandContext(ctxWithTimeout, "bash", "-c", "set -eo pipefail; "+check.Command)
-	if check.Parameters != nil {
-		for key, value := range check.Parameters {
-			cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, value))
-		}
+	shellOptions := check.ShellOptions
+	if shellOptions == "" {
+		shellOptions = DefaultShellOptions
+	}
+	cmd := exec.CommandContext(ctx, "bash", "-c", "set "+shellOptions+"; "+check.Command)
+	// Parameters are passed as environment variables for backwards compatibility (deprecated),
+	// variables from env take precedence
+	for key, value := range check.Parameters {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, value))
+	}
+	for key, value := range check.Env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, value))
 	}
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
+	// Remove the output file of an earlier run, so that its stale result is never read,
+	// and the output file of this run once the check completes unless it is kept
+	if check.OutputFile != "" {
+		if err := os.Remove(check.OutputFile); err != nil && !os.IsNotExist(err) {
+			return types.CheckResult{
+				Name:   check.Name,
+				Type:   check.Type,
+				Status: types.Error,
+				Error:  fmt.Sprintf("failed to remove output file: %v", err),
+			}, nil
+		}
+		if !check.KeepOutputFile {
+			defer os.Remove(check.OutputFile)
+		}
+	}
+
 	// Start command
 	if err := cmd.Start(); err != nil {
 		return types.CheckResult{
@@ -130,64 +317,116 @@ func (e *Executor) ExecuteCheck(ctx context.Context, check types.CheckItem) (typ
 
 	// Wait for either command completion or timeout
 	select {
-	case <-ctxWithTimeout.Done():
+	case <-ctx.Done():
 		// Kill the process if it's still running
 		if cmd.Process != nil {
 			cmd.Process.Kill()
 		}
-		if ctxWithTimeout.Err() == context.DeadlineExceeded {
+		if ctx.Err() == context.DeadlineExceeded {
 			return types.CheckResult{
 				Name:   check.Name,
 				Type:   check.Type,
 				Status: types.Error,
 				Output: "command execution timed out",
+				Retry:  types.Retryable,
 			}, context.DeadlineExceeded
 		}
-		return types.CheckResult{}, ctxWithTimeout.Err()
+		return types.CheckResult{}, ctx.Err()
 	case err := <-done:
-		// Get command output
-		output := strings.TrimSpace(stdout.String())
-		if stderr.Len() > 0 {
-			if output != "" {
-				output += "\n"
+		result := e.commandResult(check, stdout.String(), stderr.String(), err)
+		if e.profile {
+			result.Resources = resourceUsage(cmd.ProcessState)
+		}
+		return result, nil
+	}
+}
+
+// cannotRun reports whether an exit code means the command did not run: the shell could
+// not find (127) or execute (126) it, or it was killed by a signal (-1)
+func cannotRun(exitCode int) bool {
+	return exitCode == 126 || exitCode == 127 || exitCode < 0
+}
+
+// commandResult creates the result of a command that exited with the given error from
+// its output
+func (e *Executor) commandResult(check types.CheckItem, stdout, stderr string, err error) types.CheckResult {
+	// Get command output. The stderr of a failed command is kept even when ignored, since
+	// it usually explains the failure.
+	output := strings.TrimSpace(stdout)
+	if stderr != "" && (err != nil || !check.IgnoreStderr) {
+		if output != "" {
+			output += "\n"
+		}
+		output += strings.TrimSpace(stderr)
+	}
+
+	// Handle command execution errors
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			// Assertions report a failure, but a command that could not run is still an error
+			status := types.Error
+			if check.ExitAsFailure && !cannotRun(exitErr.ExitCode()) {
+				status = types.Failure
+			}
+			// Exit codes are only classified for checks that declare the retryable ones
+			var retry types.Retryability
+			if len(check.RetryableExitCodes) > 0 {
+				retry = types.Terminal
+				if slices.Contains(check.RetryableExitCodes, exitErr.ExitCode()) {
+					retry = types.Retryable
+				}
 			}
-			output += strings.TrimSpace(stderr.String())
+			// Create a direct CheckResult for exit error
+			return types.CheckResult{
+				Name:   check.Name,
+				Type:   check.Type,
+				Status: status,
+				Output: output,
+				Error:  fmt.Sprintf("command failed with exit code %d", exitErr.ExitCode()),
+				Retry:  retry,
+			}
+		}
+		// Create a direct CheckResult for other errors
+		return types.CheckResult{
+			Name:   check.Name,
+			Type:   check.Type,
+			Status: types.Error,
+			Error:  err.Error(),
 		}
+	}
 
-		// Handle command execution errors
+	// Read the result from the output file instead of stdout if configured
+	if check.OutputFile != "" {
+		data, err := os.ReadFile(check.OutputFile)
 		if err != nil {
-			if exitErr, ok := err.(*exec.ExitError); ok {
-				// Create a direct CheckResult for exit error
-				return types.CheckResult{
-					Name:   check.Name,
-					Type:   check.Type,
-					Status: types.Error,
-					Output: output,
-					Error:  fmt.Sprintf("command failed with exit code %d", exitErr.ExitCode()),
-				}, nil
-			}
-			// Create a direct CheckResult for other errors
 			return types.CheckResult{
 				Name:   check.Name,
 				Type:   check.Type,
 				Status: types.Error,
-				Error:  err.Error(),
-			}, nil
+				Output: output,
+				Error:  fmt.Sprintf("failed to read output file: %v", err),
+			}
 		}
+		output = strings.TrimSpace(string(data))
+	}
 
-		// Try to parse output as JSON first
-		var jsonOutput map[string]interface{}
-		if err := json.Unmarshal([]byte(output), &jsonOutput); err == nil {
-			// If output is valid JSON, let processor handle it
-			return e.processor.ProcessOutput(check.Name, check.Type, jsonOutput), nil
-		}
+	// Compare a value of JSON output instead of parsing it as a result
+	if check.JSONPath != "" {
+		return jsonPathResult(check, output)
+	}
 
-		// If not JSON, create a simple output map
-		rawOutput := map[string]interface{}{
-			"output": output,
+	// Parse the output according to the check's output format
+	parsed, err := e.processor.ParseOutput(check.OutputFormat, output)
+	if err != nil {
+		return types.CheckResult{
+			Name:   check.Name,
+			Type:   check.Type,
+			Status: types.Error,
+			Output: output,
+			Error:  err.Error(),
 		}
-
-		// Process the raw output into a CheckResult
-		return e.processor.ProcessOutput(check.Name, check.Type, rawOutput), nil
 	}
+
+	// Process the parsed output into a CheckResult
+	return e.processor.ProcessOutput(check.Name, check.Type, parsed)
 }
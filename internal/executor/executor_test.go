@@ -2,9 +2,13 @@ package executor
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/seastar-consulting/checkers/checks"
 	"github.com/seastar-consulting/checkers/types"
 
 	"github.com/stretchr/testify/assert"
@@ -80,6 +84,28 @@ func TestExecutor_ExecuteCheck(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "command with env",
+			check: types.CheckItem{
+				Name:    "env-test",
+				Type:    "command",
+				Command: "echo $TEST_PARAM $TEST_ENV",
+				Parameters: map[string]string{
+					"TEST_PARAM": "param-value",
+					"TEST_ENV":   "overridden",
+				},
+				Env: map[string]string{
+					"TEST_ENV": "env-value",
+				},
+			},
+			want: types.CheckResult{
+				Name:   "env-test",
+				Type:   "command",
+				Status: types.Success,
+				Output: "param-value env-value",
+			},
+			wantErr: false,
+		},
 		{
 			name: "command exit code 1",
 			check: types.CheckItem{
@@ -96,6 +122,122 @@ func TestExecutor_ExecuteCheck(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "command exit code 1 as failure",
+			check: types.CheckItem{
+				Name:          "test",
+				Type:          "command",
+				Command:       "echo 'missing /etc/app.conf' && test -f /nonexistent/app.conf",
+				ExitAsFailure: true,
+			},
+			want: types.CheckResult{
+				Name:   "test",
+				Type:   "command",
+				Status: types.Failure,
+				Output: "missing /etc/app.conf",
+				Error:  "command failed with exit code 1",
+			},
+			wantErr: false,
+		},
+		{
+			name: "command not found with exit as failure",
+			check: types.CheckItem{
+				Name:          "test",
+				Type:          "command",
+				Command:       "nonexistentcommand",
+				ExitAsFailure: true,
+			},
+			want: types.CheckResult{
+				Name:   "test",
+				Type:   "command",
+				Status: types.Error,
+				Output: "bash: line 1: nonexistentcommand: command not found",
+				Error:  "command failed with exit code 127",
+			},
+			wantErr: false,
+		},
+		{
+			name: "retryable exit code",
+			check: types.CheckItem{
+				Name:               "test",
+				Type:               "command",
+				Command:            "exit 75",
+				RetryableExitCodes: []int{75, 111},
+			},
+			want: types.CheckResult{
+				Name:   "test",
+				Type:   "command",
+				Status: types.Error,
+				Error:  "command failed with exit code 75",
+				Retry:  types.Retryable,
+			},
+			wantErr: false,
+		},
+		{
+			name: "terminal exit code",
+			check: types.CheckItem{
+				Name:               "test",
+				Type:               "command",
+				Command:            "exit 77",
+				RetryableExitCodes: []int{75, 111},
+			},
+			want: types.CheckResult{
+				Name:   "test",
+				Type:   "command",
+				Status: types.Error,
+				Error:  "command failed with exit code 77",
+				Retry:  types.Terminal,
+			},
+			wantErr: false,
+		},
+		{
+			name: "stderr included in the output by default",
+			check: types.CheckItem{
+				Name:    "test",
+				Type:    "command",
+				Command: `echo 'downloading...' >&2 && echo '{"status":"success","output":"up to date"}'`,
+			},
+			want: types.CheckResult{
+				Name:   "test",
+				Type:   "command",
+				Status: types.Success,
+				Output: "{\"status\":\"success\",\"output\":\"up to date\"}\ndownloading...",
+			},
+			wantErr: false,
+		},
+		{
+			name: "stderr ignored",
+			check: types.CheckItem{
+				Name:         "test",
+				Type:         "command",
+				Command:      `echo 'downloading...' >&2 && echo '{"status":"success","output":"up to date"}'`,
+				IgnoreStderr: true,
+			},
+			want: types.CheckResult{
+				Name:   "test",
+				Type:   "command",
+				Status: types.Success,
+				Output: "up to date",
+			},
+			wantErr: false,
+		},
+		{
+			name: "stderr of a failed command kept when ignored",
+			check: types.CheckItem{
+				Name:         "test",
+				Type:         "command",
+				Command:      "echo 'connection refused' >&2 && exit 2",
+				IgnoreStderr: true,
+			},
+			want: types.CheckResult{
+				Name:   "test",
+				Type:   "command",
+				Status: types.Error,
+				Output: "connection refused",
+				Error:  "command failed with exit code 2",
+			},
+			wantErr: false,
+		},
 		{
 			name: "pipeline failure",
 			check: types.CheckItem{
@@ -112,6 +254,39 @@ func TestExecutor_ExecuteCheck(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "pipeline failure ignored without pipefail",
+			check: types.CheckItem{
+				Name:         "test",
+				Type:         "command",
+				Command:      `exit 1 | echo '{"status":"success","output":"hello"}'`,
+				ShellOptions: "-e +o pipefail",
+			},
+			want: types.CheckResult{
+				Name:   "test",
+				Type:   "command",
+				Status: types.Success,
+				Output: "hello",
+			},
+			wantErr: false,
+		},
+		{
+			name: "unset variable with nounset",
+			check: types.CheckItem{
+				Name:         "test",
+				Type:         "command",
+				Command:      `echo "$UNDEFINED_VARIABLE"`,
+				ShellOptions: "-euo pipefail",
+			},
+			want: types.CheckResult{
+				Name:   "test",
+				Type:   "command",
+				Status: types.Error,
+				Output: "bash: line 1: UNDEFINED_VARIABLE: unbound variable",
+				Error:  "command failed with exit code 1",
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalid json output",
 			check: types.CheckItem{
@@ -127,6 +302,39 @@ func TestExecutor_ExecuteCheck(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "keyvalue output format",
+			check: types.CheckItem{
+				Name:         "keyvalue-test",
+				Type:         "command",
+				Command:      `printf 'status=fail\noutput=service down\n'`,
+				OutputFormat: "keyvalue",
+			},
+			want: types.CheckResult{
+				Name:   "keyvalue-test",
+				Type:   "command",
+				Status: types.Failure,
+				Output: "service down",
+			},
+			wantErr: false,
+		},
+		{
+			name: "json output format with invalid JSON",
+			check: types.CheckItem{
+				Name:         "json-test",
+				Type:         "command",
+				Command:      "echo not-json",
+				OutputFormat: "json",
+			},
+			want: types.CheckResult{
+				Name:   "json-test",
+				Type:   "command",
+				Status: types.Error,
+				Output: "not-json",
+				Error:  "failed to parse output as JSON: invalid character 'o' in literal null (expecting 'u')",
+			},
+			wantErr: false,
+		},
 		{
 			name: "unsupported check type",
 			check: types.CheckItem{
@@ -154,11 +362,152 @@ func TestExecutor_ExecuteCheck(t *testing.T) {
 			}
 
 			assert.NoError(t, err)
+
+			// Timestamps are checked separately as they vary between runs
+			if assert.NotNil(t, got.StartedAt) && assert.NotNil(t, got.FinishedAt) {
+				assert.False(t, got.FinishedAt.Before(*got.StartedAt))
+			}
+			got.StartedAt, got.FinishedAt = nil, nil
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
+func TestExecutor_ExecuteCheckTimestamps(t *testing.T) {
+	e := NewExecutor(5 * time.Second)
+	before := time.Now()
+	got, err := e.ExecuteCheck(context.Background(), types.CheckItem{
+		Name:    "sleep-test",
+		Type:    "command",
+		Command: "sleep 0.1 && echo ok",
+	})
+	after := time.Now()
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, got.StartedAt) && assert.NotNil(t, got.FinishedAt) {
+		assert.False(t, got.StartedAt.Before(before))
+		assert.False(t, got.FinishedAt.After(after))
+		assert.GreaterOrEqual(t, got.FinishedAt.Sub(*got.StartedAt), 100*time.Millisecond)
+	}
+}
+
+func TestExecutor_ExecuteCheckOutputFile(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		command  string
+		file     string
+		stale    bool
+		keep     bool
+		want     types.CheckResult
+		wantFile bool
+	}{
+		{
+			name:    "result read from file and file removed",
+			command: `echo 'progress' && echo '{"status":"failure","output":"2 vulnerabilities"}' > "$OUT"`,
+			file:    "removed.json",
+			want: types.CheckResult{
+				Name:   "test",
+				Type:   "command",
+				Status: types.Failure,
+				Output: "2 vulnerabilities",
+			},
+		},
+		{
+			name:     "file kept",
+			command:  `echo '{"status":"success","output":"no vulnerabilities"}' > "$OUT"`,
+			file:     "kept.json",
+			keep:     true,
+			wantFile: true,
+			want: types.CheckResult{
+				Name:   "test",
+				Type:   "command",
+				Status: types.Success,
+				Output: "no vulnerabilities",
+			},
+		},
+		{
+			name:    "file not written",
+			command: "echo 'nothing to report'",
+			file:    "missing.json",
+			want: types.CheckResult{
+				Name:   "test",
+				Type:   "command",
+				Status: types.Error,
+				Output: "nothing to report",
+				Error:  "failed to read output file: open " + filepath.Join(tmpDir, "missing.json") + ": no such file or directory",
+			},
+		},
+		{
+			name:    "stale file of an earlier run not read",
+			command: "echo 'nothing to report'",
+			file:    "stale.json",
+			stale:   true,
+			keep:    true,
+			want: types.CheckResult{
+				Name:   "test",
+				Type:   "command",
+				Status: types.Error,
+				Output: "nothing to report",
+				Error:  "failed to read output file: open " + filepath.Join(tmpDir, "stale.json") + ": no such file or directory",
+			},
+		},
+		{
+			name:    "file removed when the command fails",
+			command: `echo '{"status":"success","output":"partial"}' > "$OUT" && exit 2`,
+			file:    "failed.json",
+			want: types.CheckResult{
+				Name:   "test",
+				Type:   "command",
+				Status: types.Error,
+				Error:  "command failed with exit code 2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, tt.file)
+			if tt.stale {
+				err := os.WriteFile(path, []byte(`{"status":"success","output":"stale"}`), 0644)
+				assert.NoError(t, err)
+			}
+			e := NewExecutor(5 * time.Second)
+			got, err := e.ExecuteCheck(context.Background(), types.CheckItem{
+				Name:           "test",
+				Type:           "command",
+				Command:        tt.command,
+				Env:            map[string]string{"OUT": path},
+				OutputFile:     path,
+				KeepOutputFile: tt.keep,
+			})
+			assert.NoError(t, err)
+
+			got.StartedAt, got.FinishedAt = nil, nil
+			assert.Equal(t, tt.want, got)
+
+			_, statErr := os.Stat(path)
+			assert.Equal(t, tt.wantFile, statErr == nil)
+		})
+	}
+
+	// The file is also removed when the command times out
+	path := filepath.Join(tmpDir, "timeout.json")
+	timeout := 100 * time.Millisecond
+	_, err := NewExecutor(5*time.Second).ExecuteCheck(context.Background(), types.CheckItem{
+		Name:       "test",
+		Type:       "command",
+		Command:    `echo '{"status":"success","output":"partial"}' > "$OUT" && sleep 1`,
+		Env:        map[string]string{"OUT": path},
+		OutputFile: path,
+		Timeout:    &timeout,
+	})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	_, statErr := os.Stat(path)
+	assert.True(t, os.IsNotExist(statErr))
+}
+
 func TestExecutor_ExecuteCheckCancellation(t *testing.T) {
 	e := NewExecutor(5 * time.Second)
 	check := types.CheckItem{
@@ -190,3 +539,241 @@ func TestExecutor_ExecuteCheckCancellation(t *testing.T) {
 		t.Fatal("test timed out")
 	}
 }
+
+func TestExecutor_ExecuteCheckTimeout(t *testing.T) {
+	shortTimeout := 100 * time.Millisecond
+
+	tests := []struct {
+		name    string
+		check   types.CheckItem
+		wantErr error
+	}{
+		{
+			name: "check timeout overrides executor timeout",
+			check: types.CheckItem{
+				Name:    "slow-check",
+				Type:    "command",
+				Command: "sleep 1",
+				Timeout: &shortTimeout,
+			},
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name: "executor timeout applies without check timeout",
+			check: types.CheckItem{
+				Name:    "slow-check",
+				Type:    "command",
+				Command: "sleep 1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewExecutor(5 * time.Second)
+			_, err := e.ExecuteCheck(context.Background(), tt.check)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestExecutor_CheckTimeout(t *testing.T) {
+	checks.Register("test.slow", "Slow check used to test type timeouts", func(item types.CheckItem) (types.CheckResult, error) {
+		return types.CheckResult{}, nil
+	})
+	checks.SetDefaultTimeout("test.slow", 2*time.Minute)
+	defer delete(checks.Registry, "test.slow")
+
+	checkTimeout := 5 * time.Second
+
+	tests := []struct {
+		name         string
+		check        types.CheckItem
+		typeTimeouts bool
+		want         time.Duration
+	}{
+		{
+			name:         "type timeout applies without check timeout",
+			check:        types.CheckItem{Name: "slow", Type: "test.slow"},
+			typeTimeouts: true,
+			want:         2 * time.Minute,
+		},
+		{
+			name:         "check timeout overrides type timeout",
+			check:        types.CheckItem{Name: "slow", Type: "test.slow", Timeout: &checkTimeout},
+			typeTimeouts: true,
+			want:         checkTimeout,
+		},
+		{
+			name:  "executor timeout overrides type timeout when set by the user",
+			check: types.CheckItem{Name: "slow", Type: "test.slow"},
+			want:  30 * time.Second,
+		},
+		{
+			name:         "executor timeout for types without default timeout",
+			check:        types.CheckItem{Name: "command", Type: "command", Command: "true"},
+			typeTimeouts: true,
+			want:         30 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewExecutor(30 * time.Second)
+			e.SetTypeTimeouts(tt.typeTimeouts)
+			assert.Equal(t, tt.want, e.CheckTimeout(tt.check))
+		})
+	}
+}
+
+func TestExecutor_ExecuteCheckProfile(t *testing.T) {
+	check := types.CheckItem{
+		Name:    "busy-check",
+		Type:    "command",
+		Command: `for i in $(seq 1 20000); do :; done; echo '{"status":"success","output":"done"}'`,
+	}
+
+	e := NewExecutor(5 * time.Second)
+	result, err := e.ExecuteCheck(context.Background(), check)
+	assert.NoError(t, err)
+	assert.Nil(t, result.Resources, "resources are only recorded when profiling")
+
+	e.SetProfile(true)
+	result, err = e.ExecuteCheck(context.Background(), check)
+	assert.NoError(t, err)
+	if assert.NotNil(t, result.Resources) {
+		assert.Greater(t, result.Resources.UserSeconds+result.Resources.SystemSeconds, 0.0)
+		assert.Greater(t, result.Resources.MaxRSSBytes, int64(0))
+	}
+}
+
+func TestExecutor_ExecuteCheckResults(t *testing.T) {
+	checks.RegisterMulti("test.multi", "Check used to test multiple results", func(item types.CheckItem) ([]types.CheckResult, error) {
+		switch item.Parameters["mode"] {
+		case "empty":
+			return nil, nil
+		case "error":
+			return nil, errors.New("listing deployments failed")
+		}
+		return []types.CheckResult{
+			{Name: "deployment/web", Status: types.Success, Output: "3/3 replicas ready"},
+			{Name: "deployment/worker", Status: types.Failure, Output: "0/2 replicas ready"},
+			{Name: "deployment/web", Status: types.Success},
+			{Status: types.Success},
+		}, nil
+	})
+	defer delete(checks.Registry, "test.multi")
+
+	e := NewExecutor(5 * time.Second)
+	check := types.CheckItem{Name: "deployments", Type: "test.multi"}
+	results, err := e.ExecuteCheckResults(context.Background(), check)
+	assert.NoError(t, err)
+	var names []string
+	for _, result := range results {
+		names = append(names, result.Name)
+		assert.Equal(t, "test.multi", result.Type)
+		assert.Equal(t, "deployments", result.Parent)
+		assert.NotNil(t, result.StartedAt)
+		assert.NotNil(t, result.FinishedAt)
+	}
+	// Repeated and missing names are replaced by the position of the result
+	assert.Equal(t, []string{"deployments: deployment/web", "deployments: deployment/worker", "deployments: 3", "deployments: 4"}, names)
+	assert.Equal(t, types.Failure, results[1].Status)
+	assert.Equal(t, "0/2 replicas ready", results[1].Output)
+
+	// The results are summarized for callers expecting a single result
+	result, err := e.ExecuteCheck(context.Background(), check)
+	assert.NoError(t, err)
+	assert.Equal(t, "deployments", result.Name)
+	assert.Equal(t, types.Failure, result.Status)
+	assert.Equal(t, "1 of 4 results did not succeed: deployments: deployment/worker", result.Output)
+
+	check.Parameters = map[string]string{"mode": "empty"}
+	results, err = e.ExecuteCheckResults(context.Background(), check)
+	assert.NoError(t, err)
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, "deployments", results[0].Name)
+		assert.Equal(t, types.Success, results[0].Status)
+		assert.Equal(t, "check produced no results", results[0].Output)
+	}
+
+	check.Parameters = map[string]string{"mode": "error"}
+	results, err = e.ExecuteCheckResults(context.Background(), check)
+	assert.NoError(t, err)
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, "deployments", results[0].Name)
+		assert.Equal(t, types.Error, results[0].Status)
+		assert.Equal(t, "failed to execute check: listing deployments failed", results[0].Error)
+	}
+}
+
+func TestExecutor_ExecuteCheckRetry(t *testing.T) {
+	checks.Register("test.params", "Check used to test retry classification", func(item types.CheckItem) (types.CheckResult, error) {
+		switch item.Parameters["mode"] {
+		case "missing":
+			return types.CheckResult{Status: types.Error, Error: "url parameter is required"}, nil
+		case "invalid":
+			return types.CheckResult{Status: types.Error, Error: "Invalid value for 'port' parameter: http"}, nil
+		case "classified":
+			return types.CheckResult{Status: types.Error, Error: "url parameter is required", Retry: types.Retryable}, nil
+		case "failed":
+			return types.CheckResult{}, errors.New("Invalid value for 'port' parameter: http")
+		case "slow":
+			time.Sleep(time.Second)
+		}
+		return types.CheckResult{Status: types.Error, Error: "connection refused"}, nil
+	})
+	defer delete(checks.Registry, "test.params")
+
+	shortTimeout := 100 * time.Millisecond
+	tests := []struct {
+		name  string
+		check types.CheckItem
+		want  types.Retryability
+	}{
+		{
+			name:  "missing parameter is terminal",
+			check: types.CheckItem{Name: "params", Type: "test.params", Parameters: map[string]string{"mode": "missing"}},
+			want:  types.Terminal,
+		},
+		{
+			name:  "invalid parameter is terminal",
+			check: types.CheckItem{Name: "params", Type: "test.params", Parameters: map[string]string{"mode": "invalid"}},
+			want:  types.Terminal,
+		},
+		{
+			name:  "classification of the check is kept",
+			check: types.CheckItem{Name: "params", Type: "test.params", Parameters: map[string]string{"mode": "classified"}},
+			want:  types.Retryable,
+		},
+		{
+			name:  "invalid parameter returned as error is terminal",
+			check: types.CheckItem{Name: "params", Type: "test.params", Parameters: map[string]string{"mode": "failed"}},
+			want:  types.Terminal,
+		},
+		{
+			name:  "other errors are not classified",
+			check: types.CheckItem{Name: "params", Type: "test.params"},
+			want:  "",
+		},
+		{
+			name:  "native check timeout is retryable",
+			check: types.CheckItem{Name: "params", Type: "test.params", Parameters: map[string]string{"mode": "slow"}, Timeout: &shortTimeout},
+			want:  types.Retryable,
+		},
+		{
+			name:  "command timeout is retryable",
+			check: types.CheckItem{Name: "slow-check", Type: "command", Command: "sleep 1", Timeout: &shortTimeout},
+			want:  types.Retryable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewExecutor(5 * time.Second)
+			result, _ := e.ExecuteCheck(context.Background(), tt.check)
+			assert.Equal(t, types.Error, result.Status)
+			assert.Equal(t, tt.want, result.Retry)
+		})
+	}
+}
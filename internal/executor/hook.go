@@ -0,0 +1,69 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// RunHook runs the hook of a check for its result with the check's timeout. The hook
+// inherits the environment and the check's env, and gets the check's ID, name, type and
+// status in CHECKERS_CHECK_ID, CHECKERS_CHECK_NAME, CHECKERS_CHECK_TYPE and
+// CHECKERS_CHECK_STATUS. Hooks never change the result.
+func (e *Executor) RunHook(ctx context.Context, check types.CheckItem, result types.CheckResult) error {
+	hook := check.Hook(result.Status)
+	if hook == "" {
+		return nil
+	}
+
+	shellOptions := check.ShellOptions
+	if shellOptions == "" {
+		shellOptions = DefaultShellOptions
+	}
+	env := os.Environ()
+	for key, value := range check.Env {
+		env = append(env, fmt.Sprintf("%s=%s", key, value))
+	}
+	env = append(env,
+		"CHECKERS_CHECK_ID="+result.ID,
+		"CHECKERS_CHECK_NAME="+result.Name,
+		"CHECKERS_CHECK_TYPE="+result.Type,
+		"CHECKERS_CHECK_STATUS="+string(result.Status),
+	)
+	return runHook(ctx, hook, shellOptions, env, e.CheckTimeout(check))
+}
+
+// RunAfterRun runs a command once after all checks with the executor's timeout. It
+// inherits the environment, extended by env.
+func (e *Executor) RunAfterRun(ctx context.Context, command string, env []string) error {
+	return runHook(ctx, command, DefaultShellOptions, append(os.Environ(), env...), e.timeout)
+}
+
+// runHook runs a hook command with bash, returning its output in the error if it fails
+func runHook(ctx context.Context, command, shellOptions string, env []string, timeout time.Duration) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctxWithTimeout, "bash", "-c", "set "+shellOptions+"; "+command)
+	cmd.Env = env
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		if ctxWithTimeout.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("hook timed out after %v", timeout)
+		}
+		if out := strings.TrimSpace(output.String()); out != "" {
+			return fmt.Errorf("hook failed: %v: %s", err, out)
+		}
+		return fmt.Errorf("hook failed: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutor_RunHook(t *testing.T) {
+	tmpDir := t.TempDir()
+	marker := filepath.Join(tmpDir, "hook.out")
+	shortTimeout := 100 * time.Millisecond
+
+	tests := []struct {
+		name       string
+		check      types.CheckItem
+		status     types.CheckStatus
+		wantErr    string
+		wantMarker string
+	}{
+		{
+			name: "on_failure runs for failures",
+			check: types.CheckItem{
+				Name:      "disk",
+				Type:      "command",
+				OnSuccess: "echo success > " + marker,
+				OnFailure: `echo "$CHECKERS_CHECK_ID $CHECKERS_CHECK_NAME $CHECKERS_CHECK_TYPE $CHECKERS_CHECK_STATUS $TARGET" > ` + marker,
+				Env:       map[string]string{"TARGET": "/var"},
+			},
+			status:     types.Failure,
+			wantMarker: "disk-id disk command Failure /var\n",
+		},
+		{
+			name: "on_failure runs for errors",
+			check: types.CheckItem{
+				Name:      "disk",
+				Type:      "command",
+				OnFailure: `echo "$CHECKERS_CHECK_STATUS" > ` + marker,
+			},
+			status:     types.Error,
+			wantMarker: "Error\n",
+		},
+		{
+			name: "on_success runs for successes",
+			check: types.CheckItem{
+				Name:      "disk",
+				Type:      "command",
+				OnSuccess: "echo success > " + marker,
+				OnFailure: "echo failure > " + marker,
+			},
+			status:     types.Success,
+			wantMarker: "success\n",
+		},
+		{
+			name: "no hook for the status",
+			check: types.CheckItem{
+				Name:      "disk",
+				Type:      "command",
+				OnFailure: "echo failure > " + marker,
+			},
+			status: types.Success,
+		},
+		{
+			name: "failing hook",
+			check: types.CheckItem{
+				Name:      "disk",
+				Type:      "command",
+				OnFailure: "echo 'webhook unreachable'; exit 3",
+			},
+			status:  types.Failure,
+			wantErr: "hook failed: exit status 3: webhook unreachable",
+		},
+		{
+			name: "hook uses the check timeout",
+			check: types.CheckItem{
+				Name:      "disk",
+				Type:      "command",
+				Timeout:   &shortTimeout,
+				OnFailure: "sleep 5",
+			},
+			status:  types.Failure,
+			wantErr: "hook timed out after 100ms",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Remove(marker)
+			e := NewExecutor(time.Second)
+			result := types.CheckResult{ID: "disk-id", Name: tt.check.Name, Type: tt.check.Type, Status: tt.status}
+
+			err := e.RunHook(context.Background(), tt.check, result)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			data, err := os.ReadFile(marker)
+			if tt.wantMarker == "" {
+				assert.True(t, os.IsNotExist(err), "hook should not have run")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMarker, string(data))
+		})
+	}
+}
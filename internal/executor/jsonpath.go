@@ -0,0 +1,79 @@
+package executor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/seastar-consulting/checkers/types"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// ParseJSONPath parses a JSONPath expression in the syntax of kubectl, e.g.
+// {.items[*].name}. The braces are optional for a single expression, e.g. .status.phase.
+func ParseJSONPath(expression string) (*jsonpath.JSONPath, error) {
+	if !strings.Contains(expression, "{") {
+		expression = "{" + expression + "}"
+	}
+	j := jsonpath.New("jsonpath")
+	if err := j.Parse(expression); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// jsonPathResult evaluates the JSONPath of a command check on its output, which has to be
+// JSON, and compares the value to the expected one. Multiple values are separated by
+// spaces and objects are compared as compact JSON.
+func jsonPathResult(check types.CheckItem, output string) types.CheckResult {
+	var data interface{}
+	if err := json.Unmarshal([]byte(output), &data); err != nil {
+		return types.CheckResult{
+			Name:   check.Name,
+			Type:   check.Type,
+			Status: types.Error,
+			Output: output,
+			Error:  fmt.Sprintf("failed to parse output as JSON: %v", err),
+		}
+	}
+
+	j, err := ParseJSONPath(check.JSONPath)
+	if err != nil {
+		return types.CheckResult{
+			Name:   check.Name,
+			Type:   check.Type,
+			Status: types.Error,
+			Output: output,
+			Error:  fmt.Sprintf("invalid jsonpath %q: %v", check.JSONPath, err),
+		}
+	}
+	var value bytes.Buffer
+	if err := j.Execute(&value, data); err != nil {
+		return types.CheckResult{
+			Name:   check.Name,
+			Type:   check.Type,
+			Status: types.Error,
+			Output: output,
+			Error:  fmt.Sprintf("failed to evaluate jsonpath %q: %v", check.JSONPath, err),
+		}
+	}
+
+	actual := value.String()
+	if actual != check.Expected {
+		return types.CheckResult{
+			Name:     check.Name,
+			Type:     check.Type,
+			Status:   types.Failure,
+			Output:   fmt.Sprintf("Value at %s is '%s', expected '%s'", check.JSONPath, actual, check.Expected),
+			Expected: check.Expected,
+			Actual:   actual,
+		}
+	}
+	return types.CheckResult{
+		Name:   check.Name,
+		Type:   check.Type,
+		Status: types.Success,
+		Output: fmt.Sprintf("Value at %s is '%s'", check.JSONPath, actual),
+	}
+}
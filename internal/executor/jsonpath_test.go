@@ -0,0 +1,113 @@
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/seastar-consulting/checkers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutor_ExecuteCheckJSONPath(t *testing.T) {
+	const output = `{"status":{"phase":"Running","replicas":3},"items":[{"name":"api"},{"name":"web"}]}`
+
+	tests := []struct {
+		name     string
+		command  string
+		jsonPath string
+		expected string
+		want     types.CheckResult
+	}{
+		{
+			name:     "matching value",
+			command:  "echo '" + output + "'",
+			jsonPath: ".status.phase",
+			expected: "Running",
+			want: types.CheckResult{
+				Name:   "test",
+				Type:   "command",
+				Status: types.Success,
+				Output: "Value at .status.phase is 'Running'",
+			},
+		},
+		{
+			name:     "multiple values with braces",
+			command:  "echo '" + output + "'",
+			jsonPath: "{.items[*].name}",
+			expected: "api web",
+			want: types.CheckResult{
+				Name:   "test",
+				Type:   "command",
+				Status: types.Success,
+				Output: "Value at {.items[*].name} is 'api web'",
+			},
+		},
+		{
+			name:     "mismatching value",
+			command:  "echo '" + output + "'",
+			jsonPath: "$.status.replicas",
+			expected: "5",
+			want: types.CheckResult{
+				Name:     "test",
+				Type:     "command",
+				Status:   types.Failure,
+				Output:   "Value at $.status.replicas is '3', expected '5'",
+				Expected: "5",
+				Actual:   "3",
+			},
+		},
+		{
+			name:     "missing key",
+			command:  "echo '" + output + "'",
+			jsonPath: ".status.ready",
+			expected: "true",
+			want: types.CheckResult{
+				Name:   "test",
+				Type:   "command",
+				Status: types.Error,
+				Output: output,
+				Error:  `failed to evaluate jsonpath ".status.ready": ready is not found`,
+			},
+		},
+		{
+			name:     "output is not JSON",
+			command:  "echo 'Running'",
+			jsonPath: ".status.phase",
+			expected: "Running",
+			want: types.CheckResult{
+				Name:   "test",
+				Type:   "command",
+				Status: types.Error,
+				Output: "Running",
+				Error:  "failed to parse output as JSON: invalid character 'R' looking for beginning of value",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewExecutor(5 * time.Second)
+			got, err := e.ExecuteCheck(context.Background(), types.CheckItem{
+				Name:     "test",
+				Type:     "command",
+				Command:  tt.command,
+				JSONPath: tt.jsonPath,
+				Expected: tt.expected,
+			})
+			assert.NoError(t, err)
+
+			got.StartedAt, got.FinishedAt = nil, nil
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseJSONPath(t *testing.T) {
+	for _, expression := range []string{".status.phase", "{.status.phase}", "$.items[0].name", `{.items[?(@.name=="api")].name}`} {
+		_, err := ParseJSONPath(expression)
+		assert.NoError(t, err, expression)
+	}
+	_, err := ParseJSONPath("{.status")
+	assert.Error(t, err)
+}
@@ -0,0 +1,66 @@
+package executor
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DefaultShellOptions are the options passed to bash's set builtin before running a command
+const DefaultShellOptions = "-eo pipefail"
+
+// shellFlags are the single-letter options of bash's set builtin, besides -o
+const shellFlags = "abefhkmnptuvxBCEHPT"
+
+// shellOptionNames are the long options of bash's set builtin that can be used with -o
+var shellOptionNames = []string{
+	"allexport", "braceexpand", "emacs", "errexit", "errtrace", "functrace", "hashall",
+	"histexpand", "history", "ignoreeof", "keyword", "monitor", "noclobber", "noexec",
+	"noglob", "nolog", "notify", "nounset", "onecmd", "physical", "pipefail", "posix",
+	"privileged", "verbose", "vi", "xtrace",
+}
+
+var shellFlagsPattern = regexp.MustCompile(`^[-+][a-zA-Z]+$`)
+
+// ValidateShellOptions checks that options only consist of options of bash's set builtin,
+// e.g. "-euo pipefail" or "+o pipefail", so they cannot be used to run other commands
+func ValidateShellOptions(options string) error {
+	fields := strings.Fields(options)
+	if len(fields) == 0 {
+		return fmt.Errorf("shell options must not be empty")
+	}
+
+	expectName := false
+	for _, field := range fields {
+		if expectName {
+			if !slices.Contains(shellOptionNames, field) {
+				return fmt.Errorf("unknown shell option name %q", field)
+			}
+			expectName = false
+			continue
+		}
+
+		if !shellFlagsPattern.MatchString(field) {
+			return fmt.Errorf("invalid shell option %q, expected flags such as -e or -o followed by an option name", field)
+		}
+		for i, flag := range field[1:] {
+			if flag == 'o' {
+				// -o takes an option name and must be the last flag of its group
+				if i != len(field)-2 {
+					return fmt.Errorf("invalid shell option %q, -o must be the last flag of a group", field)
+				}
+				expectName = true
+				continue
+			}
+			if !strings.ContainsRune(shellFlags, flag) {
+				return fmt.Errorf("unknown shell flag %q in %q", flag, field)
+			}
+		}
+	}
+	if expectName {
+		return fmt.Errorf("shell options %q end with -o but no option name", options)
+	}
+
+	return nil
+}
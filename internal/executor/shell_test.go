@@ -0,0 +1,40 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateShellOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options string
+		wantErr string
+	}{
+		{name: "default", options: DefaultShellOptions},
+		{name: "nounset", options: "-euo pipefail"},
+		{name: "disable pipefail", options: "-e +o pipefail"},
+		{name: "separate groups", options: "-e -u -o pipefail -o noclobber"},
+		{name: "empty", options: " ", wantErr: "shell options must not be empty"},
+		{name: "command injection", options: "-e; rm -rf /", wantErr: `invalid shell option "-e;"`},
+		{name: "unknown flag", options: "-eq", wantErr: `unknown shell flag 'q' in "-eq"`},
+		{name: "unknown option name", options: "-o pipefial", wantErr: `unknown shell option name "pipefial"`},
+		{name: "missing option name", options: "-eo", wantErr: `shell options "-eo" end with -o but no option name`},
+		{name: "o not last in group", options: "-oe pipefail", wantErr: "-o must be the last flag of a group"},
+		{name: "bare word", options: "pipefail", wantErr: `invalid shell option "pipefail"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateShellOptions(tt.options)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
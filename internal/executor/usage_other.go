@@ -0,0 +1,21 @@
+//go:build !unix
+
+package executor
+
+import (
+	"os"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// resourceUsage returns the CPU time consumed by an exited process, the peak memory
+// is not reported on this platform
+func resourceUsage(state *os.ProcessState) *types.ResourceUsage {
+	if state == nil {
+		return nil
+	}
+	return &types.ResourceUsage{
+		UserSeconds:   state.UserTime().Seconds(),
+		SystemSeconds: state.SystemTime().Seconds(),
+	}
+}
@@ -0,0 +1,30 @@
+//go:build unix
+
+package executor
+
+import (
+	"os"
+	"runtime"
+	"syscall"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// resourceUsage returns the resources consumed by an exited process
+func resourceUsage(state *os.ProcessState) *types.ResourceUsage {
+	if state == nil {
+		return nil
+	}
+	usage := &types.ResourceUsage{
+		UserSeconds:   state.UserTime().Seconds(),
+		SystemSeconds: state.SystemTime().Seconds(),
+	}
+	if rusage, ok := state.SysUsage().(*syscall.Rusage); ok {
+		// Linux and most other systems report the peak RSS in kilobytes, macOS in bytes
+		usage.MaxRSSBytes = int64(rusage.Maxrss)
+		if runtime.GOOS != "darwin" {
+			usage.MaxRSSBytes *= 1024
+		}
+	}
+	return usage
+}
@@ -1,12 +1,25 @@
 package processor
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/seastar-consulting/checkers/types"
 )
 
+// Supported formats for parsing command output
+const (
+	OutputFormatJSON     = "json"
+	OutputFormatKeyValue = "keyvalue"
+	OutputFormatLines    = "lines"
+)
+
+// SupportedOutputFormats returns all formats that command output can be parsed as
+func SupportedOutputFormats() []string {
+	return []string{OutputFormatJSON, OutputFormatKeyValue, OutputFormatLines}
+}
+
 // Processor handles the processing of check outputs
 type Processor struct{}
 
@@ -32,7 +45,7 @@ func (p *Processor) ProcessOutput(checkName string, checkType string, output map
 	// Process status
 	if status, ok := output["status"].(string); ok {
 		switch strings.ToLower(status) {
-		case "success", "pass":
+		case "success", "pass", "ok":
 			result.Status = types.Success
 		case "failure", "fail":
 			result.Status = types.Failure
@@ -54,6 +67,70 @@ func (p *Processor) ProcessOutput(checkName string, checkType string, output map
 	if output, ok := output["output"].(string); ok {
 		result.Output = output
 	}
+	if expected, ok := output["expected"].(string); ok {
+		result.Expected = expected
+	}
+	if actual, ok := output["actual"].(string); ok {
+		result.Actual = actual
+	}
+	if details, ok := output["details"].(map[string]interface{}); ok {
+		result.Details = details
+	}
 
 	return result
 }
+
+// ParseOutput parses the raw output of a command into the fields understood by
+// ProcessOutput. Without a format, the output is parsed as JSON if possible and
+// used as-is otherwise.
+func (p *Processor) ParseOutput(format string, output string) (map[string]interface{}, error) {
+	switch format {
+	case "":
+		var jsonOutput map[string]interface{}
+		if err := json.Unmarshal([]byte(output), &jsonOutput); err == nil {
+			return jsonOutput, nil
+		}
+		return map[string]interface{}{"output": output}, nil
+	case OutputFormatJSON:
+		var jsonOutput map[string]interface{}
+		if err := json.Unmarshal([]byte(output), &jsonOutput); err != nil {
+			return nil, fmt.Errorf("failed to parse output as JSON: %v", err)
+		}
+		return jsonOutput, nil
+	case OutputFormatKeyValue:
+		return parseKeyValue(output), nil
+	case OutputFormatLines:
+		return parseLines(output), nil
+	default:
+		return nil, fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// parseKeyValue parses lines of "key=value" or "key: value" pairs. Lines without a
+// separator are ignored. If no output key is present, the whole output is used.
+func parseKeyValue(output string) map[string]interface{} {
+	parsed := make(map[string]interface{})
+	for _, line := range strings.Split(output, "\n") {
+		sep := strings.IndexAny(line, "=:")
+		if sep <= 0 {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(line[:sep]))
+		parsed[key] = strings.TrimSpace(line[sep+1:])
+	}
+
+	if _, ok := parsed["output"]; !ok {
+		parsed["output"] = output
+	}
+	return parsed
+}
+
+// parseLines uses the first line of the output as the status and the remaining
+// lines as the output
+func parseLines(output string) map[string]interface{} {
+	status, rest, _ := strings.Cut(output, "\n")
+	return map[string]interface{}{
+		"status": strings.TrimSpace(status),
+		"output": strings.TrimSpace(rest),
+	}
+}
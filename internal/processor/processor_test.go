@@ -30,6 +30,55 @@ func TestProcessor_ProcessOutput(t *testing.T) {
 				Output: "test output",
 			},
 		},
+		{
+			name:      "expected and actual values",
+			checkName: "test-check",
+			checkType: "test",
+			output: map[string]interface{}{
+				"status":   "failure",
+				"output":   "version mismatch",
+				"expected": "1.2.3",
+				"actual":   "1.2.4",
+			},
+			want: types.CheckResult{
+				Name:     "test-check",
+				Type:     "test",
+				Status:   types.Failure,
+				Output:   "version mismatch",
+				Expected: "1.2.3",
+				Actual:   "1.2.4",
+			},
+		},
+		{
+			name:      "details",
+			checkName: "test-check",
+			checkType: "test",
+			output: map[string]interface{}{
+				"status":  "success",
+				"output":  "deployed",
+				"details": map[string]interface{}{"version": "1.2.3", "replicas": float64(3)},
+			},
+			want: types.CheckResult{
+				Name:    "test-check",
+				Type:    "test",
+				Status:  types.Success,
+				Output:  "deployed",
+				Details: map[string]interface{}{"version": "1.2.3", "replicas": float64(3)},
+			},
+		},
+		{
+			name:      "ok status",
+			checkName: "test-check",
+			checkType: "test",
+			output: map[string]interface{}{
+				"status": "OK",
+			},
+			want: types.CheckResult{
+				Name:   "test-check",
+				Type:   "test",
+				Status: types.Success,
+			},
+		},
 		{
 			name:      "failure status",
 			checkName: "test-check",
@@ -127,3 +176,90 @@ func TestProcessor_ProcessOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessor_ParseOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		output  string
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:   "default format with JSON",
+			output: `{"status":"success","output":"test output"}`,
+			want: map[string]interface{}{
+				"status": "success",
+				"output": "test output",
+			},
+		},
+		{
+			name:   "default format with raw output",
+			output: "plain text",
+			want: map[string]interface{}{
+				"output": "plain text",
+			},
+		},
+		{
+			name:   "json format",
+			format: OutputFormatJSON,
+			output: `{"status":"fail"}`,
+			want: map[string]interface{}{
+				"status": "fail",
+			},
+		},
+		{
+			name:    "json format with invalid JSON",
+			format:  OutputFormatJSON,
+			output:  "not json",
+			wantErr: true,
+		},
+		{
+			name:   "keyvalue format",
+			format: OutputFormatKeyValue,
+			output: "Status=ok\noutput: all good\nignored line",
+			want: map[string]interface{}{
+				"status": "ok",
+				"output": "all good",
+			},
+		},
+		{
+			name:   "keyvalue format without output key",
+			format: OutputFormatKeyValue,
+			output: "status: warn\nversion: 1.2",
+			want: map[string]interface{}{
+				"status":  "warn",
+				"version": "1.2",
+				"output":  "status: warn\nversion: 1.2",
+			},
+		},
+		{
+			name:   "lines format",
+			format: OutputFormatLines,
+			output: "failure\ndisk is full\nat /var",
+			want: map[string]interface{}{
+				"status": "failure",
+				"output": "disk is full\nat /var",
+			},
+		},
+		{
+			name:    "unsupported format",
+			format:  "xml",
+			output:  "<status/>",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewProcessor()
+			got, err := p.ParseOutput(tt.format, tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOutput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseOutput() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// Mask is the replacement for redacted values
+const Mask = "***"
+
+// Validate checks that all redaction rules are valid regular expressions
+func Validate(rules []string) error {
+	for _, rule := range rules {
+		if _, err := regexp.Compile(rule); err != nil {
+			return fmt.Errorf("invalid redaction pattern %q: %v", rule, err)
+		}
+	}
+	return nil
+}
+
+// Result masks sensitive values in the output, error and details of a check result. Each rule is
+// either the name of one of the check's parameters or environment variables, in which case
+// its value is masked, or a regular expression whose matches are masked.
+func Result(result types.CheckResult, check types.CheckItem, rules []string) (types.CheckResult, error) {
+	patterns, err := compile(check, rules)
+	if err != nil {
+		return result, err
+	}
+
+	for _, pattern := range patterns {
+		result.Output = pattern.ReplaceAllString(result.Output, Mask)
+		result.Error = pattern.ReplaceAllString(result.Error, Mask)
+		result.Expected = pattern.ReplaceAllString(result.Expected, Mask)
+		result.Actual = pattern.ReplaceAllString(result.Actual, Mask)
+	}
+	if result.Details != nil && len(patterns) > 0 {
+		result.Details = redactValue(result.Details, patterns).(map[string]interface{})
+	}
+	return result, nil
+}
+
+// Secrets returns a copy of a check with the values of the named parameters,
+// environment variables and item keys replaced by Mask, e.g. to print its configuration.
+// Sub-checks are left untouched.
+func Secrets(check types.CheckItem, names []string) types.CheckItem {
+	if len(names) == 0 {
+		return check
+	}
+	check.Parameters = maskKeys(check.Parameters, names)
+	check.Env = maskKeys(check.Env, names)
+	if check.Items != nil {
+		items := make([]map[string]string, len(check.Items))
+		for i, item := range check.Items {
+			items[i] = maskKeys(item, names)
+		}
+		check.Items = items
+	}
+	return check
+}
+
+// maskKeys returns a copy of the map with the values of the named keys masked
+func maskKeys(values map[string]string, names []string) map[string]string {
+	if values == nil {
+		return nil
+	}
+	masked := make(map[string]string, len(values))
+	for key, value := range values {
+		if slices.Contains(names, key) {
+			value = Mask
+		}
+		masked[key] = value
+	}
+	return masked
+}
+
+// redactValue masks the strings in a value of the result's details. Maps and slices are
+// copied, so the details of the original result are left untouched.
+func redactValue(value interface{}, patterns []*regexp.Regexp) interface{} {
+	switch v := value.(type) {
+	case string:
+		for _, pattern := range patterns {
+			v = pattern.ReplaceAllString(v, Mask)
+		}
+		return v
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			redacted[key] = redactValue(elem, patterns)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, elem := range v {
+			redacted[i] = redactValue(elem, patterns)
+		}
+		return redacted
+	default:
+		return value
+	}
+}
+
+// compile turns the redaction rules into regular expressions for the given check
+func compile(check types.CheckItem, rules []string) ([]*regexp.Regexp, error) {
+	patterns := make([]*regexp.Regexp, 0, len(rules))
+	for _, rule := range rules {
+		values := make([]string, 0, 2)
+		if value, ok := check.Parameters[rule]; ok {
+			values = append(values, value)
+		}
+		if value, ok := check.Env[rule]; ok {
+			values = append(values, value)
+		}
+		if len(values) > 0 {
+			for _, value := range values {
+				// Empty values would match everywhere
+				if value != "" {
+					patterns = append(patterns, regexp.MustCompile(regexp.QuoteMeta(value)))
+				}
+			}
+			continue
+		}
+
+		pattern, err := regexp.Compile(rule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %v", rule, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
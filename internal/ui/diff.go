@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"strings"
+)
+
+// diffOp is the operation of a line in a diff
+type diffOp int
+
+const (
+	diffEqual diffOp = iota
+	diffRemoved
+	diffAdded
+)
+
+// diffLine is a line of a diff
+type diffLine struct {
+	op   diffOp
+	text string
+}
+
+// lineDiff compares the lines of the expected and actual values based on their longest
+// common subsequence, returning the lines only in expected as removed and the lines only
+// in actual as added
+func lineDiff(expected, actual string) []diffLine {
+	a := strings.Split(expected, "\n")
+	b := strings.Split(actual, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, diffLine{diffEqual, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{diffRemoved, a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{diffAdded, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{diffRemoved, a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{diffAdded, b[j]})
+	}
+	return lines
+}
+
+// commonAffixes returns the length in runes of the common prefix and suffix of a and b,
+// which do not overlap
+func commonAffixes(a, b []rune) (prefix, suffix int) {
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	return prefix, suffix
+}
+
+// formatDiff renders the difference between the expected and actual values. Lines only
+// in the expected value are prefixed with - and lines only in the actual value with +.
+// When both are single lines, the part that differs is highlighted.
+func (f *Formatter) formatDiff(expected, actual string) string {
+	if !strings.Contains(expected, "\n") && !strings.Contains(actual, "\n") {
+		a, b := []rune(expected), []rune(actual)
+		prefix, suffix := commonAffixes(a, b)
+		highlight := func(value []rune, style, changed func(...string) string) string {
+			return style(string(value[:prefix])) +
+				changed(string(value[prefix:len(value)-suffix])) +
+				style(string(value[len(value)-suffix:]))
+		}
+		return strings.Join([]string{
+			f.styles.DiffRemoved.Render("- ") + highlight(a, f.styles.DiffRemoved.Render, f.styles.DiffRemoved.Copy().Reverse(true).Render),
+			f.styles.DiffAdded.Render("+ ") + highlight(b, f.styles.DiffAdded.Render, f.styles.DiffAdded.Copy().Reverse(true).Render),
+		}, "\n")
+	}
+
+	var lines []string
+	for _, line := range lineDiff(expected, actual) {
+		switch line.op {
+		case diffRemoved:
+			lines = append(lines, f.styles.DiffRemoved.Render("- "+line.text))
+		case diffAdded:
+			lines = append(lines, f.styles.DiffAdded.Render("+ "+line.text))
+		default:
+			lines = append(lines, f.styles.TreeBranch.Render("  "+line.text))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLineDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		actual   string
+		want     []diffLine
+	}{
+		{
+			name:     "equal",
+			expected: "a\nb",
+			actual:   "a\nb",
+			want:     []diffLine{{diffEqual, "a"}, {diffEqual, "b"}},
+		},
+		{
+			name:     "changed line",
+			expected: "a\nb\nc",
+			actual:   "a\nx\nc",
+			want:     []diffLine{{diffEqual, "a"}, {diffRemoved, "b"}, {diffAdded, "x"}, {diffEqual, "c"}},
+		},
+		{
+			name:     "added and removed lines",
+			expected: "a\nb\nc",
+			actual:   "b\nc\nd",
+			want:     []diffLine{{diffRemoved, "a"}, {diffEqual, "b"}, {diffEqual, "c"}, {diffAdded, "d"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lineDiff(tt.expected, tt.actual); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lineDiff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDiff(t *testing.T) {
+	f := NewFormatter(true)
+
+	tests := []struct {
+		name     string
+		expected string
+		actual   string
+		want     string
+	}{
+		{
+			name:     "single line",
+			expected: "arn:aws:iam::123456789012:user/alice",
+			actual:   "arn:aws:iam::123456789012:user/bob",
+			want:     "- arn:aws:iam::123456789012:user/alice\n+ arn:aws:iam::123456789012:user/bob",
+		},
+		{
+			name:     "missing value",
+			expected: "active",
+			actual:   "",
+			want:     "- active\n+ ",
+		},
+		{
+			name:     "multiple lines",
+			expected: "one\ntwo\nthree",
+			actual:   "one\n2\nthree",
+			want:     "  one\n- two\n+ 2\n  three",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Styles render without colors outside of a terminal
+			if got := f.formatDiff(tt.expected, tt.actual); got != tt.want {
+				t.Errorf("formatDiff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommonAffixes(t *testing.T) {
+	tests := []struct {
+		a, b                   string
+		wantPrefix, wantSuffix int
+	}{
+		{a: "user/alice", b: "user/bob", wantPrefix: 5, wantSuffix: 0},
+		{a: "active", b: "inactive", wantPrefix: 0, wantSuffix: 6},
+		{a: "aaa", b: "aa", wantPrefix: 2, wantSuffix: 0},
+		{a: "same", b: "same", wantPrefix: 4, wantSuffix: 0},
+	}
+
+	for _, tt := range tests {
+		prefix, suffix := commonAffixes([]rune(tt.a), []rune(tt.b))
+		if prefix != tt.wantPrefix || suffix != tt.wantSuffix {
+			t.Errorf("commonAffixes(%q, %q) = %d, %d, want %d, %d", tt.a, tt.b, prefix, suffix, tt.wantPrefix, tt.wantSuffix)
+		}
+	}
+}
@@ -4,53 +4,54 @@ import (
 	"encoding/json"
 	"fmt"
 	"html/template"
-	"os"
 	"path/filepath"
 	"runtime"
 	"sort"
 	"strings"
 
 	"github.com/seastar-consulting/checkers/types"
-
-	"github.com/charmbracelet/lipgloss"
 )
 
+// for testing
+var templatePath = defaultTemplatePath()
+
+// defaultTemplatePath returns the path of the HTML template next to this source file
+func defaultTemplatePath() string {
+	_, currentFilePath, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(currentFilePath), "templates", "results.html.tmpl")
+}
+
+// DefaultMaxOutputLines is the number of lines of the output of a check shown in pretty
+// output before it is truncated
+const DefaultMaxOutputLines = 20
+
 // Formatter handles the formatting of check results
 type Formatter struct {
 	styles  *Styles
 	verbose bool
+	// maxOutputLines caps the lines of output shown per check in pretty output, 0 shows
+	// all of it
+	maxOutputLines int
 }
 
 // NewFormatter creates a new Formatter instance
 func NewFormatter(verbose bool) *Formatter {
 	return &Formatter{
-		styles:  NewStyles(),
-		verbose: verbose,
+		styles:         NewStyles(),
+		verbose:        verbose,
+		maxOutputLines: DefaultMaxOutputLines,
 	}
 }
 
+// SetMaxOutputLines sets the number of lines of output shown per check in pretty output,
+// 0 disables truncation
+func (f *Formatter) SetMaxOutputLines(lines int) {
+	f.maxOutputLines = lines
+}
+
 // formatResult formats a single check result
 func (f *Formatter) formatResult(result types.CheckResult, isLast bool) string {
-	var icon string
-	var nameStyle lipgloss.Style
-
-	switch result.Status {
-	case types.Success:
-		icon = CheckPassIcon
-		nameStyle = f.styles.Success
-	case types.Failure:
-		icon = CheckFailIcon
-		nameStyle = f.styles.Error
-	case types.Error:
-		icon = CheckErrorIcon
-		nameStyle = f.styles.Error
-	case types.Warning:
-		icon = CheckWarningIcon
-		nameStyle = f.styles.Warning
-	default:
-		icon = CheckErrorIcon
-		nameStyle = f.styles.Error
-	}
+	icon, nameStyle := f.styles.Status(result.Status)
 
 	// Format the name line with tree branch
 	branchSymbol := TreeBranch
@@ -62,17 +63,37 @@ func (f *Formatter) formatResult(result types.CheckResult, isLast bool) string {
 	if result.Type != "" {
 		nameLine += fmt.Sprintf(" (%s)", result.Type)
 	}
+	if result.Informational {
+		nameLine += " " + f.styles.TreeBranch.Render("[informational]")
+	}
+	if result.Reruns > 0 {
+		nameLine += " " + f.styles.TreeBranch.Render(rerunNote(result))
+	}
+	if result.Resources != nil && f.verbose {
+		nameLine += " " + f.styles.TreeBranch.Render(resourceNote(*result.Resources))
+	}
 
 	var output []string
 	output = append(output, nameLine)
 
 	// Add output box if verbose mode is on
 	if result.Output != "" && f.verbose {
+		box := f.styles.OutputBox.Render(f.truncateOutput(result.Output))
 		if isLast {
-			output = append(output, f.styles.OutputBox.Render(result.Output))
+			output = append(output, box)
 		} else {
 			verticalBar := f.styles.TreeBranch.Render(TreeVertical)
-			output = append(output, prepend(f.styles.OutputBox.Render(result.Output), verticalBar)...)
+			output = append(output, prepend(box, verticalBar)...)
+		}
+	}
+
+	// Add a diff of the expected and actual value if verbose
+	if (result.Expected != "" || result.Actual != "") && result.Status != types.Success && f.verbose {
+		diff := f.styles.DiffBox.Render(f.formatDiff(result.Expected, result.Actual))
+		if isLast {
+			output = append(output, diff)
+		} else {
+			output = append(output, prepend(diff, f.styles.TreeBranch.Render(TreeVertical))...)
 		}
 	}
 
@@ -100,9 +121,64 @@ func (f *Formatter) formatResult(result types.CheckResult, isLast bool) string {
 		}
 	}
 
+	// Add remediation hint and documentation link for checks that did not succeed
+	if result.Status != types.Success {
+		var hints []string
+		if result.Remediation != "" {
+			hints = append(hints, f.styles.HintBox.Render(fmt.Sprintf("%s %s", RemediationIcon, result.Remediation)))
+		}
+		if result.DocURL != "" {
+			hints = append(hints, f.styles.HintBox.Render(fmt.Sprintf("%s %s", DocURLIcon, result.DocURL)))
+		}
+		for _, hint := range hints {
+			if isLast {
+				output = append(output, hint)
+			} else {
+				output = append(output, prepend(hint, f.styles.TreeBranch.Render(TreeVertical))...)
+			}
+		}
+	}
+
 	return strings.Join(output, "\n")
 }
 
+// truncateOutput keeps the first maxOutputLines lines of the output, replacing the rest
+// with a note on how many lines were left out
+func (f *Formatter) truncateOutput(output string) string {
+	lines := strings.Split(output, "\n")
+	if f.maxOutputLines <= 0 || len(lines) <= f.maxOutputLines {
+		return output
+	}
+	hidden := len(lines) - f.maxOutputLines
+	noun := "lines"
+	if hidden == 1 {
+		noun = "line"
+	}
+	note := f.styles.TreeBranch.Render(fmt.Sprintf("… (%d more %s, use --full-output)", hidden, noun))
+	return strings.Join(lines[:f.maxOutputLines], "\n") + "\n" + note
+}
+
+// rerunNote describes how many reruns a check needed
+func rerunNote(result types.CheckResult) string {
+	reruns := "reruns"
+	if result.Reruns == 1 {
+		reruns = "rerun"
+	}
+	if result.Status == types.Success {
+		return fmt.Sprintf("[passed after %d %s]", result.Reruns, reruns)
+	}
+	return fmt.Sprintf("[still not passing after %d %s]", result.Reruns, reruns)
+}
+
+// resourceNote describes the resources consumed by a command check
+func resourceNote(usage types.ResourceUsage) string {
+	note := fmt.Sprintf("[user %.2fs, system %.2fs", usage.UserSeconds, usage.SystemSeconds)
+	if usage.MaxRSSBytes > 0 {
+		note += fmt.Sprintf(", max RSS %.1f MiB", float64(usage.MaxRSSBytes)/(1024*1024))
+	}
+	return note + "]"
+}
+
 // prepend adds a prefix to each line of a string
 func prepend(box string, item string) []string {
 	lines := strings.Split(box, "\n")
@@ -116,25 +192,34 @@ func prepend(box string, item string) []string {
 	return lines
 }
 
-// FormatFunc defines the interface for result formatting functions
-type FormatFunc func([]types.CheckResult, types.OutputMetadata) string
-
-// FormatResultsPretty formats multiple check results in a pretty format
-func (f *Formatter) FormatResultsPretty(results []types.CheckResult, metadata types.OutputMetadata) string {
-	// Group results by type
+// groupResults groups results by the key groupBy returns for them. Results keep their
+// order within a group. All formatters group with it, so every format groups the same
+// way.
+func groupResults(results []types.CheckResult, groupBy func(types.CheckResult) string) map[string][]types.CheckResult {
 	groups := make(map[string][]types.CheckResult)
-
 	for _, result := range results {
-		groupKey := "command"
-		if result.Type != "command" {
-			// For native checks, use the top-level package as the group
-			parts := strings.Split(result.Type, ".")
-			if len(parts) > 0 {
-				groupKey = parts[0]
-			}
-		}
-		groups[groupKey] = append(groups[groupKey], result)
+		key := groupBy(result)
+		groups[key] = append(groups[key], result)
 	}
+	return groups
+}
+
+// typeGroup is the group of a result by its type: native checks are grouped by their
+// top-level package, e.g. "os" for os.file_exists, and command checks under "command"
+func typeGroup(result types.CheckResult) string {
+	if result.Type == "command" {
+		return "command"
+	}
+	return strings.Split(result.Type, ".")[0]
+}
+
+// FormatFunc defines the interface for result formatting functions. Errors are returned
+// instead of a partial output, so a broken report is never mistaken for a valid one.
+type FormatFunc func([]types.CheckResult, types.OutputMetadata) (string, error)
+
+// FormatResultsPretty formats multiple check results in a pretty format, which never fails
+func (f *Formatter) FormatResultsPretty(results []types.CheckResult, metadata types.OutputMetadata) (string, error) {
+	groups := groupResults(results, typeGroup)
 
 	// Get sorted group names for consistent output
 	var groupNames []string
@@ -144,6 +229,14 @@ func (f *Formatter) FormatResultsPretty(results []types.CheckResult, metadata ty
 	sort.Strings(groupNames)
 
 	var output []string
+	if metadata.Name != "" {
+		output = append(output, f.styles.GroupHeader.Render(metadata.Name))
+		if metadata.Description != "" {
+			output = append(output, f.styles.TreeBranch.Render(metadata.Description))
+		}
+		output = append(output, "")
+	}
+
 	isLastGroup := false
 	for i, groupName := range groupNames {
 		isLastGroup = i == len(groupNames)-1
@@ -152,9 +245,9 @@ func (f *Formatter) FormatResultsPretty(results []types.CheckResult, metadata ty
 		output = append(output, f.styles.GroupHeader.Render(strings.ToUpper(groupName)))
 
 		// Add results for this group
-		groupResults := groups[groupName]
-		for j, result := range groupResults {
-			isLastResult := j == len(groupResults)-1
+		members := groups[groupName]
+		for j, result := range members {
+			isLastResult := j == len(members)-1
 			output = append(output, f.formatResult(result, isLastResult))
 		}
 
@@ -164,11 +257,11 @@ func (f *Formatter) FormatResultsPretty(results []types.CheckResult, metadata ty
 		}
 	}
 
-	return strings.Join(output, "\n") + "\n\n"
+	return strings.Join(output, "\n") + "\n\n", nil
 }
 
 // FormatResultsJSON formats check results as JSON
-func (f *Formatter) FormatResultsJSON(results []types.CheckResult, metadata types.OutputMetadata) string {
+func (f *Formatter) FormatResultsJSON(results []types.CheckResult, metadata types.OutputMetadata) (string, error) {
 	output := types.JSONOutput{
 		Results:  results,
 		Metadata: metadata,
@@ -176,10 +269,52 @@ func (f *Formatter) FormatResultsJSON(results []types.CheckResult, metadata type
 
 	jsonBytes, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
-		return fmt.Sprintf(`{"error": "failed to marshal results: %v"}`, err)
+		return "", fmt.Errorf("failed to marshal results: %w", err)
+	}
+
+	return string(jsonBytes), nil
+}
+
+// FormatResultsJSONGrouped formats check results as JSON with the results nested by
+// group, grouped the same way as in the pretty and HTML output
+func (f *Formatter) FormatResultsJSONGrouped(results []types.CheckResult, metadata types.OutputMetadata) (string, error) {
+	output := types.JSONGroupedOutput{
+		Results:  groupResults(results, typeGroup),
+		Metadata: metadata,
+	}
+
+	jsonBytes, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal results: %w", err)
+	}
+
+	return string(jsonBytes), nil
+}
+
+// FormatSummaryPretty formats the summary as a single line, mentioning skipped checks
+// only when there are any
+func (f *Formatter) FormatSummaryPretty(summary types.Summary) string {
+	skipped := ""
+	if summary.Skipped > 0 {
+		skipped = fmt.Sprintf(", %d skipped", summary.Skipped)
+	}
+	return fmt.Sprintf("%d checks: %d passed, %d failed, %d warnings, %d errors%s (%s)\n",
+		summary.Total, summary.Passed, summary.Failed, summary.Warnings, summary.Errors, skipped, summary.Duration)
+}
+
+// FormatSummaryJSON formats the summary as JSON
+func (f *Formatter) FormatSummaryJSON(summary types.Summary, metadata types.OutputMetadata) (string, error) {
+	output := types.JSONSummaryOutput{
+		Summary:  summary,
+		Metadata: metadata,
+	}
+
+	jsonBytes, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal summary: %w", err)
 	}
 
-	return string(jsonBytes)
+	return string(jsonBytes), nil
 }
 
 // HTMLData represents the data passed to the HTML template
@@ -189,28 +324,15 @@ type HTMLData struct {
 }
 
 // FormatResultsHTML formats check results as HTML
-func (f *Formatter) FormatResultsHTML(results []types.CheckResult, metadata types.OutputMetadata) string {
-	// Group results by type
-	groups := make(map[string][]types.CheckResult)
-
-	for _, result := range results {
-		groupKey := "command"
-		if result.Type != "command" {
-			// For native checks, use the top-level package as the group
-			parts := strings.Split(result.Type, ".")
-			if len(parts) > 0 {
-				groupKey = parts[0]
-			}
-		}
-		groups[groupKey] = append(groups[groupKey], result)
-	}
+func (f *Formatter) FormatResultsHTML(results []types.CheckResult, metadata types.OutputMetadata) (string, error) {
+	groups := groupResults(results, typeGroup)
 
 	// Sort results within each group by name
-	for groupName, groupResults := range groups {
-		sort.Slice(groupResults, func(i, j int) bool {
-			return groupResults[i].Name < groupResults[j].Name
+	for groupName, members := range groups {
+		sort.Slice(members, func(i, j int) bool {
+			return members[i].Name < members[j].Name
 		})
-		groups[groupName] = groupResults
+		groups[groupName] = members
 	}
 
 	// Prepare data for template
@@ -226,27 +348,16 @@ func (f *Formatter) FormatResultsHTML(results []types.CheckResult, metadata type
 		},
 	}
 
-	// Get the path to the template file
-	_, currentFilePath, _, _ := runtime.Caller(0)
-	templateDir := filepath.Dir(currentFilePath)
-	templatePath := filepath.Join(templateDir, "templates", "results.html.tmpl")
-
-	// Check if template file exists
-	if _, err := os.Stat(templatePath); os.IsNotExist(err) {
-		// Fall back to embedded template if file doesn't exist
-		return fmt.Sprintf("<html><body><h1>Error</h1><p>Template file not found: %s</p></body></html>", templatePath)
-	}
-
 	// Parse and execute template
 	tmpl, err := template.New(filepath.Base(templatePath)).Funcs(funcMap).ParseFiles(templatePath)
 	if err != nil {
-		return fmt.Sprintf("<html><body><h1>Error</h1><p>Failed to parse HTML template: %v</p></body></html>", err)
+		return "", fmt.Errorf("failed to parse HTML template: %w", err)
 	}
 
 	var buf strings.Builder
 	if err := tmpl.Execute(&buf, data); err != nil {
-		return fmt.Sprintf("<html><body><h1>Error</h1><p>Failed to execute HTML template: %v</p></body></html>", err)
+		return "", fmt.Errorf("failed to execute HTML template: %w", err)
 	}
 
-	return buf.String()
+	return buf.String(), nil
 }
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -48,7 +49,10 @@ func TestFormatter_FormatResultsHTML(t *testing.T) {
 	}
 
 	// Format results as HTML
-	html := formatter.FormatResultsHTML(results, metadata)
+	html, err := formatter.FormatResultsHTML(results, metadata)
+	if err != nil {
+		t.Fatalf("FormatResultsHTML() error = %v", err)
+	}
 
 	// Test that the HTML contains expected elements
 	expectedElements := []string{
@@ -68,7 +72,7 @@ func TestFormatter_FormatResultsHTML(t *testing.T) {
 		"This check produced a warning",
 		"This check failed with an error",
 		"This check failed",
-		"1.0.0-test", // Version from metadata
+		"1.0.0-test",        // Version from metadata
 		"test-os/test-arch", // OS from metadata
 	}
 
@@ -107,16 +111,166 @@ func TestFormatter_FormatResultsHTML_EmptyResults(t *testing.T) {
 		Version:  "1.0.0",
 		OS:       "test-os/test-arch",
 	}
-	
-	html := formatter.FormatResultsHTML([]types.CheckResult{}, metadata)
-	
+
+	html, err := formatter.FormatResultsHTML([]types.CheckResult{}, metadata)
+	if err != nil {
+		t.Fatalf("FormatResultsHTML() error = %v", err)
+	}
+
 	// Should still produce valid HTML
 	if !strings.Contains(html, "<!DOCTYPE html>") {
 		t.Errorf("FormatResultsHTML() with empty results should still produce valid HTML")
 	}
-	
+
 	// Should include metadata even with empty results
 	if !strings.Contains(html, "1.0.0") || !strings.Contains(html, "test-os/test-arch") {
 		t.Errorf("FormatResultsHTML() with empty results should still include metadata")
 	}
 }
+
+func TestFormatter_FormatResultsHTML_Title(t *testing.T) {
+	formatter := NewFormatter(false)
+
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{
+			name: "default title",
+			want: "<title>Checkers Results</title>",
+		},
+		{
+			name:  "custom title",
+			title: "Prod Preflight — 2025-01-10",
+			want:  "<title>Prod Preflight — 2025-01-10</title>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := formatter.FormatResultsHTML([]types.CheckResult{}, types.OutputMetadata{Title: tt.title})
+			if err != nil {
+				t.Fatalf("FormatResultsHTML() error = %v", err)
+			}
+			if !strings.Contains(html, tt.want) {
+				t.Errorf("FormatResultsHTML() output missing title %q", tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_FormatResultsHTML_Suite(t *testing.T) {
+	formatter := NewFormatter(false)
+
+	tests := []struct {
+		name     string
+		metadata types.OutputMetadata
+		want     []string
+		dontWant []string
+	}{
+		{
+			name:     "name as title",
+			metadata: types.OutputMetadata{Name: "Prod Preflight", Description: "Checks before deploying to production"},
+			want:     []string{"<title>Prod Preflight</title>", `<p class="description">Checks before deploying to production</p>`},
+			dontWant: []string{"Suite:"},
+		},
+		{
+			name:     "report title and name",
+			metadata: types.OutputMetadata{Title: "Nightly run", Name: "Security Scan"},
+			want:     []string{"<title>Nightly run</title>", "Suite: Security Scan"},
+			dontWant: []string{`class="description"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := formatter.FormatResultsHTML([]types.CheckResult{}, tt.metadata)
+			if err != nil {
+				t.Fatalf("FormatResultsHTML() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(html, want) {
+					t.Errorf("FormatResultsHTML() output missing %q", want)
+				}
+			}
+			for _, dontWant := range tt.dontWant {
+				if strings.Contains(html, dontWant) {
+					t.Errorf("FormatResultsHTML() output should not contain %q", dontWant)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatter_FormatResultsHTML_Remediation(t *testing.T) {
+	formatter := NewFormatter(false)
+	results := []types.CheckResult{
+		{
+			Name:        "Failing Test",
+			Status:      types.Failure,
+			Type:        "test.failure",
+			Remediation: "run the fix script",
+		},
+		{
+			Name:        "Passing Test",
+			Status:      types.Success,
+			Type:        "test.success",
+			Remediation: "should not be shown",
+		},
+	}
+
+	html, err := formatter.FormatResultsHTML(results, types.OutputMetadata{})
+	if err != nil {
+		t.Fatalf("FormatResultsHTML() error = %v", err)
+	}
+	if !strings.Contains(html, "run the fix script") {
+		t.Errorf("FormatResultsHTML() output missing remediation for failed check")
+	}
+	if strings.Contains(html, "should not be shown") {
+		t.Errorf("FormatResultsHTML() output contains remediation for successful check")
+	}
+}
+
+func TestFormatter_FormatResultsHTML_DocURL(t *testing.T) {
+	formatter := NewFormatter(false)
+	results := []types.CheckResult{
+		{
+			Name:   "Failing Test",
+			Status: types.Failure,
+			Type:   "test.failure",
+			DocURL: "https://docs.example.com/runbooks/failure?step=1&lang=en",
+		},
+		{
+			Name:   "Passing Test",
+			Status: types.Success,
+			Type:   "test.success",
+			DocURL: "https://docs.example.com/runbooks/success",
+		},
+	}
+
+	html, err := formatter.FormatResultsHTML(results, types.OutputMetadata{})
+	if err != nil {
+		t.Fatalf("FormatResultsHTML() error = %v", err)
+	}
+	if !strings.Contains(html, `<a href="https://docs.example.com/runbooks/failure?step=1&amp;lang=en"`) {
+		t.Errorf("FormatResultsHTML() output missing documentation link for failed check")
+	}
+	if strings.Contains(html, "runbooks/success") {
+		t.Errorf("FormatResultsHTML() output contains documentation link for successful check")
+	}
+}
+
+func TestFormatter_FormatResultsHTML_TemplateError(t *testing.T) {
+	originalTemplatePath := templatePath
+	defer func() { templatePath = originalTemplatePath }()
+	templatePath = filepath.Join(t.TempDir(), "missing.html.tmpl")
+
+	html, err := NewFormatter(false).FormatResultsHTML([]types.CheckResult{}, types.OutputMetadata{})
+	if err == nil || !strings.Contains(err.Error(), "failed to parse HTML template") {
+		t.Errorf("FormatResultsHTML() error = %v, want template error", err)
+	}
+	if html != "" {
+		t.Errorf("FormatResultsHTML() = %q, want no output on error", html)
+	}
+}
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// update rewrites the golden files with the current output: go test ./internal/ui -update
+var update = flag.Bool("update", false, "update the golden files")
+
+// assertGolden compares the output to the golden file in testdata
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("output differs from %s, which is a contract that tools depend on. "+
+			"If the change is intended, run the tests with -update.\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+// TestFormatResultsJSON_Golden guards the JSON output contract: any renamed, removed or
+// restructured field fails this test
+func TestFormatResultsJSON_Golden(t *testing.T) {
+	startedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	finishedAt := startedAt.Add(1500 * time.Millisecond)
+	results := []types.CheckResult{
+		{
+			ID:     "3f2a1b4c5d6e7f80",
+			Name:   "api-health",
+			Type:   "command",
+			Status: types.Success,
+			Output: "service is healthy",
+			Details: map[string]interface{}{
+				"version":  "1.4.2",
+				"replicas": 3,
+			},
+		},
+		{
+			ID:          "0a1b2c3d4e5f6a7b",
+			Name:        "disk-space",
+			Type:        "os.disk_space",
+			Status:      types.Failure,
+			Output:      "only 2GB free",
+			Error:       "free space below threshold",
+			Remediation: "clean up /var/log",
+			DocURL:      "https://docs.example.com/runbooks/disk-space",
+			Expected:    "10GB",
+			Actual:      "2GB",
+			StartedAt:   &startedAt,
+			FinishedAt:  &finishedAt,
+			Reruns:      2,
+			Retry:       types.Terminal,
+			Resources: &types.ResourceUsage{
+				UserSeconds:   1.25,
+				SystemSeconds: 0.5,
+				MaxRSSBytes:   12582912,
+			},
+			Informational: true,
+		},
+		{
+			ID:     "9c8d7e6f5a4b3c2d",
+			Name:   "deployments: web",
+			Type:   "k8s.deployments_ready",
+			Status: types.Failure,
+			Output: "0 of 2 replicas are ready",
+			Parent: "deployments",
+		},
+	}
+	metadata := types.OutputMetadata{
+		Title:       "Nightly checks",
+		Name:        "production",
+		Description: "Checks of the production environment",
+		DateTime:    "2024-03-01T12:00:00Z",
+		Version:     "v1.2.3",
+		OS:          "linux/amd64",
+	}
+
+	got, err := NewFormatter(false).FormatResultsJSON(results, metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertGolden(t, "results.golden.json", got)
+}
+
+// TestFormatSummaryJSON_Golden guards the JSON output contract of --summary-only
+func TestFormatSummaryJSON_Golden(t *testing.T) {
+	summary := types.Summary{
+		Total:    5,
+		Passed:   1,
+		Failed:   1,
+		Warnings: 1,
+		Errors:   1,
+		Skipped:  1,
+		Duration: "1.5s",
+	}
+	metadata := types.OutputMetadata{
+		DateTime: "2024-03-01T12:00:00Z",
+		Version:  "v1.2.3",
+		OS:       "linux/amd64",
+	}
+
+	got, err := NewFormatter(false).FormatSummaryJSON(summary, metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertGolden(t, "summary.golden.json", got)
+}
+
+// TestFormatResultsJSONGrouped_Golden guards the JSON output contract of --json-grouped
+func TestFormatResultsJSONGrouped_Golden(t *testing.T) {
+	results := []types.CheckResult{
+		{Name: "disk-space", Type: "command", Status: types.Success, Output: "ok"},
+		{Name: "hosts-file", Type: "os.file_exists", Status: types.Success, Output: "File exists"},
+		{Name: "repo-up-to-date", Type: "git.is_up_to_date", Status: types.Failure, Output: "Branch is behind"},
+		{Name: "ssh-binary", Type: "os.executable_exists", Status: types.Error, Error: "not found"},
+	}
+	metadata := types.OutputMetadata{
+		DateTime: "2024-03-01T12:00:00Z",
+		Version:  "v1.2.3",
+		OS:       "linux/amd64",
+	}
+
+	got, err := NewFormatter(false).FormatResultsJSONGrouped(results, metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertGolden(t, "results_grouped.golden.json", got)
+}
@@ -1,8 +1,11 @@
 package ui
 
 import (
+	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/seastar-consulting/checkers/types"
 )
@@ -54,6 +57,132 @@ func TestFormatter_FormatResult(t *testing.T) {
 			wantParts: []string{"test-check", "test"},
 			dontWant:  []string{"test failed"},
 		},
+		{
+			name:    "success after reruns",
+			verbose: false,
+			result: types.CheckResult{
+				Name:   "test-check",
+				Type:   "test",
+				Status: types.Success,
+				Reruns: 2,
+			},
+			wantIcon:  CheckPassIcon,
+			wantParts: []string{"test-check", "[passed after 2 reruns]"},
+		},
+		{
+			name:    "failure after rerun",
+			verbose: false,
+			result: types.CheckResult{
+				Name:   "test-check",
+				Type:   "test",
+				Status: types.Failure,
+				Reruns: 1,
+			},
+			wantIcon:  CheckFailIcon,
+			wantParts: []string{"test-check", "[still not passing after 1 rerun]"},
+		},
+		{
+			name:    "expected and actual - verbose",
+			verbose: true,
+			result: types.CheckResult{
+				Name:     "test-check",
+				Type:     "os.sysctl",
+				Status:   types.Failure,
+				Output:   "Kernel parameter 'vm.swappiness' is '60', expected '10'",
+				Expected: "10",
+				Actual:   "60",
+			},
+			wantIcon:  CheckFailIcon,
+			wantParts: []string{"test-check", "- 10", "+ 60"},
+		},
+		{
+			name:    "expected and actual - non-verbose",
+			verbose: false,
+			result: types.CheckResult{
+				Name:     "test-check",
+				Type:     "os.sysctl",
+				Status:   types.Failure,
+				Expected: "10",
+				Actual:   "60",
+			},
+			wantIcon: CheckFailIcon,
+			dontWant: []string{"- 10"},
+		},
+		{
+			name:    "informational result",
+			verbose: false,
+			result: types.CheckResult{
+				Name:          "test-check",
+				Type:          "test",
+				Status:        types.Failure,
+				Informational: true,
+			},
+			wantIcon:  CheckFailIcon,
+			wantParts: []string{"test-check", "[informational]"},
+		},
+		{
+			name:    "resource usage - verbose",
+			verbose: true,
+			result: types.CheckResult{
+				Name:      "test-check",
+				Type:      "command",
+				Status:    types.Success,
+				Resources: &types.ResourceUsage{UserSeconds: 1.5, SystemSeconds: 0.25, MaxRSSBytes: 12 * 1024 * 1024},
+			},
+			wantIcon:  CheckPassIcon,
+			wantParts: []string{"test-check", "[user 1.50s, system 0.25s, max RSS 12.0 MiB]"},
+		},
+		{
+			name:    "resource usage - non-verbose",
+			verbose: false,
+			result: types.CheckResult{
+				Name:      "test-check",
+				Type:      "command",
+				Status:    types.Success,
+				Resources: &types.ResourceUsage{UserSeconds: 1.5, SystemSeconds: 0.25},
+			},
+			wantIcon: CheckPassIcon,
+			dontWant: []string{"user 1.50s"},
+		},
+		{
+			name:    "failure result with remediation",
+			verbose: false,
+			result: types.CheckResult{
+				Name:        "test-check",
+				Type:        "test",
+				Status:      types.Failure,
+				Output:      "test failed",
+				Remediation: "run the fix script",
+			},
+			wantIcon:  CheckFailIcon,
+			wantParts: []string{"test-check", RemediationIcon, "run the fix script"},
+		},
+		{
+			name:    "failure result with documentation link",
+			verbose: false,
+			result: types.CheckResult{
+				Name:   "test-check",
+				Type:   "test",
+				Status: types.Failure,
+				Output: "test failed",
+				DocURL: "https://docs.example.com/runbooks/test",
+			},
+			wantIcon:  CheckFailIcon,
+			wantParts: []string{"test-check", DocURLIcon, "https://docs.example.com/runbooks/test"},
+		},
+		{
+			name:    "success result hides remediation",
+			verbose: true,
+			result: types.CheckResult{
+				Name:        "test-check",
+				Type:        "test",
+				Status:      types.Success,
+				Remediation: "run the fix script",
+			},
+			wantIcon:  CheckPassIcon,
+			wantParts: []string{"test-check"},
+			dontWant:  []string{"run the fix script"},
+		},
 		{
 			name:    "error result - multiline non-verbose",
 			verbose: false,
@@ -158,9 +287,19 @@ func TestFormatter_FormatResults(t *testing.T) {
 		name      string
 		verbose   bool
 		results   []types.CheckResult
+		metadata  types.OutputMetadata
 		wantParts []string
 		dontWant  []string
 	}{
+		{
+			name:    "suite name and description",
+			verbose: false,
+			results: []types.CheckResult{
+				{Name: "check1", Type: "test", Status: types.Success},
+			},
+			metadata:  types.OutputMetadata{Name: "Prod Preflight", Description: "Checks before deploying"},
+			wantParts: []string{"Prod Preflight\nChecks before deploying\n\nTEST", "check1"},
+		},
 		{
 			name:    "multiple results - non-verbose",
 			verbose: false,
@@ -211,7 +350,10 @@ func TestFormatter_FormatResults(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			f := NewFormatter(tt.verbose)
-			got := f.FormatResultsPretty(tt.results, types.OutputMetadata{})
+			got, err := f.FormatResultsPretty(tt.results, tt.metadata)
+			if err != nil {
+				t.Fatalf("FormatResultsPretty() error = %v", err)
+			}
 
 			for _, want := range tt.wantParts {
 				if !strings.Contains(got, want) {
@@ -243,12 +385,127 @@ func TestFormatter_FormatResults_DoubleNewline(t *testing.T) {
 		},
 	}
 
-	output := f.FormatResultsPretty(results, types.OutputMetadata{})
+	output, err := f.FormatResultsPretty(results, types.OutputMetadata{})
+	if err != nil {
+		t.Fatalf("FormatResultsPretty() error = %v", err)
+	}
 	if !strings.HasSuffix(output, "\n\n") {
 		t.Error("FormatResults output should end with double newline")
 	}
 }
 
+func TestFormatter_TruncateOutput(t *testing.T) {
+	lines := make([]string, 25)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i+1)
+	}
+	results := []types.CheckResult{
+		{Name: "noisy", Type: "command", Status: types.Failure, Output: strings.Join(lines, "\n")},
+	}
+
+	f := NewFormatter(true)
+	got, err := f.FormatResultsPretty(results, types.OutputMetadata{})
+	if err != nil {
+		t.Fatalf("FormatResultsPretty() error = %v", err)
+	}
+	if !strings.Contains(got, "line 20") || strings.Contains(got, "line 21") {
+		t.Errorf("output should be truncated after %d lines, got:\n%s", DefaultMaxOutputLines, got)
+	}
+	if !strings.Contains(got, "… (5 more lines, use --full-output)") {
+		t.Errorf("output is missing the truncation note, got:\n%s", got)
+	}
+
+	f.SetMaxOutputLines(24)
+	got, _ = f.FormatResultsPretty(results, types.OutputMetadata{})
+	if !strings.Contains(got, "… (1 more line, use --full-output)") {
+		t.Errorf("output is missing the truncation note, got:\n%s", got)
+	}
+
+	f.SetMaxOutputLines(0)
+	got, _ = f.FormatResultsPretty(results, types.OutputMetadata{})
+	if !strings.Contains(got, "line 25") || strings.Contains(got, "more lines") {
+		t.Errorf("output should not be truncated, got:\n%s", got)
+	}
+}
+
+func TestGroupResults(t *testing.T) {
+	results := []types.CheckResult{
+		{Name: "b-disk", Type: "command"},
+		{Name: "hosts", Type: "os.file_exists"},
+		{Name: "a-mem", Type: "command"},
+		{Name: "repo", Type: "git.is_up_to_date"},
+		{Name: "custom", Type: "plugin"},
+	}
+
+	groups := groupResults(results, typeGroup)
+	want := map[string][]string{
+		"command": {"b-disk", "a-mem"},
+		"os":      {"hosts"},
+		"git":     {"repo"},
+		"plugin":  {"custom"},
+	}
+	if len(groups) != len(want) {
+		t.Fatalf("got %d groups, want %d: %+v", len(groups), len(want), groups)
+	}
+	for group, names := range want {
+		var got []string
+		for _, result := range groups[group] {
+			got = append(got, result.Name)
+		}
+		if strings.Join(got, ",") != strings.Join(names, ",") {
+			t.Errorf("group %q = %v, want %v", group, got, names)
+		}
+	}
+
+	byStatus := groupResults([]types.CheckResult{
+		{Name: "a", Status: types.Success},
+		{Name: "b", Status: types.Failure},
+		{Name: "c", Status: types.Success},
+	}, func(result types.CheckResult) string { return string(result.Status) })
+	if len(byStatus[string(types.Success)]) != 2 || len(byStatus[string(types.Failure)]) != 1 {
+		t.Errorf("groupResults() by status = %+v", byStatus)
+	}
+}
+
+func TestFormatter_FormatSummary(t *testing.T) {
+	f := NewFormatter(false)
+	results := []types.CheckResult{
+		{Name: "a", Status: types.Success},
+		{Name: "b", Status: types.Success},
+		{Name: "c", Status: types.Failure},
+		{Name: "d", Status: types.Warning},
+		{Name: "e", Status: types.Error},
+	}
+	summary := types.NewSummary(results, 1500*time.Millisecond)
+
+	want := "5 checks: 2 passed, 1 failed, 1 warnings, 1 errors (1.5s)\n"
+	if got := f.FormatSummaryPretty(summary); got != want {
+		t.Errorf("FormatSummaryPretty() = %q, want %q", got, want)
+	}
+
+	var output types.JSONSummaryOutput
+	summaryJSON, err := f.FormatSummaryJSON(summary, types.OutputMetadata{Version: "v1.0.0"})
+	if err != nil {
+		t.Fatalf("FormatSummaryJSON() error = %v", err)
+	}
+	if err := json.Unmarshal([]byte(summaryJSON), &output); err != nil {
+		t.Fatalf("FormatSummaryJSON() returned invalid JSON: %v", err)
+	}
+	wantSummary := types.Summary{Total: 5, Passed: 2, Failed: 1, Warnings: 1, Errors: 1, Duration: "1.5s"}
+	if output.Summary != wantSummary {
+		t.Errorf("FormatSummaryJSON() summary = %+v, want %+v", output.Summary, wantSummary)
+	}
+	if output.Metadata.Version != "v1.0.0" {
+		t.Errorf("FormatSummaryJSON() metadata version = %q, want %q", output.Metadata.Version, "v1.0.0")
+	}
+
+	summary = types.NewSummary(append(results, types.CheckResult{Name: "f", Status: types.Skipped}), time.Second)
+	want = "6 checks: 2 passed, 1 failed, 1 warnings, 1 errors, 1 skipped (1s)\n"
+	if got := f.FormatSummaryPretty(summary); got != want {
+		t.Errorf("FormatSummaryPretty() = %q, want %q", got, want)
+	}
+}
+
 func TestPrepend(t *testing.T) {
 	tests := []struct {
 		name     string
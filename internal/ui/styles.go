@@ -1,6 +1,9 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/seastar-consulting/checkers/types"
+)
 
 const (
 	// Icons
@@ -8,6 +11,9 @@ const (
 	CheckFailIcon    = "❌"
 	CheckErrorIcon   = "🟠"
 	CheckWarningIcon = "⚠️"
+	CheckSkipIcon    = "⏭️"
+	RemediationIcon  = "💡"
+	DocURLIcon       = "📖"
 
 	// Tree symbols
 	TreeBranch   = "├──"
@@ -20,8 +26,13 @@ type Styles struct {
 	Success     lipgloss.Style
 	Error       lipgloss.Style
 	Warning     lipgloss.Style
+	Skipped     lipgloss.Style
 	OutputBox   lipgloss.Style
 	ErrorBox    lipgloss.Style
+	HintBox     lipgloss.Style
+	DiffBox     lipgloss.Style
+	DiffRemoved lipgloss.Style
+	DiffAdded   lipgloss.Style
 	GroupHeader lipgloss.Style
 	TreeBranch  lipgloss.Style
 }
@@ -38,6 +49,9 @@ func NewStyles() *Styles {
 		Warning: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("11")),
 
+		Skipped: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("8")),
+
 		OutputBox: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("8")).
 			Border(lipgloss.RoundedBorder()).
@@ -52,6 +66,25 @@ func NewStyles() *Styles {
 			Padding(0, 1).
 			MarginLeft(4),
 
+		HintBox: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("14")).
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("14")).
+			Padding(0, 1).
+			MarginLeft(4),
+
+		DiffBox: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("8")).
+			Padding(0, 1).
+			MarginLeft(4),
+
+		DiffRemoved: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("9")),
+
+		DiffAdded: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("10")),
+
 		GroupHeader: lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color("12")),
@@ -60,3 +93,19 @@ func NewStyles() *Styles {
 			Foreground(lipgloss.Color("8")),
 	}
 }
+
+// Status returns the icon and the name style for a check status
+func (s *Styles) Status(status types.CheckStatus) (string, lipgloss.Style) {
+	switch status {
+	case types.Success:
+		return CheckPassIcon, s.Success
+	case types.Failure:
+		return CheckFailIcon, s.Error
+	case types.Warning:
+		return CheckWarningIcon, s.Warning
+	case types.Skipped:
+		return CheckSkipIcon, s.Skipped
+	default:
+		return CheckErrorIcon, s.Error
+	}
+}
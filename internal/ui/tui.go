@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-isatty"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+// tuiFilters are the status filters cycled through in the TUI, the empty status shows all results
+var tuiFilters = []types.CheckStatus{"", types.Failure, types.Error, types.Warning, types.Success, types.Skipped}
+
+// tuiModel is the bubbletea model of the interactive results browser
+type tuiModel struct {
+	styles   *Styles
+	title    string
+	results  []types.CheckResult
+	filter   int
+	visible  []int
+	cursor   int
+	offset   int
+	height   int
+	expanded map[int]bool
+}
+
+// newTUIModel creates a model showing all results, none of them expanded
+func newTUIModel(results []types.CheckResult, metadata types.OutputMetadata) *tuiModel {
+	title := metadata.Title
+	if title == "" {
+		title = metadata.Name
+	}
+	if title == "" {
+		title = "Checkers results"
+	}
+	m := &tuiModel{
+		styles:   NewStyles(),
+		title:    title,
+		results:  results,
+		expanded: make(map[int]bool),
+	}
+	m.applyFilter()
+	return m
+}
+
+// applyFilter updates the visible results after the filter changed
+func (m *tuiModel) applyFilter() {
+	m.visible = m.visible[:0]
+	for i, result := range m.results {
+		if tuiFilters[m.filter] == "" || result.Status == tuiFilters[m.filter] {
+			m.visible = append(m.visible, i)
+		}
+	}
+	m.cursor = 0
+	m.offset = 0
+}
+
+// rows returns the number of results that fit on the screen
+func (m *tuiModel) rows() int {
+	// Leave room for the header and help lines
+	if m.height <= 4 {
+		return len(m.visible)
+	}
+	return m.height - 4
+}
+
+// Init implements tea.Model
+func (m *tuiModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.visible)-1 {
+				m.cursor++
+			}
+		case "enter", " ":
+			if len(m.visible) > 0 {
+				index := m.visible[m.cursor]
+				m.expanded[index] = !m.expanded[index]
+			}
+		case "tab", "f":
+			m.filter = (m.filter + 1) % len(tuiFilters)
+			m.applyFilter()
+		}
+	}
+
+	// Scroll to keep the selected result on the screen
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+m.rows() {
+		m.offset = m.cursor - m.rows() + 1
+	}
+	return m, nil
+}
+
+// View implements tea.Model
+func (m *tuiModel) View() string {
+	filter := "all"
+	if status := tuiFilters[m.filter]; status != "" {
+		filter = string(status)
+	}
+
+	var lines []string
+	lines = append(lines, m.styles.GroupHeader.Render(m.title)+
+		fmt.Sprintf(" (%d of %d checks, showing: %s)", len(m.visible), len(m.results), filter), "")
+
+	if len(m.visible) == 0 {
+		lines = append(lines, "  No checks match the filter")
+	}
+	end := min(m.offset+m.rows(), len(m.visible))
+	for i := m.offset; i < end; i++ {
+		index := m.visible[i]
+		result := m.results[index]
+
+		icon, nameStyle := m.styles.Status(result.Status)
+		pointer := " "
+		if i == m.cursor {
+			pointer = ">"
+		}
+		line := fmt.Sprintf("%s %s %s", pointer, icon, nameStyle.Render(result.Name))
+		if result.Type != "" {
+			line += fmt.Sprintf(" (%s)", result.Type)
+		}
+		lines = append(lines, line)
+
+		if m.expanded[index] {
+			if result.Output != "" {
+				lines = append(lines, m.styles.OutputBox.Render(result.Output))
+			}
+			if result.Error != "" {
+				lines = append(lines, m.styles.ErrorBox.Render(strings.TrimSpace(result.Error)))
+			}
+			if result.Remediation != "" && result.Status != types.Success {
+				lines = append(lines, m.styles.HintBox.Render(fmt.Sprintf("%s %s", RemediationIcon, result.Remediation)))
+			}
+			if result.DocURL != "" && result.Status != types.Success {
+				lines = append(lines, m.styles.HintBox.Render(fmt.Sprintf("%s %s", DocURLIcon, result.DocURL)))
+			}
+		}
+	}
+
+	lines = append(lines, "", m.styles.TreeBranch.Render("↑/↓ select • enter expand • tab filter by status • q quit"))
+	return strings.Join(lines, "\n")
+}
+
+// RunTUI shows the results in an interactive browser until the user quits
+func RunTUI(results []types.CheckResult, metadata types.OutputMetadata, in io.Reader, out io.Writer) error {
+	program := tea.NewProgram(newTUIModel(results, metadata),
+		tea.WithInput(in), tea.WithOutput(out), tea.WithAltScreen())
+	_, err := program.Run()
+	return err
+}
+
+// IsTerminal reports whether both the reader and the writer are connected to a terminal
+func IsTerminal(in io.Reader, out io.Writer) bool {
+	inFile, ok := in.(*os.File)
+	if !ok || !isatty.IsTerminal(inFile.Fd()) {
+		return false
+	}
+	outFile, ok := out.(*os.File)
+	return ok && isatty.IsTerminal(outFile.Fd())
+}
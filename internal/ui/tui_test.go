@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/seastar-consulting/checkers/types"
+)
+
+func TestTUIModel(t *testing.T) {
+	results := []types.CheckResult{
+		{Name: "check-a", Type: "command", Status: types.Success, Output: "all good"},
+		{Name: "check-b", Type: "os.file_exists", Status: types.Failure, Output: "file missing", Remediation: "create the file"},
+		{Name: "check-c", Type: "command", Status: types.Error, Error: "command failed with exit code 2"},
+	}
+	m := newTUIModel(results, types.OutputMetadata{Title: "Preflight"})
+
+	press := func(key string) {
+		var msg tea.KeyMsg
+		switch key {
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "up":
+			msg = tea.KeyMsg{Type: tea.KeyUp}
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		m.Update(msg)
+	}
+
+	view := m.View()
+	if !strings.Contains(view, "Preflight") || !strings.Contains(view, "(3 of 3 checks, showing: all)") {
+		t.Errorf("unexpected header: %s", view)
+	}
+	if !strings.Contains(view, "> "+CheckPassIcon) {
+		t.Errorf("expected first check to be selected: %s", view)
+	}
+	if strings.Contains(view, "file missing") {
+		t.Errorf("output should be hidden until expanded: %s", view)
+	}
+
+	// Select and expand the second check
+	press("down")
+	press("enter")
+	view = m.View()
+	if m.cursor != 1 {
+		t.Errorf("cursor = %d, want 1", m.cursor)
+	}
+	if !strings.Contains(view, "file missing") || !strings.Contains(view, "create the file") {
+		t.Errorf("expected expanded output and remediation: %s", view)
+	}
+
+	// Collapse again
+	press("enter")
+	if strings.Contains(m.View(), "file missing") {
+		t.Errorf("output should be hidden after collapsing: %s", m.View())
+	}
+
+	// The cursor stays within the list
+	press("down")
+	press("down")
+	if m.cursor != 2 {
+		t.Errorf("cursor = %d, want 2", m.cursor)
+	}
+	press("up")
+	press("up")
+	press("up")
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d, want 0", m.cursor)
+	}
+
+	// Filter by failures, then errors
+	press("tab")
+	view = m.View()
+	if !strings.Contains(view, "(1 of 3 checks, showing: Failure)") || !strings.Contains(view, "check-b") || strings.Contains(view, "check-a") {
+		t.Errorf("expected only failures: %s", view)
+	}
+	press("f")
+	view = m.View()
+	if !strings.Contains(view, "showing: Error") || !strings.Contains(view, "check-c") || strings.Contains(view, "check-b") {
+		t.Errorf("expected only errors: %s", view)
+	}
+
+	// No warnings
+	press("tab")
+	if !strings.Contains(m.View(), "No checks match the filter") {
+		t.Errorf("expected empty list: %s", m.View())
+	}
+	press("enter") // must not panic on an empty list
+
+	// Quit
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}); cmd == nil {
+		t.Error("expected quit command")
+	}
+}
+
+func TestTUIModelScrolling(t *testing.T) {
+	var results []types.CheckResult
+	for i := 0; i < 10; i++ {
+		results = append(results, types.CheckResult{Name: string(rune('a' + i)), Status: types.Success})
+	}
+	m := newTUIModel(results, types.OutputMetadata{})
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 7})
+
+	for i := 0; i < 5; i++ {
+		m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	if m.offset != 3 {
+		t.Errorf("offset = %d, want 3", m.offset)
+	}
+	view := m.View()
+	if strings.Contains(view, CheckPassIcon+" "+m.styles.Success.Render("c")) || !strings.Contains(view, "> "+CheckPassIcon+" "+m.styles.Success.Render("f")) {
+		t.Errorf("expected checks d to f to be shown with f selected: %s", view)
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	if IsTerminal(strings.NewReader(""), &strings.Builder{}) {
+		t.Error("buffers are not terminals")
+	}
+}
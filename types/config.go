@@ -1,21 +1,123 @@
 package types
 
-import "time"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+)
 
 // CheckItem represents a single check to be executed
 type CheckItem struct {
-	Name        string              `yaml:"name"`
-	Description string              `yaml:"description,omitempty"`
-	Type        string              `yaml:"type"`
-	Command     string              `yaml:"command,omitempty"`
-	Parameters  map[string]string   `yaml:"parameters,omitempty"`
-	Items       []map[string]string `yaml:"items,omitempty"`
+	ID             string              `yaml:"id,omitempty" toml:"id,omitempty"`
+	Name           string              `yaml:"name" toml:"name"`
+	Description    string              `yaml:"description,omitempty" toml:"description,omitempty"`
+	Type           string              `yaml:"type" toml:"type"`
+	Command        string              `yaml:"command,omitempty" toml:"command,omitempty"`
+	OutputFormat   string              `yaml:"output_format,omitempty" toml:"output_format,omitempty"`
+	Parameters     map[string]string   `yaml:"parameters,omitempty" toml:"parameters,omitempty"`
+	Env            map[string]string   `yaml:"env,omitempty" toml:"env,omitempty"`
+	Items          []map[string]string `yaml:"items,omitempty" toml:"items,omitempty"`
+	Matrix         map[string][]string `yaml:"matrix,omitempty" toml:"matrix,omitempty"`
+	Redact         []string            `yaml:"redact,omitempty" toml:"redact,omitempty"`
+	SecretParams   []string            `yaml:"secret_params,omitempty" toml:"secret_params,omitempty"`
+	Timeout        *time.Duration      `yaml:"timeout,omitempty" toml:"timeout,omitempty"`
+	Remediation    string              `yaml:"remediation,omitempty" toml:"remediation,omitempty"`
+	DocURL         string              `yaml:"doc_url,omitempty" toml:"doc_url,omitempty"`
+	Checks         []CheckItem         `yaml:"checks,omitempty" toml:"checks,omitempty"`
+	Priority       int                 `yaml:"priority,omitempty" toml:"priority,omitempty"`
+	ShellOptions   string              `yaml:"shell_options,omitempty" toml:"shell_options,omitempty"`
+	OutputFile     string              `yaml:"output_file,omitempty" toml:"output_file,omitempty"`
+	KeepOutputFile bool                `yaml:"keep_output_file,omitempty" toml:"keep_output_file,omitempty"`
+	// Informational checks are reported but never affect the exit code
+	Informational bool `yaml:"informational,omitempty" toml:"informational,omitempty"`
+	// Paths are the glob patterns of the files a check is associated with, see --changed-since
+	Paths []string `yaml:"paths,omitempty" toml:"paths,omitempty"`
+	// OnSuccess and OnFailure are commands run after the check, depending on its result
+	OnSuccess string `yaml:"on_success,omitempty" toml:"on_success,omitempty"`
+	OnFailure string `yaml:"on_failure,omitempty" toml:"on_failure,omitempty"`
+	// JSONPath selects a value of the JSON output of a command check, which has to equal Expected
+	JSONPath string `yaml:"jsonpath,omitempty" toml:"jsonpath,omitempty"`
+	Expected string `yaml:"expected,omitempty" toml:"expected,omitempty"`
+	// ExitAsFailure reports a non-zero exit code of a command check as a failure instead of
+	// an error, for commands asserting a condition like `test -f`
+	ExitAsFailure bool `yaml:"exit_as_failure,omitempty" toml:"exit_as_failure,omitempty"`
+	// IgnoreStderr leaves the stderr of a command check that exits successfully out of the
+	// output that is parsed and shown, e.g. for tools writing progress to stderr
+	IgnoreStderr bool `yaml:"ignore_stderr,omitempty" toml:"ignore_stderr,omitempty"`
+	// RetryableExitCodes are the exit codes of a command check that mean a transient
+	// problem, any other non-zero exit code is terminal and not rerun
+	RetryableExitCodes []int `yaml:"retryable_exit_codes,omitempty" toml:"retryable_exit_codes,omitempty"`
+	// Enabled is nil for checks that do not set it, which are enabled
+	Enabled *bool `yaml:"enabled,omitempty" toml:"enabled,omitempty"`
+}
+
+// StableID returns the ID of the check, or when it has none, an ID derived from a hash
+// of its type, name and parameters, which stays the same across runs
+func (c CheckItem) StableID() string {
+	if c.ID != "" {
+		return c.ID
+	}
+	keys := make([]string, 0, len(c.Parameters))
+	for key := range c.Parameters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Separate the fields, so e.g. moving a character from the type to the name changes the hash
+	var b strings.Builder
+	b.WriteString(c.Type + "\x00" + c.Name)
+	for _, key := range keys {
+		b.WriteString("\x00" + key + "=" + c.Parameters[key])
+	}
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Hook returns the command to run for a result of the check with the given status:
+// on_success for successful checks, none for skipped checks, on_failure otherwise
+func (c CheckItem) Hook(status CheckStatus) string {
+	switch status {
+	case Success:
+		return c.OnSuccess
+	case Skipped:
+		return ""
+	default:
+		return c.OnFailure
+	}
+}
+
+// Child returns the check a result of a check producing multiple results belongs to: a
+// copy of the check named after the result, whose ID is derived from the check's ID
+func (c CheckItem) Child(name string) CheckItem {
+	child := c
+	child.Name = name
+	if c.ID != "" {
+		child.ID = c.ID + "-" + strings.TrimPrefix(name, c.Name+": ")
+	}
+	return child
+}
+
+// IsEnabled reports whether the check should run, which is the case unless it sets
+// enabled to false
+func (c CheckItem) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
 }
 
 // Config represents the structure of the checks.yaml file
 type Config struct {
-	Timeout *time.Duration `yaml:"timeout,omitempty"`
-	Checks  []CheckItem    `yaml:"checks"`
+	Name         string         `yaml:"name,omitempty" toml:"name,omitempty"`
+	Description  string         `yaml:"description,omitempty" toml:"description,omitempty"`
+	Timeout      *time.Duration `yaml:"timeout,omitempty" toml:"timeout,omitempty"`
+	Redact       []string       `yaml:"redact,omitempty" toml:"redact,omitempty"`
+	ShellOptions string         `yaml:"shell_options,omitempty" toml:"shell_options,omitempty"`
+	// OutputFormat is the output format used when --output is not given
+	OutputFormat OutputFormat `yaml:"output_format,omitempty" toml:"output_format,omitempty"`
+	// AfterRun is a command run once after all checks, see --after-run
+	AfterRun         string      `yaml:"after_run,omitempty" toml:"after_run,omitempty"`
+	AfterRunRequired bool        `yaml:"after_run_required,omitempty" toml:"after_run_required,omitempty"`
+	Checks           []CheckItem `yaml:"checks" toml:"checks"`
 }
 
 // CheckStatus represents the result of a single check
@@ -26,12 +128,60 @@ const (
 	Failure CheckStatus = "Failure"
 	Warning CheckStatus = "Warning"
 	Error   CheckStatus = "Error"
+	// Skipped checks were not run, e.g. because they are disabled
+	Skipped CheckStatus = "Skipped"
 )
 
+// Retryability tells whether a check that did not succeed may succeed when it runs again
+type Retryability string
+
+const (
+	// Retryable results are caused by transient problems, e.g. a network timeout
+	Retryable Retryability = "retryable"
+	// Terminal results do not change by running the check again, e.g. a permission error
+	Terminal Retryability = "terminal"
+)
+
+// CheckResult represents the result of a check. Expected and Actual are only set by
+// checks comparing a value, Resources only for command checks when profiling is enabled.
 type CheckResult struct {
-	Name   string      `json:"name"`
-	Type   string      `json:"type"`
-	Status CheckStatus `json:"status"`
-	Output string      `json:"output"`
-	Error  string      `json:"error,omitempty"`
+	ID            string         `json:"id,omitempty"`
+	Name          string         `json:"name"`
+	Type          string         `json:"type"`
+	Status        CheckStatus    `json:"status"`
+	Output        string         `json:"output"`
+	Error         string         `json:"error,omitempty"`
+	Remediation   string         `json:"remediation,omitempty"`
+	DocURL        string         `json:"doc_url,omitempty"`
+	Expected      string         `json:"expected,omitempty"`
+	Actual        string         `json:"actual,omitempty"`
+	StartedAt     *time.Time     `json:"started_at,omitempty"`
+	FinishedAt    *time.Time     `json:"finished_at,omitempty"`
+	Reruns        int            `json:"reruns,omitempty"`
+	Resources     *ResourceUsage `json:"resources,omitempty"`
+	Informational bool           `json:"informational,omitempty"`
+	// Retry classifies results that did not succeed, empty if the check does not tell
+	Retry Retryability `json:"retry,omitempty"`
+	// Parent is the name of the check that produced the result, for checks producing
+	// multiple results
+	Parent string `json:"parent,omitempty"`
+
+	// Details holds structured facts gathered by the check, such as a resolved commit hash
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// CheckName returns the name of the configured check that produced the result
+func (r CheckResult) CheckName() string {
+	if r.Parent != "" {
+		return r.Parent
+	}
+	return r.Name
+}
+
+// ResourceUsage holds the resources consumed by the process of a command check
+type ResourceUsage struct {
+	UserSeconds   float64 `json:"user_seconds"`
+	SystemSeconds float64 `json:"system_seconds"`
+	// MaxRSSBytes is the peak resident set size, zero where the platform does not report it
+	MaxRSSBytes int64 `json:"max_rss_bytes,omitempty"`
 }
@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // OutputFormat represents the supported output formats
 type OutputFormat string
 
@@ -36,11 +38,15 @@ func SupportedOutputFormats() []OutputFormat {
 	}
 }
 
-// OutputMetadata contains metadata about the check execution
+// OutputMetadata contains metadata about the check execution. DateTime, Version and OS
+// describe the environment of the run and are empty when it is anonymized.
 type OutputMetadata struct {
-	DateTime string `json:"datetime"`
-	Version  string `json:"version"`
-	OS       string `json:"os"`
+	Title       string `json:"title,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	DateTime    string `json:"datetime,omitempty"`
+	Version     string `json:"version,omitempty"`
+	OS          string `json:"os,omitempty"`
 }
 
 // JSONOutput represents the full JSON output format including results and metadata
@@ -48,3 +54,50 @@ type JSONOutput struct {
 	Results  []CheckResult  `json:"results"`
 	Metadata OutputMetadata `json:"metadata"`
 }
+
+// JSONGroupedOutput is the JSON output format with the results nested by group, e.g.
+// "os" or "command", the same way they are grouped in the pretty and HTML output
+type JSONGroupedOutput struct {
+	Results  map[string][]CheckResult `json:"results"`
+	Metadata OutputMetadata           `json:"metadata"`
+}
+
+// Summary contains the aggregate counts of a check execution
+type Summary struct {
+	Total    int    `json:"total"`
+	Passed   int    `json:"passed"`
+	Failed   int    `json:"failed"`
+	Warnings int    `json:"warnings"`
+	Errors   int    `json:"errors"`
+	Skipped  int    `json:"skipped,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// NewSummary counts the results by status
+func NewSummary(results []CheckResult, duration time.Duration) Summary {
+	summary := Summary{
+		Total:    len(results),
+		Duration: duration.String(),
+	}
+	for _, result := range results {
+		switch result.Status {
+		case Success:
+			summary.Passed++
+		case Failure:
+			summary.Failed++
+		case Warning:
+			summary.Warnings++
+		case Skipped:
+			summary.Skipped++
+		default:
+			summary.Errors++
+		}
+	}
+	return summary
+}
+
+// JSONSummaryOutput represents the JSON output format when only the summary is requested
+type JSONSummaryOutput struct {
+	Summary  Summary        `json:"summary"`
+	Metadata OutputMetadata `json:"metadata"`
+}